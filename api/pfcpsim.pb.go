@@ -15,6 +15,8 @@ import (
 
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const (
@@ -24,6 +26,174 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type PdnType int32
+
+const (
+	PdnType_PDN_TYPE_IPV4   PdnType = 0
+	PdnType_PDN_TYPE_IPV6   PdnType = 1
+	PdnType_PDN_TYPE_IPV4V6 PdnType = 2
+)
+
+// Enum value maps for PdnType.
+var (
+	PdnType_name = map[int32]string{
+		0: "PDN_TYPE_IPV4",
+		1: "PDN_TYPE_IPV6",
+		2: "PDN_TYPE_IPV4V6",
+	}
+	PdnType_value = map[string]int32{
+		"PDN_TYPE_IPV4":   0,
+		"PDN_TYPE_IPV6":   1,
+		"PDN_TYPE_IPV4V6": 2,
+	}
+)
+
+func (x PdnType) Enum() *PdnType {
+	p := new(PdnType)
+	*p = x
+	return p
+}
+
+func (x PdnType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PdnType) Descriptor() protoreflect.EnumDescriptor {
+	return file_pfcpsim_proto_enumTypes[0].Descriptor()
+}
+
+func (PdnType) Type() protoreflect.EnumType {
+	return &file_pfcpsim_proto_enumTypes[0]
+}
+
+func (x PdnType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PdnType.Descriptor instead.
+func (PdnType) EnumDescriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{0}
+}
+
+// FailurePolicy selects how a batch session procedure handles the failure of a session.
+type FailurePolicy int32
+
+const (
+	// every session is attempted
+	FailurePolicy_FAILURE_POLICY_CONTINUE FailurePolicy = 0
+	// no session is attempted after the first failure. Requests already in flight are completed
+	FailurePolicy_FAILURE_POLICY_STOP FailurePolicy = 1
+	// like STOP, then the sessions established by the request are deleted. Supported by CreateSession only
+	FailurePolicy_FAILURE_POLICY_ROLLBACK FailurePolicy = 2
+)
+
+// Enum value maps for FailurePolicy.
+var (
+	FailurePolicy_name = map[int32]string{
+		0: "FAILURE_POLICY_CONTINUE",
+		1: "FAILURE_POLICY_STOP",
+		2: "FAILURE_POLICY_ROLLBACK",
+	}
+	FailurePolicy_value = map[string]int32{
+		"FAILURE_POLICY_CONTINUE": 0,
+		"FAILURE_POLICY_STOP":     1,
+		"FAILURE_POLICY_ROLLBACK": 2,
+	}
+)
+
+func (x FailurePolicy) Enum() *FailurePolicy {
+	p := new(FailurePolicy)
+	*p = x
+	return p
+}
+
+func (x FailurePolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FailurePolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_pfcpsim_proto_enumTypes[1].Descriptor()
+}
+
+func (FailurePolicy) Type() protoreflect.EnumType {
+	return &file_pfcpsim_proto_enumTypes[1]
+}
+
+func (x FailurePolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FailurePolicy.Descriptor instead.
+func (FailurePolicy) EnumDescriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{1}
+}
+
+type SessionOutcome int32
+
+const (
+	SessionOutcome_SESSION_OUTCOME_UNSPECIFIED SessionOutcome = 0
+	SessionOutcome_SESSION_OUTCOME_SUCCESS     SessionOutcome = 1
+	// the UPF answered with a Cause other than Request accepted
+	SessionOutcome_SESSION_OUTCOME_REJECTED SessionOutcome = 2
+	// no response was received after all retransmissions
+	SessionOutcome_SESSION_OUTCOME_TIMEOUT SessionOutcome = 3
+	// any other failure, e.g. an invalid response or an unknown session
+	SessionOutcome_SESSION_OUTCOME_FAILED SessionOutcome = 4
+	// not attempted because of a previous failure (see FailurePolicy)
+	SessionOutcome_SESSION_OUTCOME_SKIPPED SessionOutcome = 5
+	// established, then deleted because of a failure (see FailurePolicy)
+	SessionOutcome_SESSION_OUTCOME_ROLLED_BACK SessionOutcome = 6
+)
+
+// Enum value maps for SessionOutcome.
+var (
+	SessionOutcome_name = map[int32]string{
+		0: "SESSION_OUTCOME_UNSPECIFIED",
+		1: "SESSION_OUTCOME_SUCCESS",
+		2: "SESSION_OUTCOME_REJECTED",
+		3: "SESSION_OUTCOME_TIMEOUT",
+		4: "SESSION_OUTCOME_FAILED",
+		5: "SESSION_OUTCOME_SKIPPED",
+		6: "SESSION_OUTCOME_ROLLED_BACK",
+	}
+	SessionOutcome_value = map[string]int32{
+		"SESSION_OUTCOME_UNSPECIFIED": 0,
+		"SESSION_OUTCOME_SUCCESS":     1,
+		"SESSION_OUTCOME_REJECTED":    2,
+		"SESSION_OUTCOME_TIMEOUT":     3,
+		"SESSION_OUTCOME_FAILED":      4,
+		"SESSION_OUTCOME_SKIPPED":     5,
+		"SESSION_OUTCOME_ROLLED_BACK": 6,
+	}
+)
+
+func (x SessionOutcome) Enum() *SessionOutcome {
+	p := new(SessionOutcome)
+	*p = x
+	return p
+}
+
+func (x SessionOutcome) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SessionOutcome) Descriptor() protoreflect.EnumDescriptor {
+	return file_pfcpsim_proto_enumTypes[2].Descriptor()
+}
+
+func (SessionOutcome) Type() protoreflect.EnumType {
+	return &file_pfcpsim_proto_enumTypes[2]
+}
+
+func (x SessionOutcome) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SessionOutcome.Descriptor instead.
+func (SessionOutcome) EnumDescriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{2}
+}
+
 type CreateSessionRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -31,12 +201,26 @@ type CreateSessionRequest struct {
 
 	// count represents the number of session
 	Count int32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
-	// baseID is used to create incremental IDs for PDRs, FARs, QERs
-	BaseID        int32    `protobuf:"varint,2,opt,name=baseID,proto3" json:"baseID,omitempty"`
-	NodeBAddress  string   `protobuf:"bytes,3,opt,name=nodeBAddress,proto3" json:"nodeBAddress,omitempty"`
+	// baseID is the index of the first session, the following sessions are indexed baseID+1, baseID+2, ...
+	BaseID       int32  `protobuf:"varint,2,opt,name=baseID,proto3" json:"baseID,omitempty"`
+	NodeBAddress string `protobuf:"bytes,3,opt,name=nodeBAddress,proto3" json:"nodeBAddress,omitempty"`
+	// name of the IPv4 UE pool, or IPv4 network, from which UE addresses are allocated. Required with PDN
+	// types IPv4 and IPv4v6. A network that is not the network of an existing pool gets its own pool
 	UeAddressPool string   `protobuf:"bytes,4,opt,name=ueAddressPool,proto3" json:"ueAddressPool,omitempty"`
 	AppFilters    []string `protobuf:"bytes,5,rep,name=appFilters,proto3" json:"appFilters,omitempty"`
 	Qfi           int32    `protobuf:"varint,6,opt,name=qfi,proto3" json:"qfi,omitempty"` // Should be uint8
+	// name of the IPv6 UE pool, or IPv6 network, from which a /64 prefix is delegated to each UE. Required
+	// with PDN types IPv6 and IPv4v6
+	UeAddressPoolV6 string  `protobuf:"bytes,7,opt,name=ueAddressPoolV6,proto3" json:"ueAddressPoolV6,omitempty"`
+	PdnType         PdnType `protobuf:"varint,8,opt,name=pdnType,proto3,enum=api.PdnType" json:"pdnType,omitempty"`
+	// name of the session profile describing the rules of the sessions. The built-in "default" profile is used if empty
+	Profile       string        `protobuf:"bytes,9,opt,name=profile,proto3" json:"profile,omitempty"`
+	FailurePolicy FailurePolicy `protobuf:"varint,10,opt,name=failurePolicy,proto3,enum=api.FailurePolicy" json:"failurePolicy,omitempty"`
+	// let the UPF allocate the F-TEID of the uplink PDRs (F-TEID CHOOSE) instead of pfcpsim
+	UpfAllocatedTEID bool `protobuf:"varint,11,opt,name=upfAllocatedTEID,proto3" json:"upfAllocatedTEID,omitempty"`
+	// let the UPF allocate the UE addresses required by pdnType (CHV4/CHV6 flags) instead of pfcpsim.
+	// ueAddressPool and ueAddressPoolV6 are ignored.
+	UpfAllocatedUEAddress bool `protobuf:"varint,12,opt,name=upfAllocatedUEAddress,proto3" json:"upfAllocatedUEAddress,omitempty"`
 }
 
 func (x *CreateSessionRequest) Reset() {
@@ -111,6 +295,48 @@ func (x *CreateSessionRequest) GetQfi() int32 {
 	return 0
 }
 
+func (x *CreateSessionRequest) GetUeAddressPoolV6() string {
+	if x != nil {
+		return x.UeAddressPoolV6
+	}
+	return ""
+}
+
+func (x *CreateSessionRequest) GetPdnType() PdnType {
+	if x != nil {
+		return x.PdnType
+	}
+	return PdnType_PDN_TYPE_IPV4
+}
+
+func (x *CreateSessionRequest) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+func (x *CreateSessionRequest) GetFailurePolicy() FailurePolicy {
+	if x != nil {
+		return x.FailurePolicy
+	}
+	return FailurePolicy_FAILURE_POLICY_CONTINUE
+}
+
+func (x *CreateSessionRequest) GetUpfAllocatedTEID() bool {
+	if x != nil {
+		return x.UpfAllocatedTEID
+	}
+	return false
+}
+
+func (x *CreateSessionRequest) GetUpfAllocatedUEAddress() bool {
+	if x != nil {
+		return x.UpfAllocatedUEAddress
+	}
+	return false
+}
+
 type ModifySessionRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -118,13 +344,31 @@ type ModifySessionRequest struct {
 
 	// count represents the number of session
 	Count int32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
-	// baseID is used to create incremental IDs for PDRs, FARs, QERs
-	BaseID        int32    `protobuf:"varint,2,opt,name=baseID,proto3" json:"baseID,omitempty"`
-	NodeBAddress  string   `protobuf:"bytes,3,opt,name=nodeBAddress,proto3" json:"nodeBAddress,omitempty"`
-	UeAddressPool string   `protobuf:"bytes,4,opt,name=ueAddressPool,proto3" json:"ueAddressPool,omitempty"`
-	BufferFlag    bool     `protobuf:"varint,5,opt,name=bufferFlag,proto3" json:"bufferFlag,omitempty"`
-	NotifyCPFlag  bool     `protobuf:"varint,6,opt,name=notifyCPFlag,proto3" json:"notifyCPFlag,omitempty"`
-	AppFilters    []string `protobuf:"bytes,7,rep,name=appFilters,proto3" json:"appFilters,omitempty"`
+	// baseID is the index of the first session, the following sessions are indexed baseID+1, baseID+2, ...
+	BaseID        int32  `protobuf:"varint,2,opt,name=baseID,proto3" json:"baseID,omitempty"`
+	NodeBAddress  string `protobuf:"bytes,3,opt,name=nodeBAddress,proto3" json:"nodeBAddress,omitempty"`
+	UeAddressPool string `protobuf:"bytes,4,opt,name=ueAddressPool,proto3" json:"ueAddressPool,omitempty"`
+	BufferFlag    bool   `protobuf:"varint,5,opt,name=bufferFlag,proto3" json:"bufferFlag,omitempty"`
+	NotifyCPFlag  bool   `protobuf:"varint,6,opt,name=notifyCPFlag,proto3" json:"notifyCPFlag,omitempty"`
+	// Deprecated: ignored, the downlink FARs and URRs recorded for each session are updated
+	AppFilters    []string      `protobuf:"bytes,7,rep,name=appFilters,proto3" json:"appFilters,omitempty"`
+	FailurePolicy FailurePolicy `protobuf:"varint,8,opt,name=failurePolicy,proto3,enum=api.FailurePolicy" json:"failurePolicy,omitempty"`
+	// TEID of the gNB set in the downlink FARs. The session index is used if 0.
+	// The downlink FARs and URRs are updated if nodeBAddress, nodeBTEID, bufferFlag or notifyCPFlag is set,
+	// or if no other modification is requested
+	NodeBTEID uint32 `protobuf:"varint,9,opt,name=nodeBTEID,proto3" json:"nodeBTEID,omitempty"`
+	// QERs to update
+	QerUpdate *QERUpdate `protobuf:"bytes,10,opt,name=qerUpdate,proto3" json:"qerUpdate,omitempty"`
+	// application filters to add: an uplink and a downlink PDR with their application QERs are created,
+	// using the uplink F-TEID, the UE addresses and the FARs of the session
+	AddAppFilters []string `protobuf:"bytes,11,rep,name=addAppFilters,proto3" json:"addAppFilters,omitempty"`
+	// application filters to remove: the PDRs matching their SDF filter are removed, along with the rules
+	// no other PDR uses
+	RemoveAppFilters []string `protobuf:"bytes,12,rep,name=removeAppFilters,proto3" json:"removeAppFilters,omitempty"`
+	// ask the UPF for an immediate usage report of every URR of the session (Query URR)
+	QueryURR bool `protobuf:"varint,13,opt,name=queryURR,proto3" json:"queryURR,omitempty"`
+	// QFI of the application QERs created for addAppFilters
+	Qfi int32 `protobuf:"varint,14,opt,name=qfi,proto3" json:"qfi,omitempty"`
 }
 
 func (x *ModifySessionRequest) Reset() {
@@ -206,31 +450,86 @@ func (x *ModifySessionRequest) GetAppFilters() []string {
 	return nil
 }
 
-type ConfigureRequest struct {
+func (x *ModifySessionRequest) GetFailurePolicy() FailurePolicy {
+	if x != nil {
+		return x.FailurePolicy
+	}
+	return FailurePolicy_FAILURE_POLICY_CONTINUE
+}
+
+func (x *ModifySessionRequest) GetNodeBTEID() uint32 {
+	if x != nil {
+		return x.NodeBTEID
+	}
+	return 0
+}
+
+func (x *ModifySessionRequest) GetQerUpdate() *QERUpdate {
+	if x != nil {
+		return x.QerUpdate
+	}
+	return nil
+}
+
+func (x *ModifySessionRequest) GetAddAppFilters() []string {
+	if x != nil {
+		return x.AddAppFilters
+	}
+	return nil
+}
+
+func (x *ModifySessionRequest) GetRemoveAppFilters() []string {
+	if x != nil {
+		return x.RemoveAppFilters
+	}
+	return nil
+}
+
+func (x *ModifySessionRequest) GetQueryURR() bool {
+	if x != nil {
+		return x.QueryURR
+	}
+	return false
+}
+
+func (x *ModifySessionRequest) GetQfi() int32 {
+	if x != nil {
+		return x.Qfi
+	}
+	return 0
+}
+
+// QERUpdate changes the QERs of the modified sessions. Values left unset are not changed.
+type QERUpdate struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// the data-plane interface between UPF and gNodeB
-	UpfN3Address string `protobuf:"bytes,1,opt,name=upfN3Address,proto3" json:"upfN3Address,omitempty"`
-	// the PFCP agent server address
-	RemotePeerAddress string `protobuf:"bytes,3,opt,name=remotePeerAddress,proto3" json:"remotePeerAddress,omitempty"`
+	// IDs of the QERs to update. Every QER of the session is updated if empty
+	Ids []uint32 `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	// the MBR is replaced if uplinkMBR or downlinkMBR is set, the GBR if uplinkGBR or downlinkGBR is set
+	UplinkMBR   uint64 `protobuf:"varint,2,opt,name=uplinkMBR,proto3" json:"uplinkMBR,omitempty"`
+	DownlinkMBR uint64 `protobuf:"varint,3,opt,name=downlinkMBR,proto3" json:"downlinkMBR,omitempty"`
+	UplinkGBR   uint64 `protobuf:"varint,4,opt,name=uplinkGBR,proto3" json:"uplinkGBR,omitempty"`
+	DownlinkGBR uint64 `protobuf:"varint,5,opt,name=downlinkGBR,proto3" json:"downlinkGBR,omitempty"`
+	// "open" or "closed", both directions. Unchanged if empty
+	GateStatus string `protobuf:"bytes,6,opt,name=gateStatus,proto3" json:"gateStatus,omitempty"`
 }
 
-func (x *ConfigureRequest) Reset() {
-	*x = ConfigureRequest{}
+func (x *QERUpdate) Reset() {
+	*x = QERUpdate{}
 	mi := &file_pfcpsim_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ConfigureRequest) String() string {
+func (x *QERUpdate) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConfigureRequest) ProtoMessage() {}
+func (*QERUpdate) ProtoMessage() {}
 
-func (x *ConfigureRequest) ProtoReflect() protoreflect.Message {
+func (x *QERUpdate) ProtoReflect() protoreflect.Message {
 	mi := &file_pfcpsim_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -242,49 +541,99 @@ func (x *ConfigureRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConfigureRequest.ProtoReflect.Descriptor instead.
-func (*ConfigureRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use QERUpdate.ProtoReflect.Descriptor instead.
+func (*QERUpdate) Descriptor() ([]byte, []int) {
 	return file_pfcpsim_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *ConfigureRequest) GetUpfN3Address() string {
+func (x *QERUpdate) GetIds() []uint32 {
 	if x != nil {
-		return x.UpfN3Address
+		return x.Ids
 	}
-	return ""
+	return nil
 }
 
-func (x *ConfigureRequest) GetRemotePeerAddress() string {
+func (x *QERUpdate) GetUplinkMBR() uint64 {
 	if x != nil {
-		return x.RemotePeerAddress
+		return x.UplinkMBR
+	}
+	return 0
+}
+
+func (x *QERUpdate) GetDownlinkMBR() uint64 {
+	if x != nil {
+		return x.DownlinkMBR
+	}
+	return 0
+}
+
+func (x *QERUpdate) GetUplinkGBR() uint64 {
+	if x != nil {
+		return x.UplinkGBR
+	}
+	return 0
+}
+
+func (x *QERUpdate) GetDownlinkGBR() uint64 {
+	if x != nil {
+		return x.DownlinkGBR
+	}
+	return 0
+}
+
+func (x *QERUpdate) GetGateStatus() string {
+	if x != nil {
+		return x.GateStatus
 	}
 	return ""
 }
 
-type DeleteSessionRequest struct {
+type ConfigureRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Count int32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
-	// baseID is used to decide where to start deleting sessions
-	BaseID int32 `protobuf:"varint,2,opt,name=baseID,proto3" json:"baseID,omitempty"`
+	// the data-plane interface between UPF and gNodeB
+	UpfN3Address string `protobuf:"bytes,1,opt,name=upfN3Address,proto3" json:"upfN3Address,omitempty"`
+	// the PFCP agent server address
+	RemotePeerAddress string `protobuf:"bytes,3,opt,name=remotePeerAddress,proto3" json:"remotePeerAddress,omitempty"`
+	// maximum number of session requests waiting for a response at the same time (default: 1)
+	MaxInFlight int32 `protobuf:"varint,4,opt,name=maxInFlight,proto3" json:"maxInFlight,omitempty"`
+	// T1, the time in milliseconds to wait for a response before retransmitting a request (default: 5000)
+	T1 int32 `protobuf:"varint,5,opt,name=t1,proto3" json:"t1,omitempty"`
+	// N1, the number of retransmissions before a request is considered failed (default: 0)
+	N1 *int32 `protobuf:"varint,6,opt,name=n1,proto3,oneof" json:"n1,omitempty"`
+	// re-associate and re-establish active sessions when the remote peer restarts
+	AutoRecovery bool `protobuf:"varint,7,opt,name=autoRecovery,proto3" json:"autoRecovery,omitempty"`
+	// ranges the uplink TEIDs are allocated from, each one a single TEID or a range (e.g. 1000-1999).
+	// Unchanged if empty, by default TEIDs are allocated from 1 to 4294967295
+	TeidRanges []string `protobuf:"bytes,8,rep,name=teidRanges,proto3" json:"teidRanges,omitempty"`
+	// emulate idle UEs: a Downlink Data Report is followed, after pagingDelay, by a Session Modification
+	// switching the downlink FARs back to forward towards the last known gNB tunnel
+	PagingEmulation bool                 `protobuf:"varint,9,opt,name=pagingEmulation,proto3" json:"pagingEmulation,omitempty"`
+	PagingDelay     *durationpb.Duration `protobuf:"bytes,10,opt,name=pagingDelay,proto3" json:"pagingDelay,omitempty"`
+	// wait for the UPF to initiate the Association Setup instead of sending the Association Setup Request
+	PassiveAssociation bool `protobuf:"varint,11,opt,name=passiveAssociation,proto3" json:"passiveAssociation,omitempty"`
+	// Node ID accepted from the UPF in passive association mode, any if empty
+	UpfNodeID string `protobuf:"bytes,12,opt,name=upfNodeID,proto3" json:"upfNodeID,omitempty"`
+	// CP function features advertised to the UPF in the Association Setup (e.g. LOAD, OVRL, BUNDL)
+	CpFunctionFeatures []string `protobuf:"bytes,13,rep,name=cpFunctionFeatures,proto3" json:"cpFunctionFeatures,omitempty"`
 }
 
-func (x *DeleteSessionRequest) Reset() {
-	*x = DeleteSessionRequest{}
+func (x *ConfigureRequest) Reset() {
+	*x = ConfigureRequest{}
 	mi := &file_pfcpsim_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteSessionRequest) String() string {
+func (x *ConfigureRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteSessionRequest) ProtoMessage() {}
+func (*ConfigureRequest) ProtoMessage() {}
 
-func (x *DeleteSessionRequest) ProtoReflect() protoreflect.Message {
+func (x *ConfigureRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pfcpsim_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -296,45 +645,119 @@ func (x *DeleteSessionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteSessionRequest.ProtoReflect.Descriptor instead.
-func (*DeleteSessionRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ConfigureRequest.ProtoReflect.Descriptor instead.
+func (*ConfigureRequest) Descriptor() ([]byte, []int) {
 	return file_pfcpsim_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *DeleteSessionRequest) GetCount() int32 {
+func (x *ConfigureRequest) GetUpfN3Address() string {
 	if x != nil {
-		return x.Count
+		return x.UpfN3Address
+	}
+	return ""
+}
+
+func (x *ConfigureRequest) GetRemotePeerAddress() string {
+	if x != nil {
+		return x.RemotePeerAddress
+	}
+	return ""
+}
+
+func (x *ConfigureRequest) GetMaxInFlight() int32 {
+	if x != nil {
+		return x.MaxInFlight
 	}
 	return 0
 }
 
-func (x *DeleteSessionRequest) GetBaseID() int32 {
+func (x *ConfigureRequest) GetT1() int32 {
 	if x != nil {
-		return x.BaseID
+		return x.T1
 	}
 	return 0
 }
 
-type EmptyRequest struct {
+func (x *ConfigureRequest) GetN1() int32 {
+	if x != nil && x.N1 != nil {
+		return *x.N1
+	}
+	return 0
+}
+
+func (x *ConfigureRequest) GetAutoRecovery() bool {
+	if x != nil {
+		return x.AutoRecovery
+	}
+	return false
+}
+
+func (x *ConfigureRequest) GetTeidRanges() []string {
+	if x != nil {
+		return x.TeidRanges
+	}
+	return nil
+}
+
+func (x *ConfigureRequest) GetPagingEmulation() bool {
+	if x != nil {
+		return x.PagingEmulation
+	}
+	return false
+}
+
+func (x *ConfigureRequest) GetPagingDelay() *durationpb.Duration {
+	if x != nil {
+		return x.PagingDelay
+	}
+	return nil
+}
+
+func (x *ConfigureRequest) GetPassiveAssociation() bool {
+	if x != nil {
+		return x.PassiveAssociation
+	}
+	return false
+}
+
+func (x *ConfigureRequest) GetUpfNodeID() string {
+	if x != nil {
+		return x.UpfNodeID
+	}
+	return ""
+}
+
+func (x *ConfigureRequest) GetCpFunctionFeatures() []string {
+	if x != nil {
+		return x.CpFunctionFeatures
+	}
+	return nil
+}
+
+type UpdateAssociationRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// CP function features advertised to the UPF (e.g. LOAD, OVRL, BUNDL). The CP Function Features IE is not
+	// sent if empty
+	CpFunctionFeatures []string `protobuf:"bytes,1,rep,name=cpFunctionFeatures,proto3" json:"cpFunctionFeatures,omitempty"`
 }
 
-func (x *EmptyRequest) Reset() {
-	*x = EmptyRequest{}
+func (x *UpdateAssociationRequest) Reset() {
+	*x = UpdateAssociationRequest{}
 	mi := &file_pfcpsim_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EmptyRequest) String() string {
+func (x *UpdateAssociationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EmptyRequest) ProtoMessage() {}
+func (*UpdateAssociationRequest) ProtoMessage() {}
 
-func (x *EmptyRequest) ProtoReflect() protoreflect.Message {
+func (x *UpdateAssociationRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pfcpsim_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -346,34 +769,54 @@ func (x *EmptyRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EmptyRequest.ProtoReflect.Descriptor instead.
-func (*EmptyRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateAssociationRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAssociationRequest) Descriptor() ([]byte, []int) {
 	return file_pfcpsim_proto_rawDescGZIP(), []int{4}
 }
 
-type Response struct {
+func (x *UpdateAssociationRequest) GetCpFunctionFeatures() []string {
+	if x != nil {
+		return x.CpFunctionFeatures
+	}
+	return nil
+}
+
+// AssociationInfo is the state of the association negotiated with the UPF
+type AssociationInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	StatusCode int32  `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
-	Message    string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	PeerNodeID string `protobuf:"bytes,1,opt,name=peerNodeID,proto3" json:"peerNodeID,omitempty"`
+	// octets of the last UP Function Features IE received from the UPF
+	UpFunctionFeatures []byte `protobuf:"bytes,2,opt,name=upFunctionFeatures,proto3" json:"upFunctionFeatures,omitempty"`
+	// octets of the last CP Function Features IE sent to the UPF
+	CpFunctionFeatures []byte `protobuf:"bytes,3,opt,name=cpFunctionFeatures,proto3" json:"cpFunctionFeatures,omitempty"`
+	// the UPF asked, with an Association Update Request, to release the association within gracefulReleasePeriod
+	ReleaseRequested      bool                 `protobuf:"varint,4,opt,name=releaseRequested,proto3" json:"releaseRequested,omitempty"`
+	GracefulReleasePeriod *durationpb.Duration `protobuf:"bytes,5,opt,name=gracefulReleasePeriod,proto3" json:"gracefulReleasePeriod,omitempty"`
+	// time of the last Association Setup or Update
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	// names of the UP function features advertised by the UPF (e.g. FTUP, UEIP)
+	UpFeatures        []string        `protobuf:"bytes,7,rep,name=upFeatures,proto3" json:"upFeatures,omitempty"`
+	UpIPResources     []*UPIPResource `protobuf:"bytes,8,rep,name=upIPResources,proto3" json:"upIPResources,omitempty"`
+	AlternativeSMFIPs []string        `protobuf:"bytes,9,rep,name=alternativeSMFIPs,proto3" json:"alternativeSMFIPs,omitempty"`
 }
 
-func (x *Response) Reset() {
-	*x = Response{}
+func (x *AssociationInfo) Reset() {
+	*x = AssociationInfo{}
 	mi := &file_pfcpsim_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Response) String() string {
+func (x *AssociationInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Response) ProtoMessage() {}
+func (*AssociationInfo) ProtoMessage() {}
 
-func (x *Response) ProtoReflect() protoreflect.Message {
+func (x *AssociationInfo) ProtoReflect() protoreflect.Message {
 	mi := &file_pfcpsim_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -385,96 +828,2323 @@ func (x *Response) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Response.ProtoReflect.Descriptor instead.
-func (*Response) Descriptor() ([]byte, []int) {
+// Deprecated: Use AssociationInfo.ProtoReflect.Descriptor instead.
+func (*AssociationInfo) Descriptor() ([]byte, []int) {
 	return file_pfcpsim_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *Response) GetStatusCode() int32 {
+func (x *AssociationInfo) GetPeerNodeID() string {
+	if x != nil {
+		return x.PeerNodeID
+	}
+	return ""
+}
+
+func (x *AssociationInfo) GetUpFunctionFeatures() []byte {
+	if x != nil {
+		return x.UpFunctionFeatures
+	}
+	return nil
+}
+
+func (x *AssociationInfo) GetCpFunctionFeatures() []byte {
+	if x != nil {
+		return x.CpFunctionFeatures
+	}
+	return nil
+}
+
+func (x *AssociationInfo) GetReleaseRequested() bool {
+	if x != nil {
+		return x.ReleaseRequested
+	}
+	return false
+}
+
+func (x *AssociationInfo) GetGracefulReleasePeriod() *durationpb.Duration {
+	if x != nil {
+		return x.GracefulReleasePeriod
+	}
+	return nil
+}
+
+func (x *AssociationInfo) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *AssociationInfo) GetUpFeatures() []string {
+	if x != nil {
+		return x.UpFeatures
+	}
+	return nil
+}
+
+func (x *AssociationInfo) GetUpIPResources() []*UPIPResource {
+	if x != nil {
+		return x.UpIPResources
+	}
+	return nil
+}
+
+func (x *AssociationInfo) GetAlternativeSMFIPs() []string {
+	if x != nil {
+		return x.AlternativeSMFIPs
+	}
+	return nil
+}
+
+// UPIPResource is a User Plane IP Resource Information IE received from the UPF
+type UPIPResource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ipv4Address string `protobuf:"bytes,1,opt,name=ipv4Address,proto3" json:"ipv4Address,omitempty"`
+	Ipv6Address string `protobuf:"bytes,2,opt,name=ipv6Address,proto3" json:"ipv6Address,omitempty"`
+	// number of bits of the TEIDs set to teidRange, 0 if no range is advertised
+	TeidRangeIndication uint32 `protobuf:"varint,3,opt,name=teidRangeIndication,proto3" json:"teidRangeIndication,omitempty"`
+	TeidRange           uint32 `protobuf:"varint,4,opt,name=teidRange,proto3" json:"teidRange,omitempty"`
+	NetworkInstance     string `protobuf:"bytes,5,opt,name=networkInstance,proto3" json:"networkInstance,omitempty"`
+	SourceInterface     uint32 `protobuf:"varint,6,opt,name=sourceInterface,proto3" json:"sourceInterface,omitempty"`
+}
+
+func (x *UPIPResource) Reset() {
+	*x = UPIPResource{}
+	mi := &file_pfcpsim_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UPIPResource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UPIPResource) ProtoMessage() {}
+
+func (x *UPIPResource) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UPIPResource.ProtoReflect.Descriptor instead.
+func (*UPIPResource) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UPIPResource) GetIpv4Address() string {
+	if x != nil {
+		return x.Ipv4Address
+	}
+	return ""
+}
+
+func (x *UPIPResource) GetIpv6Address() string {
+	if x != nil {
+		return x.Ipv6Address
+	}
+	return ""
+}
+
+func (x *UPIPResource) GetTeidRangeIndication() uint32 {
+	if x != nil {
+		return x.TeidRangeIndication
+	}
+	return 0
+}
+
+func (x *UPIPResource) GetTeidRange() uint32 {
+	if x != nil {
+		return x.TeidRange
+	}
+	return 0
+}
+
+func (x *UPIPResource) GetNetworkInstance() string {
+	if x != nil {
+		return x.NetworkInstance
+	}
+	return ""
+}
+
+func (x *UPIPResource) GetSourceInterface() uint32 {
+	if x != nil {
+		return x.SourceInterface
+	}
+	return 0
+}
+
+type GetAssociationResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StatusCode  int32            `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	Message     string           `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Association *AssociationInfo `protobuf:"bytes,3,opt,name=association,proto3" json:"association,omitempty"`
+}
+
+func (x *GetAssociationResponse) Reset() {
+	*x = GetAssociationResponse{}
+	mi := &file_pfcpsim_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAssociationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAssociationResponse) ProtoMessage() {}
+
+func (x *GetAssociationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAssociationResponse.ProtoReflect.Descriptor instead.
+func (*GetAssociationResponse) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetAssociationResponse) GetStatusCode() int32 {
 	if x != nil {
 		return x.StatusCode
 	}
 	return 0
 }
 
-func (x *Response) GetMessage() string {
+func (x *GetAssociationResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetAssociationResponse) GetAssociation() *AssociationInfo {
+	if x != nil {
+		return x.Association
+	}
+	return nil
+}
+
+type UpdateAssociationResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StatusCode  int32            `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	Message     string           `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Association *AssociationInfo `protobuf:"bytes,3,opt,name=association,proto3" json:"association,omitempty"`
+}
+
+func (x *UpdateAssociationResponse) Reset() {
+	*x = UpdateAssociationResponse{}
+	mi := &file_pfcpsim_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAssociationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAssociationResponse) ProtoMessage() {}
+
+func (x *UpdateAssociationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAssociationResponse.ProtoReflect.Descriptor instead.
+func (*UpdateAssociationResponse) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateAssociationResponse) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *UpdateAssociationResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
+func (x *UpdateAssociationResponse) GetAssociation() *AssociationInfo {
+	if x != nil {
+		return x.Association
+	}
+	return nil
+}
+
+type DeleteSessionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Count int32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	// baseID is used to decide where to start deleting sessions
+	BaseID        int32         `protobuf:"varint,2,opt,name=baseID,proto3" json:"baseID,omitempty"`
+	FailurePolicy FailurePolicy `protobuf:"varint,3,opt,name=failurePolicy,proto3,enum=api.FailurePolicy" json:"failurePolicy,omitempty"`
+}
+
+func (x *DeleteSessionRequest) Reset() {
+	*x = DeleteSessionRequest{}
+	mi := &file_pfcpsim_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSessionRequest) ProtoMessage() {}
+
+func (x *DeleteSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSessionRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSessionRequest) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteSessionRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *DeleteSessionRequest) GetBaseID() int32 {
+	if x != nil {
+		return x.BaseID
+	}
+	return 0
+}
+
+func (x *DeleteSessionRequest) GetFailurePolicy() FailurePolicy {
+	if x != nil {
+		return x.FailurePolicy
+	}
+	return FailurePolicy_FAILURE_POLICY_CONTINUE
+}
+
+type GetUsageReportsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Count int32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	// baseID is used to decide where to start retrieving usage reports
+	BaseID int32 `protobuf:"varint,2,opt,name=baseID,proto3" json:"baseID,omitempty"`
+}
+
+func (x *GetUsageReportsRequest) Reset() {
+	*x = GetUsageReportsRequest{}
+	mi := &file_pfcpsim_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsageReportsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageReportsRequest) ProtoMessage() {}
+
+func (x *GetUsageReportsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageReportsRequest.ProtoReflect.Descriptor instead.
+func (*GetUsageReportsRequest) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetUsageReportsRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *GetUsageReportsRequest) GetBaseID() int32 {
+	if x != nil {
+		return x.BaseID
+	}
+	return 0
+}
+
+type VolumeMeasurement struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TotalVolume     uint64 `protobuf:"varint,1,opt,name=totalVolume,proto3" json:"totalVolume,omitempty"`
+	UplinkVolume    uint64 `protobuf:"varint,2,opt,name=uplinkVolume,proto3" json:"uplinkVolume,omitempty"`
+	DownlinkVolume  uint64 `protobuf:"varint,3,opt,name=downlinkVolume,proto3" json:"downlinkVolume,omitempty"`
+	TotalPackets    uint64 `protobuf:"varint,4,opt,name=totalPackets,proto3" json:"totalPackets,omitempty"`
+	UplinkPackets   uint64 `protobuf:"varint,5,opt,name=uplinkPackets,proto3" json:"uplinkPackets,omitempty"`
+	DownlinkPackets uint64 `protobuf:"varint,6,opt,name=downlinkPackets,proto3" json:"downlinkPackets,omitempty"`
+}
+
+func (x *VolumeMeasurement) Reset() {
+	*x = VolumeMeasurement{}
+	mi := &file_pfcpsim_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VolumeMeasurement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VolumeMeasurement) ProtoMessage() {}
+
+func (x *VolumeMeasurement) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VolumeMeasurement.ProtoReflect.Descriptor instead.
+func (*VolumeMeasurement) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *VolumeMeasurement) GetTotalVolume() uint64 {
+	if x != nil {
+		return x.TotalVolume
+	}
+	return 0
+}
+
+func (x *VolumeMeasurement) GetUplinkVolume() uint64 {
+	if x != nil {
+		return x.UplinkVolume
+	}
+	return 0
+}
+
+func (x *VolumeMeasurement) GetDownlinkVolume() uint64 {
+	if x != nil {
+		return x.DownlinkVolume
+	}
+	return 0
+}
+
+func (x *VolumeMeasurement) GetTotalPackets() uint64 {
+	if x != nil {
+		return x.TotalPackets
+	}
+	return 0
+}
+
+func (x *VolumeMeasurement) GetUplinkPackets() uint64 {
+	if x != nil {
+		return x.UplinkPackets
+	}
+	return 0
+}
+
+func (x *VolumeMeasurement) GetDownlinkPackets() uint64 {
+	if x != nil {
+		return x.DownlinkPackets
+	}
+	return 0
+}
+
+type UsageReport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UrrID  uint32 `protobuf:"varint,1,opt,name=urrID,proto3" json:"urrID,omitempty"`
+	UrSeqn uint32 `protobuf:"varint,2,opt,name=urSeqn,proto3" json:"urSeqn,omitempty"`
+	// names of the Usage Report Trigger flags set by the UPF (e.g. PERIO, VOLTH)
+	Triggers []string `protobuf:"bytes,3,rep,name=triggers,proto3" json:"triggers,omitempty"`
+	// not set if the report does not carry a Volume Measurement
+	Volume     *VolumeMeasurement     `protobuf:"bytes,4,opt,name=volume,proto3" json:"volume,omitempty"`
+	Duration   *durationpb.Duration   `protobuf:"bytes,5,opt,name=duration,proto3" json:"duration,omitempty"`
+	StartTime  *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	EndTime    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=endTime,proto3" json:"endTime,omitempty"`
+	ReceivedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=receivedAt,proto3" json:"receivedAt,omitempty"`
+}
+
+func (x *UsageReport) Reset() {
+	*x = UsageReport{}
+	mi := &file_pfcpsim_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UsageReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsageReport) ProtoMessage() {}
+
+func (x *UsageReport) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsageReport.ProtoReflect.Descriptor instead.
+func (*UsageReport) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UsageReport) GetUrrID() uint32 {
+	if x != nil {
+		return x.UrrID
+	}
+	return 0
+}
+
+func (x *UsageReport) GetUrSeqn() uint32 {
+	if x != nil {
+		return x.UrSeqn
+	}
+	return 0
+}
+
+func (x *UsageReport) GetTriggers() []string {
+	if x != nil {
+		return x.Triggers
+	}
+	return nil
+}
+
+func (x *UsageReport) GetVolume() *VolumeMeasurement {
+	if x != nil {
+		return x.Volume
+	}
+	return nil
+}
+
+func (x *UsageReport) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *UsageReport) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *UsageReport) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *UsageReport) GetReceivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReceivedAt
+	}
+	return nil
+}
+
+type SessionUsageReports struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        int32  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	LocalSEID uint64 `protobuf:"varint,2,opt,name=localSEID,proto3" json:"localSEID,omitempty"`
+	// reports received for the session, oldest first
+	Reports []*UsageReport `protobuf:"bytes,3,rep,name=reports,proto3" json:"reports,omitempty"`
+}
+
+func (x *SessionUsageReports) Reset() {
+	*x = SessionUsageReports{}
+	mi := &file_pfcpsim_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionUsageReports) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionUsageReports) ProtoMessage() {}
+
+func (x *SessionUsageReports) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionUsageReports.ProtoReflect.Descriptor instead.
+func (*SessionUsageReports) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SessionUsageReports) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SessionUsageReports) GetLocalSEID() uint64 {
+	if x != nil {
+		return x.LocalSEID
+	}
+	return 0
+}
+
+func (x *SessionUsageReports) GetReports() []*UsageReport {
+	if x != nil {
+		return x.Reports
+	}
+	return nil
+}
+
+type GetUsageReportsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StatusCode int32                  `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	Message    string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Sessions   []*SessionUsageReports `protobuf:"bytes,3,rep,name=sessions,proto3" json:"sessions,omitempty"`
+}
+
+func (x *GetUsageReportsResponse) Reset() {
+	*x = GetUsageReportsResponse{}
+	mi := &file_pfcpsim_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsageReportsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageReportsResponse) ProtoMessage() {}
+
+func (x *GetUsageReportsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageReportsResponse.ProtoReflect.Descriptor instead.
+func (*GetUsageReportsResponse) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetUsageReportsResponse) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *GetUsageReportsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetUsageReportsResponse) GetSessions() []*SessionUsageReports {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type SessionInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// id is the index the session was created with (see baseID)
+	Id        int32  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	LocalSEID uint64 `protobuf:"varint,2,opt,name=localSEID,proto3" json:"localSEID,omitempty"`
+	PeerSEID  uint64 `protobuf:"varint,3,opt,name=peerSEID,proto3" json:"peerSEID,omitempty"`
+	// UE IPv4 address and/or UE IPv6 prefix in CIDR notation
+	UeAddresses []string `protobuf:"bytes,4,rep,name=ueAddresses,proto3" json:"ueAddresses,omitempty"`
+	UplinkTEID  uint32   `protobuf:"varint,5,opt,name=uplinkTEID,proto3" json:"uplinkTEID,omitempty"`
+	// set once the session is modified with the gNB address
+	NodeBAddress string                 `protobuf:"bytes,6,opt,name=nodeBAddress,proto3" json:"nodeBAddress,omitempty"`
+	PdrIDs       []uint32               `protobuf:"varint,7,rep,packed,name=pdrIDs,proto3" json:"pdrIDs,omitempty"`
+	FarIDs       []uint32               `protobuf:"varint,8,rep,packed,name=farIDs,proto3" json:"farIDs,omitempty"`
+	QerIDs       []uint32               `protobuf:"varint,9,rep,packed,name=qerIDs,proto3" json:"qerIDs,omitempty"`
+	UrrIDs       []uint32               `protobuf:"varint,10,rep,packed,name=urrIDs,proto3" json:"urrIDs,omitempty"`
+	CreatedAt    *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	ModifiedAt   *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=modifiedAt,proto3" json:"modifiedAt,omitempty"`
+	// set once the session is modified with the gNB TEID
+	NodeBTEID uint32 `protobuf:"varint,13,opt,name=nodeBTEID,proto3" json:"nodeBTEID,omitempty"`
+	// pagings emulated after Downlink Data Reports, oldest first
+	Pagings []*Paging `protobuf:"bytes,14,rep,name=pagings,proto3" json:"pagings,omitempty"`
+	// the UPF released the association, and the session with it
+	Released bool `protobuf:"varint,15,opt,name=released,proto3" json:"released,omitempty"`
+	// the UPF reported a failure of the user plane path towards the gNB, not recovered yet
+	UserPlanePathFailure bool `protobuf:"varint,16,opt,name=userPlanePathFailure,proto3" json:"userPlanePathFailure,omitempty"`
+}
+
+func (x *SessionInfo) Reset() {
+	*x = SessionInfo{}
+	mi := &file_pfcpsim_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionInfo) ProtoMessage() {}
+
+func (x *SessionInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionInfo.ProtoReflect.Descriptor instead.
+func (*SessionInfo) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SessionInfo) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SessionInfo) GetLocalSEID() uint64 {
+	if x != nil {
+		return x.LocalSEID
+	}
+	return 0
+}
+
+func (x *SessionInfo) GetPeerSEID() uint64 {
+	if x != nil {
+		return x.PeerSEID
+	}
+	return 0
+}
+
+func (x *SessionInfo) GetUeAddresses() []string {
+	if x != nil {
+		return x.UeAddresses
+	}
+	return nil
+}
+
+func (x *SessionInfo) GetUplinkTEID() uint32 {
+	if x != nil {
+		return x.UplinkTEID
+	}
+	return 0
+}
+
+func (x *SessionInfo) GetNodeBAddress() string {
+	if x != nil {
+		return x.NodeBAddress
+	}
+	return ""
+}
+
+func (x *SessionInfo) GetPdrIDs() []uint32 {
+	if x != nil {
+		return x.PdrIDs
+	}
+	return nil
+}
+
+func (x *SessionInfo) GetFarIDs() []uint32 {
+	if x != nil {
+		return x.FarIDs
+	}
+	return nil
+}
+
+func (x *SessionInfo) GetQerIDs() []uint32 {
+	if x != nil {
+		return x.QerIDs
+	}
+	return nil
+}
+
+func (x *SessionInfo) GetUrrIDs() []uint32 {
+	if x != nil {
+		return x.UrrIDs
+	}
+	return nil
+}
+
+func (x *SessionInfo) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *SessionInfo) GetModifiedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ModifiedAt
+	}
+	return nil
+}
+
+func (x *SessionInfo) GetNodeBTEID() uint32 {
+	if x != nil {
+		return x.NodeBTEID
+	}
+	return 0
+}
+
+func (x *SessionInfo) GetPagings() []*Paging {
+	if x != nil {
+		return x.Pagings
+	}
+	return nil
+}
+
+func (x *SessionInfo) GetReleased() bool {
+	if x != nil {
+		return x.Released
+	}
+	return false
+}
+
+func (x *SessionInfo) GetUserPlanePathFailure() bool {
+	if x != nil {
+		return x.UserPlanePathFailure
+	}
+	return false
+}
+
+// Paging is the emulation of the paging of an idle UE, triggered by a Downlink Data Report.
+type Paging struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReportedAt *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=reportedAt,proto3" json:"reportedAt,omitempty"`
+	// the time the downlink FARs were switched back to forward
+	CompletedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=completedAt,proto3" json:"completedAt,omitempty"`
+	// end-to-end paging latency, including the paging delay
+	Latency *durationpb.Duration `protobuf:"bytes,3,opt,name=latency,proto3" json:"latency,omitempty"`
+}
+
+func (x *Paging) Reset() {
+	*x = Paging{}
+	mi := &file_pfcpsim_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Paging) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Paging) ProtoMessage() {}
+
+func (x *Paging) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Paging.ProtoReflect.Descriptor instead.
+func (*Paging) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Paging) GetReportedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReportedAt
+	}
+	return nil
+}
+
+func (x *Paging) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *Paging) GetLatency() *durationpb.Duration {
+	if x != nil {
+		return x.Latency
+	}
+	return nil
+}
+
+type ListSessionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StatusCode int32          `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	Message    string         `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Sessions   []*SessionInfo `protobuf:"bytes,3,rep,name=sessions,proto3" json:"sessions,omitempty"`
+}
+
+func (x *ListSessionsResponse) Reset() {
+	*x = ListSessionsResponse{}
+	mi := &file_pfcpsim_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsResponse) ProtoMessage() {}
+
+func (x *ListSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsResponse.ProtoReflect.Descriptor instead.
+func (*ListSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListSessionsResponse) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *ListSessionsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListSessionsResponse) GetSessions() []*SessionInfo {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type GetSessionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetSessionRequest) Reset() {
+	*x = GetSessionRequest{}
+	mi := &file_pfcpsim_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSessionRequest) ProtoMessage() {}
+
+func (x *GetSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSessionRequest.ProtoReflect.Descriptor instead.
+func (*GetSessionRequest) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetSessionRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetSessionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StatusCode int32        `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	Message    string       `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Session    *SessionInfo `protobuf:"bytes,3,opt,name=session,proto3" json:"session,omitempty"`
+}
+
+func (x *GetSessionResponse) Reset() {
+	*x = GetSessionResponse{}
+	mi := &file_pfcpsim_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSessionResponse) ProtoMessage() {}
+
+func (x *GetSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSessionResponse.ProtoReflect.Descriptor instead.
+func (*GetSessionResponse) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetSessionResponse) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *GetSessionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetSessionResponse) GetSession() *SessionInfo {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+type WatchEventsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// types selects the events to receive (e.g. SessionReport, AssociationDown). All events are sent if empty.
+	Types []string `protobuf:"bytes,1,rep,name=types,proto3" json:"types,omitempty"`
+}
+
+func (x *WatchEventsRequest) Reset() {
+	*x = WatchEventsRequest{}
+	mi := &file_pfcpsim_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchEventsRequest) ProtoMessage() {}
+
+func (x *WatchEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchEventsRequest.ProtoReflect.Descriptor instead.
+func (*WatchEventsRequest) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *WatchEventsRequest) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+type Event struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type      string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// name of the PFCP message that raised the event, if any
+	MessageType    string `protobuf:"bytes,3,opt,name=messageType,proto3" json:"messageType,omitempty"`
+	SequenceNumber uint32 `protobuf:"varint,4,opt,name=sequenceNumber,proto3" json:"sequenceNumber,omitempty"`
+	Seid           uint64 `protobuf:"varint,5,opt,name=seid,proto3" json:"seid,omitempty"`
+	Details        string `protobuf:"bytes,6,opt,name=details,proto3" json:"details,omitempty"`
+	// addresses of the remote GTP-U peers of a user plane path failure or recovery
+	RemoteGTPUPeers []string `protobuf:"bytes,7,rep,name=remoteGTPUPeers,proto3" json:"remoteGTPUPeers,omitempty"`
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_pfcpsim_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Event) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Event) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *Event) GetMessageType() string {
+	if x != nil {
+		return x.MessageType
+	}
+	return ""
+}
+
+func (x *Event) GetSequenceNumber() uint32 {
+	if x != nil {
+		return x.SequenceNumber
+	}
+	return 0
+}
+
+func (x *Event) GetSeid() uint64 {
+	if x != nil {
+		return x.Seid
+	}
+	return 0
+}
+
+func (x *Event) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+func (x *Event) GetRemoteGTPUPeers() []string {
+	if x != nil {
+		return x.RemoteGTPUPeers
+	}
+	return nil
+}
+
+type EmptyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *EmptyRequest) Reset() {
+	*x = EmptyRequest{}
+	mi := &file_pfcpsim_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmptyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmptyRequest) ProtoMessage() {}
+
+func (x *EmptyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmptyRequest.ProtoReflect.Descriptor instead.
+func (*EmptyRequest) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{22}
+}
+
+type Response struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StatusCode int32  `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	Message    string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *Response) Reset() {
+	*x = Response{}
+	mi := &file_pfcpsim_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Response) ProtoMessage() {}
+
+func (x *Response) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Response.ProtoReflect.Descriptor instead.
+func (*Response) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *Response) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *Response) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type FSEID struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Seid    uint64 `protobuf:"varint,1,opt,name=seid,proto3" json:"seid,omitempty"`
+	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+}
+
+func (x *FSEID) Reset() {
+	*x = FSEID{}
+	mi := &file_pfcpsim_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FSEID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FSEID) ProtoMessage() {}
+
+func (x *FSEID) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FSEID.ProtoReflect.Descriptor instead.
+func (*FSEID) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *FSEID) GetSeid() uint64 {
+	if x != nil {
+		return x.Seid
+	}
+	return 0
+}
+
+func (x *FSEID) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type CreatedPDR struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PdrID uint32 `protobuf:"varint,1,opt,name=pdrID,proto3" json:"pdrID,omitempty"`
+	// F-TEID allocated by the UPF
+	Teid        uint32 `protobuf:"varint,2,opt,name=teid,proto3" json:"teid,omitempty"`
+	Ipv4Address string `protobuf:"bytes,3,opt,name=ipv4Address,proto3" json:"ipv4Address,omitempty"`
+	Ipv6Address string `protobuf:"bytes,4,opt,name=ipv6Address,proto3" json:"ipv6Address,omitempty"`
+	// UE IPv4 address and UE IPv6 prefix in CIDR notation allocated by the UPF
+	UeAddress    string `protobuf:"bytes,5,opt,name=ueAddress,proto3" json:"ueAddress,omitempty"`
+	UeIPv6Prefix string `protobuf:"bytes,6,opt,name=ueIPv6Prefix,proto3" json:"ueIPv6Prefix,omitempty"`
+}
+
+func (x *CreatedPDR) Reset() {
+	*x = CreatedPDR{}
+	mi := &file_pfcpsim_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatedPDR) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatedPDR) ProtoMessage() {}
+
+func (x *CreatedPDR) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatedPDR.ProtoReflect.Descriptor instead.
+func (*CreatedPDR) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CreatedPDR) GetPdrID() uint32 {
+	if x != nil {
+		return x.PdrID
+	}
+	return 0
+}
+
+func (x *CreatedPDR) GetTeid() uint32 {
+	if x != nil {
+		return x.Teid
+	}
+	return 0
+}
+
+func (x *CreatedPDR) GetIpv4Address() string {
+	if x != nil {
+		return x.Ipv4Address
+	}
+	return ""
+}
+
+func (x *CreatedPDR) GetIpv6Address() string {
+	if x != nil {
+		return x.Ipv6Address
+	}
+	return ""
+}
+
+func (x *CreatedPDR) GetUeAddress() string {
+	if x != nil {
+		return x.UeAddress
+	}
+	return ""
+}
+
+func (x *CreatedPDR) GetUeIPv6Prefix() string {
+	if x != nil {
+		return x.UeIPv6Prefix
+	}
+	return ""
+}
+
+type SessionResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// id is the index of the session (see baseID)
+	Id      int32          `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Outcome SessionOutcome `protobuf:"varint,2,opt,name=outcome,proto3,enum=api.SessionOutcome" json:"outcome,omitempty"`
+	// PFCP Cause of the response, 0 if no valid response was received
+	Cause uint32 `protobuf:"varint,3,opt,name=cause,proto3" json:"cause,omitempty"`
+	// not set if the session could not be established
+	LocalFSEID *FSEID `protobuf:"bytes,4,opt,name=localFSEID,proto3" json:"localFSEID,omitempty"`
+	UpfFSEID   *FSEID `protobuf:"bytes,5,opt,name=upfFSEID,proto3" json:"upfFSEID,omitempty"`
+	// UE IPv4 address and/or UE IPv6 prefix in CIDR notation
+	UeAddresses []string `protobuf:"bytes,6,rep,name=ueAddresses,proto3" json:"ueAddresses,omitempty"`
+	// Created PDR IEs returned by the UPF
+	CreatedPDRs []*CreatedPDR `protobuf:"bytes,7,rep,name=createdPDRs,proto3" json:"createdPDRs,omitempty"`
+	// error details, empty on success
+	Message string `protobuf:"bytes,8,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *SessionResult) Reset() {
+	*x = SessionResult{}
+	mi := &file_pfcpsim_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionResult) ProtoMessage() {}
+
+func (x *SessionResult) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionResult.ProtoReflect.Descriptor instead.
+func (*SessionResult) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SessionResult) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SessionResult) GetOutcome() SessionOutcome {
+	if x != nil {
+		return x.Outcome
+	}
+	return SessionOutcome_SESSION_OUTCOME_UNSPECIFIED
+}
+
+func (x *SessionResult) GetCause() uint32 {
+	if x != nil {
+		return x.Cause
+	}
+	return 0
+}
+
+func (x *SessionResult) GetLocalFSEID() *FSEID {
+	if x != nil {
+		return x.LocalFSEID
+	}
+	return nil
+}
+
+func (x *SessionResult) GetUpfFSEID() *FSEID {
+	if x != nil {
+		return x.UpfFSEID
+	}
+	return nil
+}
+
+func (x *SessionResult) GetUeAddresses() []string {
+	if x != nil {
+		return x.UeAddresses
+	}
+	return nil
+}
+
+func (x *SessionResult) GetCreatedPDRs() []*CreatedPDR {
+	if x != nil {
+		return x.CreatedPDRs
+	}
+	return nil
+}
+
+func (x *SessionResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// SessionsResponse is returned by the session procedures. If any session fails, the RPC returns an
+// error with code INTERNAL, whose details carry the SessionsResponse with the results of every session.
+type SessionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StatusCode int32  `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	Message    string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// one result per session, ordered by id
+	Results []*SessionResult `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *SessionsResponse) Reset() {
+	*x = SessionsResponse{}
+	mi := &file_pfcpsim_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionsResponse) ProtoMessage() {}
+
+func (x *SessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionsResponse.ProtoReflect.Descriptor instead.
+func (*SessionsResponse) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SessionsResponse) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *SessionsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SessionsResponse) GetResults() []*SessionResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// UEPool is a pool UE addresses are allocated from. Addresses are released when their session is deleted.
+type UEPool struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// name of the pool. Pools created by CreateSession from a network are named after the network
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// network in CIDR notation. IPv4 pools allocate host addresses, IPv6 pools delegate /64 prefixes
+	Network string `protobuf:"bytes,2,opt,name=network,proto3" json:"network,omitempty"`
+	// addresses never allocated: single addresses, ranges (e.g. 10.0.0.1-10.0.0.9) or networks in CIDR notation
+	Exclusions []string `protobuf:"bytes,3,rep,name=exclusions,proto3" json:"exclusions,omitempty"`
+	// number of addresses (/64 prefixes for IPv6 pools) that can be allocated. Ignored by AddUEPool
+	Size uint64 `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	// number of addresses in use. Ignored by AddUEPool
+	Allocated uint64 `protobuf:"varint,5,opt,name=allocated,proto3" json:"allocated,omitempty"`
+}
+
+func (x *UEPool) Reset() {
+	*x = UEPool{}
+	mi := &file_pfcpsim_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UEPool) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UEPool) ProtoMessage() {}
+
+func (x *UEPool) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UEPool.ProtoReflect.Descriptor instead.
+func (*UEPool) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *UEPool) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UEPool) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *UEPool) GetExclusions() []string {
+	if x != nil {
+		return x.Exclusions
+	}
+	return nil
+}
+
+func (x *UEPool) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *UEPool) GetAllocated() uint64 {
+	if x != nil {
+		return x.Allocated
+	}
+	return 0
+}
+
+type RemoveUEPoolRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *RemoveUEPoolRequest) Reset() {
+	*x = RemoveUEPoolRequest{}
+	mi := &file_pfcpsim_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveUEPoolRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveUEPoolRequest) ProtoMessage() {}
+
+func (x *RemoveUEPoolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveUEPoolRequest.ProtoReflect.Descriptor instead.
+func (*RemoveUEPoolRequest) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RemoveUEPoolRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ListUEPoolsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StatusCode int32     `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	Message    string    `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Pools      []*UEPool `protobuf:"bytes,3,rep,name=pools,proto3" json:"pools,omitempty"`
+}
+
+func (x *ListUEPoolsResponse) Reset() {
+	*x = ListUEPoolsResponse{}
+	mi := &file_pfcpsim_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUEPoolsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUEPoolsResponse) ProtoMessage() {}
+
+func (x *ListUEPoolsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pfcpsim_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUEPoolsResponse.ProtoReflect.Descriptor instead.
+func (*ListUEPoolsResponse) Descriptor() ([]byte, []int) {
+	return file_pfcpsim_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListUEPoolsResponse) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *ListUEPoolsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListUEPoolsResponse) GetPools() []*UEPool {
+	if x != nil {
+		return x.Pools
+	}
+	return nil
+}
+
 var File_pfcpsim_proto protoreflect.FileDescriptor
 
 var file_pfcpsim_proto_rawDesc = []byte{
 	0x0a, 0x0d, 0x70, 0x66, 0x63, 0x70, 0x73, 0x69, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
-	0x03, 0x61, 0x70, 0x69, 0x22, 0xc0, 0x01, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53,
-	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a,
-	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f,
-	0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x61, 0x73, 0x65, 0x49, 0x44, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x61, 0x73, 0x65, 0x49, 0x44, 0x12, 0x22, 0x0a, 0x0c, 0x6e,
-	0x6f, 0x64, 0x65, 0x42, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0c, 0x6e, 0x6f, 0x64, 0x65, 0x42, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12,
-	0x24, 0x0a, 0x0d, 0x75, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x6f, 0x6c,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x75, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
-	0x73, 0x50, 0x6f, 0x6f, 0x6c, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x70, 0x70, 0x46, 0x69, 0x6c, 0x74,
-	0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x70, 0x70, 0x46, 0x69,
-	0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x71, 0x66, 0x69, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x03, 0x71, 0x66, 0x69, 0x22, 0xf2, 0x01, 0x0a, 0x14, 0x4d, 0x6f, 0x64, 0x69,
-	0x66, 0x79, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x61, 0x73, 0x65, 0x49, 0x44,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x61, 0x73, 0x65, 0x49, 0x44, 0x12, 0x22,
-	0x0a, 0x0c, 0x6e, 0x6f, 0x64, 0x65, 0x42, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6e, 0x6f, 0x64, 0x65, 0x42, 0x41, 0x64, 0x64, 0x72, 0x65,
-	0x73, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x75, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x50,
-	0x6f, 0x6f, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x75, 0x65, 0x41, 0x64, 0x64,
-	0x72, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x6f, 0x6c, 0x12, 0x1e, 0x0a, 0x0a, 0x62, 0x75, 0x66, 0x66,
-	0x65, 0x72, 0x46, 0x6c, 0x61, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x62, 0x75,
-	0x66, 0x66, 0x65, 0x72, 0x46, 0x6c, 0x61, 0x67, 0x12, 0x22, 0x0a, 0x0c, 0x6e, 0x6f, 0x74, 0x69,
-	0x66, 0x79, 0x43, 0x50, 0x46, 0x6c, 0x61, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c,
-	0x6e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x43, 0x50, 0x46, 0x6c, 0x61, 0x67, 0x12, 0x1e, 0x0a, 0x0a,
-	0x61, 0x70, 0x70, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09,
-	0x52, 0x0a, 0x61, 0x70, 0x70, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x22, 0x64, 0x0a, 0x10,
+	0x03, 0x61, 0x70, 0x69, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc8, 0x03, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x61, 0x73, 0x65, 0x49, 0x44, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x61, 0x73, 0x65, 0x49, 0x44, 0x12, 0x22, 0x0a, 0x0c,
+	0x6e, 0x6f, 0x64, 0x65, 0x42, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x6e, 0x6f, 0x64, 0x65, 0x42, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x12, 0x24, 0x0a, 0x0d, 0x75, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x6f,
+	0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x75, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x50, 0x6f, 0x6f, 0x6c, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x70, 0x70, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x70, 0x70, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x71, 0x66, 0x69, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x03, 0x71, 0x66, 0x69, 0x12, 0x28, 0x0a, 0x0f, 0x75, 0x65, 0x41, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x6f, 0x6c, 0x56, 0x36, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0f, 0x75, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x6f, 0x6c,
+	0x56, 0x36, 0x12, 0x26, 0x0a, 0x07, 0x70, 0x64, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x64, 0x6e, 0x54, 0x79, 0x70,
+	0x65, 0x52, 0x07, 0x70, 0x64, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x12, 0x38, 0x0a, 0x0d, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52,
+	0x0d, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x2a,
+	0x0a, 0x10, 0x75, 0x70, 0x66, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x65, 0x64, 0x54, 0x45,
+	0x49, 0x44, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x75, 0x70, 0x66, 0x41, 0x6c, 0x6c,
+	0x6f, 0x63, 0x61, 0x74, 0x65, 0x64, 0x54, 0x45, 0x49, 0x44, 0x12, 0x34, 0x0a, 0x15, 0x75, 0x70,
+	0x66, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x65, 0x64, 0x55, 0x45, 0x41, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x75, 0x70, 0x66, 0x41, 0x6c,
+	0x6c, 0x6f, 0x63, 0x61, 0x74, 0x65, 0x64, 0x55, 0x45, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x22, 0xf8, 0x03, 0x0a, 0x14, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x62, 0x61, 0x73, 0x65, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x06, 0x62, 0x61, 0x73, 0x65, 0x49, 0x44, 0x12, 0x22, 0x0a, 0x0c, 0x6e, 0x6f, 0x64, 0x65, 0x42,
+	0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6e,
+	0x6f, 0x64, 0x65, 0x42, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x75,
+	0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x6f, 0x6c, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x75, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x6f,
+	0x6c, 0x12, 0x1e, 0x0a, 0x0a, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x46, 0x6c, 0x61, 0x67, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x46, 0x6c, 0x61,
+	0x67, 0x12, 0x22, 0x0a, 0x0c, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x43, 0x50, 0x46, 0x6c, 0x61,
+	0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x43,
+	0x50, 0x46, 0x6c, 0x61, 0x67, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x70, 0x70, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x70, 0x70, 0x46, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x38, 0x0a, 0x0d, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65,
+	0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x52, 0x0d, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12,
+	0x1c, 0x0a, 0x09, 0x6e, 0x6f, 0x64, 0x65, 0x42, 0x54, 0x45, 0x49, 0x44, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x09, 0x6e, 0x6f, 0x64, 0x65, 0x42, 0x54, 0x45, 0x49, 0x44, 0x12, 0x2c, 0x0a,
+	0x09, 0x71, 0x65, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x51, 0x45, 0x52, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x52, 0x09, 0x71, 0x65, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x61,
+	0x64, 0x64, 0x41, 0x70, 0x70, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x0b, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0d, 0x61, 0x64, 0x64, 0x41, 0x70, 0x70, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x73, 0x12, 0x2a, 0x0a, 0x10, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x41, 0x70, 0x70, 0x46, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x72, 0x65, 0x6d,
+	0x6f, 0x76, 0x65, 0x41, 0x70, 0x70, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x1a, 0x0a,
+	0x08, 0x71, 0x75, 0x65, 0x72, 0x79, 0x55, 0x52, 0x52, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x71, 0x75, 0x65, 0x72, 0x79, 0x55, 0x52, 0x52, 0x12, 0x10, 0x0a, 0x03, 0x71, 0x66, 0x69,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x71, 0x66, 0x69, 0x22, 0xbd, 0x01, 0x0a, 0x09,
+	0x51, 0x45, 0x52, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x03, 0x69, 0x64, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x75,
+	0x70, 0x6c, 0x69, 0x6e, 0x6b, 0x4d, 0x42, 0x52, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09,
+	0x75, 0x70, 0x6c, 0x69, 0x6e, 0x6b, 0x4d, 0x42, 0x52, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x6f, 0x77,
+	0x6e, 0x6c, 0x69, 0x6e, 0x6b, 0x4d, 0x42, 0x52, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b,
+	0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x69, 0x6e, 0x6b, 0x4d, 0x42, 0x52, 0x12, 0x1c, 0x0a, 0x09, 0x75,
+	0x70, 0x6c, 0x69, 0x6e, 0x6b, 0x47, 0x42, 0x52, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09,
+	0x75, 0x70, 0x6c, 0x69, 0x6e, 0x6b, 0x47, 0x42, 0x52, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x6f, 0x77,
+	0x6e, 0x6c, 0x69, 0x6e, 0x6b, 0x47, 0x42, 0x52, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b,
+	0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x69, 0x6e, 0x6b, 0x47, 0x42, 0x52, 0x12, 0x1e, 0x0a, 0x0a, 0x67,
+	0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x67, 0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xdb, 0x03, 0x0a, 0x10,
 	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
 	0x12, 0x22, 0x0a, 0x0c, 0x75, 0x70, 0x66, 0x4e, 0x33, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x75, 0x70, 0x66, 0x4e, 0x33, 0x41, 0x64, 0x64,
 	0x72, 0x65, 0x73, 0x73, 0x12, 0x2c, 0x0a, 0x11, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x50, 0x65,
 	0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
 	0x11, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x50, 0x65, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65,
-	0x73, 0x73, 0x22, 0x44, 0x0a, 0x14, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73,
-	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f,
-	0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
-	0x12, 0x16, 0x0a, 0x06, 0x62, 0x61, 0x73, 0x65, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x06, 0x62, 0x61, 0x73, 0x65, 0x49, 0x44, 0x22, 0x0e, 0x0a, 0x0c, 0x45, 0x6d, 0x70, 0x74,
+	0x73, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x49, 0x6e, 0x46, 0x6c, 0x69, 0x67, 0x68,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x49, 0x6e, 0x46, 0x6c,
+	0x69, 0x67, 0x68, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x31, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x02, 0x74, 0x31, 0x12, 0x13, 0x0a, 0x02, 0x6e, 0x31, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05,
+	0x48, 0x00, 0x52, 0x02, 0x6e, 0x31, 0x88, 0x01, 0x01, 0x12, 0x22, 0x0a, 0x0c, 0x61, 0x75, 0x74,
+	0x6f, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0c, 0x61, 0x75, 0x74, 0x6f, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x12, 0x1e, 0x0a,
+	0x0a, 0x74, 0x65, 0x69, 0x64, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0a, 0x74, 0x65, 0x69, 0x64, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x28, 0x0a,
+	0x0f, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x45, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x45, 0x6d,
+	0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3b, 0x0a, 0x0b, 0x70, 0x61, 0x67, 0x69, 0x6e,
+	0x67, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x44,
+	0x65, 0x6c, 0x61, 0x79, 0x12, 0x2e, 0x0a, 0x12, 0x70, 0x61, 0x73, 0x73, 0x69, 0x76, 0x65, 0x41,
+	0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x12, 0x70, 0x61, 0x73, 0x73, 0x69, 0x76, 0x65, 0x41, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x75, 0x70, 0x66, 0x4e, 0x6f, 0x64, 0x65, 0x49,
+	0x44, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x70, 0x66, 0x4e, 0x6f, 0x64, 0x65,
+	0x49, 0x44, 0x12, 0x2e, 0x0a, 0x12, 0x63, 0x70, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x09, 0x52, 0x12,
+	0x63, 0x70, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x73, 0x42, 0x05, 0x0a, 0x03, 0x5f, 0x6e, 0x31, 0x22, 0x4a, 0x0a, 0x18, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x41, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2e, 0x0a, 0x12, 0x63, 0x70, 0x46, 0x75, 0x6e, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x12, 0x63, 0x70, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x65, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x73, 0x22, 0xcf, 0x03, 0x0a, 0x0f, 0x41, 0x73, 0x73, 0x6f, 0x63, 0x69,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x65, 0x65,
+	0x72, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70,
+	0x65, 0x65, 0x72, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x12, 0x2e, 0x0a, 0x12, 0x75, 0x70, 0x46,
+	0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x12, 0x75, 0x70, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x12, 0x63, 0x70, 0x46,
+	0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x12, 0x63, 0x70, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x72, 0x65, 0x6c,
+	0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x10, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x65, 0x64, 0x12, 0x4f, 0x0a, 0x15, 0x67, 0x72, 0x61, 0x63, 0x65, 0x66, 0x75,
+	0x6c, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x15, 0x67, 0x72, 0x61, 0x63, 0x65, 0x66, 0x75, 0x6c, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65,
+	0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x38, 0x0a, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x64, 0x41, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74,
+	0x12, 0x1e, 0x0a, 0x0a, 0x75, 0x70, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x07,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x75, 0x70, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73,
+	0x12, 0x37, 0x0a, 0x0d, 0x75, 0x70, 0x49, 0x50, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x50,
+	0x49, 0x50, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x0d, 0x75, 0x70, 0x49, 0x50,
+	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x11, 0x61, 0x6c, 0x74,
+	0x65, 0x72, 0x6e, 0x61, 0x74, 0x69, 0x76, 0x65, 0x53, 0x4d, 0x46, 0x49, 0x50, 0x73, 0x18, 0x09,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x11, 0x61, 0x6c, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x74, 0x69, 0x76,
+	0x65, 0x53, 0x4d, 0x46, 0x49, 0x50, 0x73, 0x22, 0xf6, 0x01, 0x0a, 0x0c, 0x55, 0x50, 0x49, 0x50,
+	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x69, 0x70, 0x76, 0x34,
+	0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x69,
+	0x70, 0x76, 0x34, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x69, 0x70,
+	0x76, 0x36, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x69, 0x70, 0x76, 0x36, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x30, 0x0a, 0x13,
+	0x74, 0x65, 0x69, 0x64, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x6e, 0x64, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x13, 0x74, 0x65, 0x69, 0x64, 0x52,
+	0x61, 0x6e, 0x67, 0x65, 0x49, 0x6e, 0x64, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c,
+	0x0a, 0x09, 0x74, 0x65, 0x69, 0x64, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x09, 0x74, 0x65, 0x69, 0x64, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x28, 0x0a, 0x0f,
+	0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x28, 0x0a, 0x0f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65,
+	0x22, 0x8b, 0x01, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x41, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x36, 0x0a, 0x0b, 0x61, 0x73, 0x73, 0x6f, 0x63, 0x69,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x41, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66,
+	0x6f, 0x52, 0x0b, 0x61, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x8e,
+	0x01, 0x0a, 0x19, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x36, 0x0a, 0x0b, 0x61, 0x73, 0x73, 0x6f, 0x63,
+	0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x41, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x0b, 0x61, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22,
+	0x7e, 0x0a, 0x14, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x62, 0x61, 0x73, 0x65, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x62,
+	0x61, 0x73, 0x65, 0x49, 0x44, 0x12, 0x38, 0x0a, 0x0d, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65,
+	0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x52, 0x0d, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x22,
+	0x46, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x62, 0x61, 0x73, 0x65, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x06, 0x62, 0x61, 0x73, 0x65, 0x49, 0x44, 0x22, 0xf5, 0x01, 0x0a, 0x11, 0x56, 0x6f, 0x6c, 0x75,
+	0x6d, 0x65, 0x4d, 0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x20, 0x0a,
+	0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12,
+	0x22, 0x0a, 0x0c, 0x75, 0x70, 0x6c, 0x69, 0x6e, 0x6b, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x75, 0x70, 0x6c, 0x69, 0x6e, 0x6b, 0x56, 0x6f, 0x6c,
+	0x75, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x69, 0x6e, 0x6b, 0x56,
+	0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x64, 0x6f, 0x77,
+	0x6e, 0x6c, 0x69, 0x6e, 0x6b, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x12,
+	0x24, 0x0a, 0x0d, 0x75, 0x70, 0x6c, 0x69, 0x6e, 0x6b, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x75, 0x70, 0x6c, 0x69, 0x6e, 0x6b, 0x50, 0x61,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x69, 0x6e,
+	0x6b, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f,
+	0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x69, 0x6e, 0x6b, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x22,
+	0xea, 0x02, 0x0a, 0x0b, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x75, 0x72, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05,
+	0x75, 0x72, 0x72, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x72, 0x53, 0x65, 0x71, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x75, 0x72, 0x53, 0x65, 0x71, 0x6e, 0x12, 0x1a, 0x0a,
+	0x08, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x08, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x73, 0x12, 0x2e, 0x0a, 0x06, 0x76, 0x6f, 0x6c,
+	0x75, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x4d, 0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x52, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x64, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x38, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x34, 0x0a, 0x07, 0x65, 0x6e,
+	0x64, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65,
+	0x12, 0x3a, 0x0a, 0x0a, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x41, 0x74, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x0a, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x41, 0x74, 0x22, 0x6f, 0x0a, 0x13,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x45, 0x49, 0x44,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x45, 0x49,
+	0x44, 0x12, 0x2a, 0x0a, 0x07, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x52, 0x07, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x22, 0x8a, 0x01,
+	0x0a, 0x17, 0x47, 0x65, 0x74, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x34, 0x0a, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73,
+	0x52, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xa8, 0x04, 0x0a, 0x0b, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f,
+	0x63, 0x61, 0x6c, 0x53, 0x45, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x6c,
+	0x6f, 0x63, 0x61, 0x6c, 0x53, 0x45, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x65, 0x65, 0x72,
+	0x53, 0x45, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x70, 0x65, 0x65, 0x72,
+	0x53, 0x45, 0x49, 0x44, 0x12, 0x20, 0x0a, 0x0b, 0x75, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x75, 0x65, 0x41, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x75, 0x70, 0x6c, 0x69, 0x6e, 0x6b,
+	0x54, 0x45, 0x49, 0x44, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x75, 0x70, 0x6c, 0x69,
+	0x6e, 0x6b, 0x54, 0x45, 0x49, 0x44, 0x12, 0x22, 0x0a, 0x0c, 0x6e, 0x6f, 0x64, 0x65, 0x42, 0x41,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6e, 0x6f,
+	0x64, 0x65, 0x42, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x64,
+	0x72, 0x49, 0x44, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x06, 0x70, 0x64, 0x72, 0x49,
+	0x44, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x61, 0x72, 0x49, 0x44, 0x73, 0x18, 0x08, 0x20, 0x03,
+	0x28, 0x0d, 0x52, 0x06, 0x66, 0x61, 0x72, 0x49, 0x44, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x71, 0x65,
+	0x72, 0x49, 0x44, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x06, 0x71, 0x65, 0x72, 0x49,
+	0x44, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x72, 0x72, 0x49, 0x44, 0x73, 0x18, 0x0a, 0x20, 0x03,
+	0x28, 0x0d, 0x52, 0x06, 0x75, 0x72, 0x72, 0x49, 0x44, 0x73, 0x12, 0x38, 0x0a, 0x09, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x41, 0x74, 0x12, 0x3a, 0x0a, 0x0a, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64,
+	0x41, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x41, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x6f, 0x64, 0x65, 0x42, 0x54, 0x45, 0x49, 0x44, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x09, 0x6e, 0x6f, 0x64, 0x65, 0x42, 0x54, 0x45, 0x49, 0x44, 0x12, 0x25,
+	0x0a, 0x07, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x52, 0x07, 0x70, 0x61,
+	0x67, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65,
+	0x64, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65,
+	0x64, 0x12, 0x32, 0x0a, 0x14, 0x75, 0x73, 0x65, 0x72, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x50, 0x61,
+	0x74, 0x68, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x14, 0x75, 0x73, 0x65, 0x72, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x50, 0x61, 0x74, 0x68, 0x46, 0x61,
+	0x69, 0x6c, 0x75, 0x72, 0x65, 0x22, 0xb7, 0x01, 0x0a, 0x06, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x67,
+	0x12, 0x3a, 0x0a, 0x0a, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x0a, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x3c, 0x0a, 0x0b,
+	0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x63,
+	0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x33, 0x0a, 0x07, 0x6c, 0x61,
+	0x74, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x22,
+	0x7f, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x2c, 0x0a, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73,
+	0x22, 0x23, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x02, 0x69, 0x64, 0x22, 0x7b, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x53, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2a, 0x0a, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x22, 0x2a, 0x0a, 0x12, 0x57, 0x61, 0x74, 0x63, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x79, 0x70, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x22, 0xf7,
+	0x01, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x38, 0x0a, 0x09,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x54, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x73, 0x65, 0x71, 0x75,
+	0x65, 0x6e, 0x63, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0e, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04,
+	0x73, 0x65, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x28,
+	0x0a, 0x0f, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x47, 0x54, 0x50, 0x55, 0x50, 0x65, 0x65, 0x72,
+	0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x47,
+	0x54, 0x50, 0x55, 0x50, 0x65, 0x65, 0x72, 0x73, 0x22, 0x0e, 0x0a, 0x0c, 0x45, 0x6d, 0x70, 0x74,
 	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x45, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70,
 	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63,
 	0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75,
 	0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x32,
-	0xda, 0x02, 0x0a, 0x07, 0x50, 0x46, 0x43, 0x50, 0x53, 0x69, 0x6d, 0x12, 0x33, 0x0a, 0x09, 0x43,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x12, 0x15, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x43,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x0d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x12, 0x2f, 0x0a, 0x09, 0x41, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x12, 0x11, 0x2e,
-	0x61, 0x70, 0x69, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x0d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x12, 0x32, 0x0a, 0x0c, 0x44, 0x69, 0x73, 0x61, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74,
-	0x65, 0x12, 0x11, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53,
-	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x0d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x00, 0x12, 0x3b, 0x0a, 0x0d, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x53, 0x65, 0x73, 0x73,
-	0x69, 0x6f, 0x6e, 0x12, 0x19, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79,
-	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d,
-	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x3b, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
-	0x12, 0x19, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x65, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x61, 0x70,
-	0x69, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x07, 0x5a, 0x05,
-	0x2e, 0x3b, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
+	0x35, 0x0a, 0x05, 0x46, 0x53, 0x45, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x65, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0xbc, 0x01, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x50, 0x44, 0x52, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x64, 0x72, 0x49, 0x44, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x70, 0x64, 0x72, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x65, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x65, 0x69, 0x64, 0x12,
+	0x20, 0x0a, 0x0b, 0x69, 0x70, 0x76, 0x34, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x70, 0x76, 0x34, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x12, 0x20, 0x0a, 0x0b, 0x69, 0x70, 0x76, 0x36, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x70, 0x76, 0x36, 0x41, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x75, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x12, 0x22, 0x0a, 0x0c, 0x75, 0x65, 0x49, 0x50, 0x76, 0x36, 0x50, 0x72, 0x65, 0x66, 0x69,
+	0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x75, 0x65, 0x49, 0x50, 0x76, 0x36, 0x50,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x22, 0xa7, 0x02, 0x0a, 0x0d, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x2d, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x63, 0x6f,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x52, 0x07, 0x6f,
+	0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x61, 0x75, 0x73, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x61, 0x75, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x0a,
+	0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x46, 0x53, 0x45, 0x49, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x46, 0x53, 0x45, 0x49, 0x44, 0x52, 0x0a, 0x6c, 0x6f,
+	0x63, 0x61, 0x6c, 0x46, 0x53, 0x45, 0x49, 0x44, 0x12, 0x26, 0x0a, 0x08, 0x75, 0x70, 0x66, 0x46,
+	0x53, 0x45, 0x49, 0x44, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x46, 0x53, 0x45, 0x49, 0x44, 0x52, 0x08, 0x75, 0x70, 0x66, 0x46, 0x53, 0x45, 0x49, 0x44,
+	0x12, 0x20, 0x0a, 0x0b, 0x75, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18,
+	0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x75, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x65, 0x73, 0x12, 0x31, 0x0a, 0x0b, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x50, 0x44, 0x52,
+	0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x50, 0x44, 0x52, 0x52, 0x0b, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x50, 0x44, 0x52, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
+	0x7b, 0x0a, 0x10, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x43, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2c,
+	0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x88, 0x01, 0x0a,
+	0x06, 0x55, 0x45, 0x50, 0x6f, 0x6f, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6e,
+	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x78, 0x63, 0x6c, 0x75,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x6c, 0x6c,
+	0x6f, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x61, 0x6c,
+	0x6c, 0x6f, 0x63, 0x61, 0x74, 0x65, 0x64, 0x22, 0x29, 0x0a, 0x13, 0x52, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x55, 0x45, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x22, 0x73, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x55, 0x45, 0x50, 0x6f, 0x6f, 0x6c,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x21, 0x0a, 0x05, 0x70, 0x6f, 0x6f, 0x6c, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x45, 0x50, 0x6f, 0x6f, 0x6c,
+	0x52, 0x05, 0x70, 0x6f, 0x6f, 0x6c, 0x73, 0x2a, 0x44, 0x0a, 0x07, 0x50, 0x64, 0x6e, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x11, 0x0a, 0x0d, 0x50, 0x44, 0x4e, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x49,
+	0x50, 0x56, 0x34, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x50, 0x44, 0x4e, 0x5f, 0x54, 0x59, 0x50,
+	0x45, 0x5f, 0x49, 0x50, 0x56, 0x36, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x50, 0x44, 0x4e, 0x5f,
+	0x54, 0x59, 0x50, 0x45, 0x5f, 0x49, 0x50, 0x56, 0x34, 0x56, 0x36, 0x10, 0x02, 0x2a, 0x62, 0x0a,
+	0x0d, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x1b,
+	0x0a, 0x17, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59,
+	0x5f, 0x43, 0x4f, 0x4e, 0x54, 0x49, 0x4e, 0x55, 0x45, 0x10, 0x00, 0x12, 0x17, 0x0a, 0x13, 0x46,
+	0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x53, 0x54,
+	0x4f, 0x50, 0x10, 0x01, 0x12, 0x1b, 0x0a, 0x17, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f,
+	0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x52, 0x4f, 0x4c, 0x4c, 0x42, 0x41, 0x43, 0x4b, 0x10,
+	0x02, 0x2a, 0xe3, 0x01, 0x0a, 0x0e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4f, 0x75, 0x74,
+	0x63, 0x6f, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x1b, 0x53, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f,
+	0x4f, 0x55, 0x54, 0x43, 0x4f, 0x4d, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1b, 0x0a, 0x17, 0x53, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e,
+	0x5f, 0x4f, 0x55, 0x54, 0x43, 0x4f, 0x4d, 0x45, 0x5f, 0x53, 0x55, 0x43, 0x43, 0x45, 0x53, 0x53,
+	0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18, 0x53, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x4f, 0x55,
+	0x54, 0x43, 0x4f, 0x4d, 0x45, 0x5f, 0x52, 0x45, 0x4a, 0x45, 0x43, 0x54, 0x45, 0x44, 0x10, 0x02,
+	0x12, 0x1b, 0x0a, 0x17, 0x53, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x4f, 0x55, 0x54, 0x43,
+	0x4f, 0x4d, 0x45, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x4f, 0x55, 0x54, 0x10, 0x03, 0x12, 0x1a, 0x0a,
+	0x16, 0x53, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x4f, 0x55, 0x54, 0x43, 0x4f, 0x4d, 0x45,
+	0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x04, 0x12, 0x1b, 0x0a, 0x17, 0x53, 0x45, 0x53,
+	0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x4f, 0x55, 0x54, 0x43, 0x4f, 0x4d, 0x45, 0x5f, 0x53, 0x4b, 0x49,
+	0x50, 0x50, 0x45, 0x44, 0x10, 0x05, 0x12, 0x1f, 0x0a, 0x1b, 0x53, 0x45, 0x53, 0x53, 0x49, 0x4f,
+	0x4e, 0x5f, 0x4f, 0x55, 0x54, 0x43, 0x4f, 0x4d, 0x45, 0x5f, 0x52, 0x4f, 0x4c, 0x4c, 0x45, 0x44,
+	0x5f, 0x42, 0x41, 0x43, 0x4b, 0x10, 0x06, 0x32, 0xb9, 0x07, 0x0a, 0x07, 0x50, 0x46, 0x43, 0x50,
+	0x53, 0x69, 0x6d, 0x12, 0x33, 0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65,
+	0x12, 0x15, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x2f, 0x0a, 0x09, 0x41, 0x73, 0x73, 0x6f,
+	0x63, 0x69, 0x61, 0x74, 0x65, 0x12, 0x11, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x32, 0x0a, 0x0c, 0x44, 0x69, 0x73,
+	0x61, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x12, 0x11, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x54, 0x0a,
+	0x11, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x1d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41,
+	0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x73,
+	0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x41, 0x73, 0x73, 0x6f, 0x63, 0x69,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x11, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x47,
+	0x65, 0x74, 0x41, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0d,
+	0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x43, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x19, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x11, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x53, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x55, 0x73,
+	0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x1b, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x47, 0x65,
+	0x74, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x29, 0x0a, 0x09, 0x41, 0x64, 0x64, 0x55, 0x45,
+	0x50, 0x6f, 0x6f, 0x6c, 0x12, 0x0b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x45, 0x50, 0x6f, 0x6f,
+	0x6c, 0x1a, 0x0d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x39, 0x0a, 0x0c, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x55, 0x45, 0x50, 0x6f,
+	0x6f, 0x6c, 0x12, 0x18, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x55,
+	0x45, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a,
+	0x0b, 0x4c, 0x69, 0x73, 0x74, 0x55, 0x45, 0x50, 0x6f, 0x6f, 0x6c, 0x73, 0x12, 0x11, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x18, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x55, 0x45, 0x50, 0x6f, 0x6f, 0x6c,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x0b, 0x57,
+	0x61, 0x74, 0x63, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x17, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x0a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22,
+	0x00, 0x30, 0x01, 0x42, 0x07, 0x5a, 0x05, 0x2e, 0x3b, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -489,33 +3159,115 @@ func file_pfcpsim_proto_rawDescGZIP() []byte {
 	return file_pfcpsim_proto_rawDescData
 }
 
-var file_pfcpsim_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_pfcpsim_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_pfcpsim_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
 var file_pfcpsim_proto_goTypes = []any{
-	(*CreateSessionRequest)(nil), // 0: api.CreateSessionRequest
-	(*ModifySessionRequest)(nil), // 1: api.ModifySessionRequest
-	(*ConfigureRequest)(nil),     // 2: api.ConfigureRequest
-	(*DeleteSessionRequest)(nil), // 3: api.DeleteSessionRequest
-	(*EmptyRequest)(nil),         // 4: api.EmptyRequest
-	(*Response)(nil),             // 5: api.Response
+	(PdnType)(0),                      // 0: api.PdnType
+	(FailurePolicy)(0),                // 1: api.FailurePolicy
+	(SessionOutcome)(0),               // 2: api.SessionOutcome
+	(*CreateSessionRequest)(nil),      // 3: api.CreateSessionRequest
+	(*ModifySessionRequest)(nil),      // 4: api.ModifySessionRequest
+	(*QERUpdate)(nil),                 // 5: api.QERUpdate
+	(*ConfigureRequest)(nil),          // 6: api.ConfigureRequest
+	(*UpdateAssociationRequest)(nil),  // 7: api.UpdateAssociationRequest
+	(*AssociationInfo)(nil),           // 8: api.AssociationInfo
+	(*UPIPResource)(nil),              // 9: api.UPIPResource
+	(*GetAssociationResponse)(nil),    // 10: api.GetAssociationResponse
+	(*UpdateAssociationResponse)(nil), // 11: api.UpdateAssociationResponse
+	(*DeleteSessionRequest)(nil),      // 12: api.DeleteSessionRequest
+	(*GetUsageReportsRequest)(nil),    // 13: api.GetUsageReportsRequest
+	(*VolumeMeasurement)(nil),         // 14: api.VolumeMeasurement
+	(*UsageReport)(nil),               // 15: api.UsageReport
+	(*SessionUsageReports)(nil),       // 16: api.SessionUsageReports
+	(*GetUsageReportsResponse)(nil),   // 17: api.GetUsageReportsResponse
+	(*SessionInfo)(nil),               // 18: api.SessionInfo
+	(*Paging)(nil),                    // 19: api.Paging
+	(*ListSessionsResponse)(nil),      // 20: api.ListSessionsResponse
+	(*GetSessionRequest)(nil),         // 21: api.GetSessionRequest
+	(*GetSessionResponse)(nil),        // 22: api.GetSessionResponse
+	(*WatchEventsRequest)(nil),        // 23: api.WatchEventsRequest
+	(*Event)(nil),                     // 24: api.Event
+	(*EmptyRequest)(nil),              // 25: api.EmptyRequest
+	(*Response)(nil),                  // 26: api.Response
+	(*FSEID)(nil),                     // 27: api.FSEID
+	(*CreatedPDR)(nil),                // 28: api.CreatedPDR
+	(*SessionResult)(nil),             // 29: api.SessionResult
+	(*SessionsResponse)(nil),          // 30: api.SessionsResponse
+	(*UEPool)(nil),                    // 31: api.UEPool
+	(*RemoveUEPoolRequest)(nil),       // 32: api.RemoveUEPoolRequest
+	(*ListUEPoolsResponse)(nil),       // 33: api.ListUEPoolsResponse
+	(*durationpb.Duration)(nil),       // 34: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),     // 35: google.protobuf.Timestamp
 }
 var file_pfcpsim_proto_depIdxs = []int32{
-	2, // 0: api.PFCPSim.Configure:input_type -> api.ConfigureRequest
-	4, // 1: api.PFCPSim.Associate:input_type -> api.EmptyRequest
-	4, // 2: api.PFCPSim.Disassociate:input_type -> api.EmptyRequest
-	0, // 3: api.PFCPSim.CreateSession:input_type -> api.CreateSessionRequest
-	1, // 4: api.PFCPSim.ModifySession:input_type -> api.ModifySessionRequest
-	3, // 5: api.PFCPSim.DeleteSession:input_type -> api.DeleteSessionRequest
-	5, // 6: api.PFCPSim.Configure:output_type -> api.Response
-	5, // 7: api.PFCPSim.Associate:output_type -> api.Response
-	5, // 8: api.PFCPSim.Disassociate:output_type -> api.Response
-	5, // 9: api.PFCPSim.CreateSession:output_type -> api.Response
-	5, // 10: api.PFCPSim.ModifySession:output_type -> api.Response
-	5, // 11: api.PFCPSim.DeleteSession:output_type -> api.Response
-	6, // [6:12] is the sub-list for method output_type
-	0, // [0:6] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0,  // 0: api.CreateSessionRequest.pdnType:type_name -> api.PdnType
+	1,  // 1: api.CreateSessionRequest.failurePolicy:type_name -> api.FailurePolicy
+	1,  // 2: api.ModifySessionRequest.failurePolicy:type_name -> api.FailurePolicy
+	5,  // 3: api.ModifySessionRequest.qerUpdate:type_name -> api.QERUpdate
+	34, // 4: api.ConfigureRequest.pagingDelay:type_name -> google.protobuf.Duration
+	34, // 5: api.AssociationInfo.gracefulReleasePeriod:type_name -> google.protobuf.Duration
+	35, // 6: api.AssociationInfo.updatedAt:type_name -> google.protobuf.Timestamp
+	9,  // 7: api.AssociationInfo.upIPResources:type_name -> api.UPIPResource
+	8,  // 8: api.GetAssociationResponse.association:type_name -> api.AssociationInfo
+	8,  // 9: api.UpdateAssociationResponse.association:type_name -> api.AssociationInfo
+	1,  // 10: api.DeleteSessionRequest.failurePolicy:type_name -> api.FailurePolicy
+	14, // 11: api.UsageReport.volume:type_name -> api.VolumeMeasurement
+	34, // 12: api.UsageReport.duration:type_name -> google.protobuf.Duration
+	35, // 13: api.UsageReport.startTime:type_name -> google.protobuf.Timestamp
+	35, // 14: api.UsageReport.endTime:type_name -> google.protobuf.Timestamp
+	35, // 15: api.UsageReport.receivedAt:type_name -> google.protobuf.Timestamp
+	15, // 16: api.SessionUsageReports.reports:type_name -> api.UsageReport
+	16, // 17: api.GetUsageReportsResponse.sessions:type_name -> api.SessionUsageReports
+	35, // 18: api.SessionInfo.createdAt:type_name -> google.protobuf.Timestamp
+	35, // 19: api.SessionInfo.modifiedAt:type_name -> google.protobuf.Timestamp
+	19, // 20: api.SessionInfo.pagings:type_name -> api.Paging
+	35, // 21: api.Paging.reportedAt:type_name -> google.protobuf.Timestamp
+	35, // 22: api.Paging.completedAt:type_name -> google.protobuf.Timestamp
+	34, // 23: api.Paging.latency:type_name -> google.protobuf.Duration
+	18, // 24: api.ListSessionsResponse.sessions:type_name -> api.SessionInfo
+	18, // 25: api.GetSessionResponse.session:type_name -> api.SessionInfo
+	35, // 26: api.Event.timestamp:type_name -> google.protobuf.Timestamp
+	2,  // 27: api.SessionResult.outcome:type_name -> api.SessionOutcome
+	27, // 28: api.SessionResult.localFSEID:type_name -> api.FSEID
+	27, // 29: api.SessionResult.upfFSEID:type_name -> api.FSEID
+	28, // 30: api.SessionResult.createdPDRs:type_name -> api.CreatedPDR
+	29, // 31: api.SessionsResponse.results:type_name -> api.SessionResult
+	31, // 32: api.ListUEPoolsResponse.pools:type_name -> api.UEPool
+	6,  // 33: api.PFCPSim.Configure:input_type -> api.ConfigureRequest
+	25, // 34: api.PFCPSim.Associate:input_type -> api.EmptyRequest
+	25, // 35: api.PFCPSim.Disassociate:input_type -> api.EmptyRequest
+	7,  // 36: api.PFCPSim.UpdateAssociation:input_type -> api.UpdateAssociationRequest
+	25, // 37: api.PFCPSim.GetAssociation:input_type -> api.EmptyRequest
+	3,  // 38: api.PFCPSim.CreateSession:input_type -> api.CreateSessionRequest
+	4,  // 39: api.PFCPSim.ModifySession:input_type -> api.ModifySessionRequest
+	12, // 40: api.PFCPSim.DeleteSession:input_type -> api.DeleteSessionRequest
+	25, // 41: api.PFCPSim.ListSessions:input_type -> api.EmptyRequest
+	21, // 42: api.PFCPSim.GetSession:input_type -> api.GetSessionRequest
+	13, // 43: api.PFCPSim.GetUsageReports:input_type -> api.GetUsageReportsRequest
+	31, // 44: api.PFCPSim.AddUEPool:input_type -> api.UEPool
+	32, // 45: api.PFCPSim.RemoveUEPool:input_type -> api.RemoveUEPoolRequest
+	25, // 46: api.PFCPSim.ListUEPools:input_type -> api.EmptyRequest
+	23, // 47: api.PFCPSim.WatchEvents:input_type -> api.WatchEventsRequest
+	26, // 48: api.PFCPSim.Configure:output_type -> api.Response
+	26, // 49: api.PFCPSim.Associate:output_type -> api.Response
+	26, // 50: api.PFCPSim.Disassociate:output_type -> api.Response
+	11, // 51: api.PFCPSim.UpdateAssociation:output_type -> api.UpdateAssociationResponse
+	10, // 52: api.PFCPSim.GetAssociation:output_type -> api.GetAssociationResponse
+	30, // 53: api.PFCPSim.CreateSession:output_type -> api.SessionsResponse
+	30, // 54: api.PFCPSim.ModifySession:output_type -> api.SessionsResponse
+	30, // 55: api.PFCPSim.DeleteSession:output_type -> api.SessionsResponse
+	20, // 56: api.PFCPSim.ListSessions:output_type -> api.ListSessionsResponse
+	22, // 57: api.PFCPSim.GetSession:output_type -> api.GetSessionResponse
+	17, // 58: api.PFCPSim.GetUsageReports:output_type -> api.GetUsageReportsResponse
+	26, // 59: api.PFCPSim.AddUEPool:output_type -> api.Response
+	26, // 60: api.PFCPSim.RemoveUEPool:output_type -> api.Response
+	33, // 61: api.PFCPSim.ListUEPools:output_type -> api.ListUEPoolsResponse
+	24, // 62: api.PFCPSim.WatchEvents:output_type -> api.Event
+	48, // [48:63] is the sub-list for method output_type
+	33, // [33:48] is the sub-list for method input_type
+	33, // [33:33] is the sub-list for extension type_name
+	33, // [33:33] is the sub-list for extension extendee
+	0,  // [0:33] is the sub-list for field type_name
 }
 
 func init() { file_pfcpsim_proto_init() }
@@ -523,18 +3275,20 @@ func file_pfcpsim_proto_init() {
 	if File_pfcpsim_proto != nil {
 		return
 	}
+	file_pfcpsim_proto_msgTypes[3].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_pfcpsim_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   6,
+			NumEnums:      3,
+			NumMessages:   31,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_pfcpsim_proto_goTypes,
 		DependencyIndexes: file_pfcpsim_proto_depIdxs,
+		EnumInfos:         file_pfcpsim_proto_enumTypes,
 		MessageInfos:      file_pfcpsim_proto_msgTypes,
 	}.Build()
 	File_pfcpsim_proto = out.File
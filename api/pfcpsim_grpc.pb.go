@@ -28,9 +28,28 @@ type PFCPSimClient interface {
 	Associate(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*Response, error)
 	// Disassociate perform teardown of association and disconnects from remote peer.
 	Disassociate(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*Response, error)
-	CreateSession(ctx context.Context, in *CreateSessionRequest, opts ...grpc.CallOption) (*Response, error)
-	ModifySession(ctx context.Context, in *ModifySessionRequest, opts ...grpc.CallOption) (*Response, error)
-	DeleteSession(ctx context.Context, in *DeleteSessionRequest, opts ...grpc.CallOption) (*Response, error)
+	// UpdateAssociation performs an Association Update procedure and returns the updated association state
+	UpdateAssociation(ctx context.Context, in *UpdateAssociationRequest, opts ...grpc.CallOption) (*UpdateAssociationResponse, error)
+	// GetAssociation returns the state of the association, with the features negotiated with the UPF
+	GetAssociation(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*GetAssociationResponse, error)
+	CreateSession(ctx context.Context, in *CreateSessionRequest, opts ...grpc.CallOption) (*SessionsResponse, error)
+	ModifySession(ctx context.Context, in *ModifySessionRequest, opts ...grpc.CallOption) (*SessionsResponse, error)
+	DeleteSession(ctx context.Context, in *DeleteSessionRequest, opts ...grpc.CallOption) (*SessionsResponse, error)
+	// ListSessions returns the state of every active session
+	ListSessions(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error)
+	// GetSession returns the state of the session created with the given id
+	GetSession(ctx context.Context, in *GetSessionRequest, opts ...grpc.CallOption) (*GetSessionResponse, error)
+	// GetUsageReports returns the usage reports received from the UPF for the selected sessions
+	GetUsageReports(ctx context.Context, in *GetUsageReportsRequest, opts ...grpc.CallOption) (*GetUsageReportsResponse, error)
+	// AddUEPool adds a named UE address pool, which must not overlap any other pool
+	AddUEPool(ctx context.Context, in *UEPool, opts ...grpc.CallOption) (*Response, error)
+	// RemoveUEPool removes a UE address pool with no address in use
+	RemoveUEPool(ctx context.Context, in *RemoveUEPoolRequest, opts ...grpc.CallOption) (*Response, error)
+	// ListUEPools returns the utilisation of every UE address pool
+	ListUEPools(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*ListUEPoolsResponse, error)
+	// WatchEvents streams the events raised on N4 (association changes, heartbeat failures, reports and
+	// unsolicited messages) as they happen
+	WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (PFCPSim_WatchEventsClient, error)
 }
 
 type pFCPSimClient struct {
@@ -68,8 +87,26 @@ func (c *pFCPSimClient) Disassociate(ctx context.Context, in *EmptyRequest, opts
 	return out, nil
 }
 
-func (c *pFCPSimClient) CreateSession(ctx context.Context, in *CreateSessionRequest, opts ...grpc.CallOption) (*Response, error) {
-	out := new(Response)
+func (c *pFCPSimClient) UpdateAssociation(ctx context.Context, in *UpdateAssociationRequest, opts ...grpc.CallOption) (*UpdateAssociationResponse, error) {
+	out := new(UpdateAssociationResponse)
+	err := c.cc.Invoke(ctx, "/api.PFCPSim/UpdateAssociation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pFCPSimClient) GetAssociation(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*GetAssociationResponse, error) {
+	out := new(GetAssociationResponse)
+	err := c.cc.Invoke(ctx, "/api.PFCPSim/GetAssociation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pFCPSimClient) CreateSession(ctx context.Context, in *CreateSessionRequest, opts ...grpc.CallOption) (*SessionsResponse, error) {
+	out := new(SessionsResponse)
 	err := c.cc.Invoke(ctx, "/api.PFCPSim/CreateSession", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -77,8 +114,8 @@ func (c *pFCPSimClient) CreateSession(ctx context.Context, in *CreateSessionRequ
 	return out, nil
 }
 
-func (c *pFCPSimClient) ModifySession(ctx context.Context, in *ModifySessionRequest, opts ...grpc.CallOption) (*Response, error) {
-	out := new(Response)
+func (c *pFCPSimClient) ModifySession(ctx context.Context, in *ModifySessionRequest, opts ...grpc.CallOption) (*SessionsResponse, error) {
+	out := new(SessionsResponse)
 	err := c.cc.Invoke(ctx, "/api.PFCPSim/ModifySession", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -86,8 +123,8 @@ func (c *pFCPSimClient) ModifySession(ctx context.Context, in *ModifySessionRequ
 	return out, nil
 }
 
-func (c *pFCPSimClient) DeleteSession(ctx context.Context, in *DeleteSessionRequest, opts ...grpc.CallOption) (*Response, error) {
-	out := new(Response)
+func (c *pFCPSimClient) DeleteSession(ctx context.Context, in *DeleteSessionRequest, opts ...grpc.CallOption) (*SessionsResponse, error) {
+	out := new(SessionsResponse)
 	err := c.cc.Invoke(ctx, "/api.PFCPSim/DeleteSession", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -95,6 +132,92 @@ func (c *pFCPSimClient) DeleteSession(ctx context.Context, in *DeleteSessionRequ
 	return out, nil
 }
 
+func (c *pFCPSimClient) ListSessions(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error) {
+	out := new(ListSessionsResponse)
+	err := c.cc.Invoke(ctx, "/api.PFCPSim/ListSessions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pFCPSimClient) GetSession(ctx context.Context, in *GetSessionRequest, opts ...grpc.CallOption) (*GetSessionResponse, error) {
+	out := new(GetSessionResponse)
+	err := c.cc.Invoke(ctx, "/api.PFCPSim/GetSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pFCPSimClient) GetUsageReports(ctx context.Context, in *GetUsageReportsRequest, opts ...grpc.CallOption) (*GetUsageReportsResponse, error) {
+	out := new(GetUsageReportsResponse)
+	err := c.cc.Invoke(ctx, "/api.PFCPSim/GetUsageReports", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pFCPSimClient) AddUEPool(ctx context.Context, in *UEPool, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/api.PFCPSim/AddUEPool", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pFCPSimClient) RemoveUEPool(ctx context.Context, in *RemoveUEPoolRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/api.PFCPSim/RemoveUEPool", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pFCPSimClient) ListUEPools(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*ListUEPoolsResponse, error) {
+	out := new(ListUEPoolsResponse)
+	err := c.cc.Invoke(ctx, "/api.PFCPSim/ListUEPools", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pFCPSimClient) WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (PFCPSim_WatchEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PFCPSim_ServiceDesc.Streams[0], "/api.PFCPSim/WatchEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &pFCPSimWatchEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PFCPSim_WatchEventsClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type pFCPSimWatchEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *pFCPSimWatchEventsClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // PFCPSimServer is the server API for PFCPSim service.
 // All implementations must embed UnimplementedPFCPSimServer
 // for forward compatibility
@@ -104,9 +227,28 @@ type PFCPSimServer interface {
 	Associate(context.Context, *EmptyRequest) (*Response, error)
 	// Disassociate perform teardown of association and disconnects from remote peer.
 	Disassociate(context.Context, *EmptyRequest) (*Response, error)
-	CreateSession(context.Context, *CreateSessionRequest) (*Response, error)
-	ModifySession(context.Context, *ModifySessionRequest) (*Response, error)
-	DeleteSession(context.Context, *DeleteSessionRequest) (*Response, error)
+	// UpdateAssociation performs an Association Update procedure and returns the updated association state
+	UpdateAssociation(context.Context, *UpdateAssociationRequest) (*UpdateAssociationResponse, error)
+	// GetAssociation returns the state of the association, with the features negotiated with the UPF
+	GetAssociation(context.Context, *EmptyRequest) (*GetAssociationResponse, error)
+	CreateSession(context.Context, *CreateSessionRequest) (*SessionsResponse, error)
+	ModifySession(context.Context, *ModifySessionRequest) (*SessionsResponse, error)
+	DeleteSession(context.Context, *DeleteSessionRequest) (*SessionsResponse, error)
+	// ListSessions returns the state of every active session
+	ListSessions(context.Context, *EmptyRequest) (*ListSessionsResponse, error)
+	// GetSession returns the state of the session created with the given id
+	GetSession(context.Context, *GetSessionRequest) (*GetSessionResponse, error)
+	// GetUsageReports returns the usage reports received from the UPF for the selected sessions
+	GetUsageReports(context.Context, *GetUsageReportsRequest) (*GetUsageReportsResponse, error)
+	// AddUEPool adds a named UE address pool, which must not overlap any other pool
+	AddUEPool(context.Context, *UEPool) (*Response, error)
+	// RemoveUEPool removes a UE address pool with no address in use
+	RemoveUEPool(context.Context, *RemoveUEPoolRequest) (*Response, error)
+	// ListUEPools returns the utilisation of every UE address pool
+	ListUEPools(context.Context, *EmptyRequest) (*ListUEPoolsResponse, error)
+	// WatchEvents streams the events raised on N4 (association changes, heartbeat failures, reports and
+	// unsolicited messages) as they happen
+	WatchEvents(*WatchEventsRequest, PFCPSim_WatchEventsServer) error
 	mustEmbedUnimplementedPFCPSimServer()
 }
 
@@ -123,15 +265,42 @@ func (UnimplementedPFCPSimServer) Associate(context.Context, *EmptyRequest) (*Re
 func (UnimplementedPFCPSimServer) Disassociate(context.Context, *EmptyRequest) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Disassociate not implemented")
 }
-func (UnimplementedPFCPSimServer) CreateSession(context.Context, *CreateSessionRequest) (*Response, error) {
+func (UnimplementedPFCPSimServer) UpdateAssociation(context.Context, *UpdateAssociationRequest) (*UpdateAssociationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAssociation not implemented")
+}
+func (UnimplementedPFCPSimServer) GetAssociation(context.Context, *EmptyRequest) (*GetAssociationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAssociation not implemented")
+}
+func (UnimplementedPFCPSimServer) CreateSession(context.Context, *CreateSessionRequest) (*SessionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateSession not implemented")
 }
-func (UnimplementedPFCPSimServer) ModifySession(context.Context, *ModifySessionRequest) (*Response, error) {
+func (UnimplementedPFCPSimServer) ModifySession(context.Context, *ModifySessionRequest) (*SessionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ModifySession not implemented")
 }
-func (UnimplementedPFCPSimServer) DeleteSession(context.Context, *DeleteSessionRequest) (*Response, error) {
+func (UnimplementedPFCPSimServer) DeleteSession(context.Context, *DeleteSessionRequest) (*SessionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteSession not implemented")
 }
+func (UnimplementedPFCPSimServer) ListSessions(context.Context, *EmptyRequest) (*ListSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessions not implemented")
+}
+func (UnimplementedPFCPSimServer) GetSession(context.Context, *GetSessionRequest) (*GetSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSession not implemented")
+}
+func (UnimplementedPFCPSimServer) GetUsageReports(context.Context, *GetUsageReportsRequest) (*GetUsageReportsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsageReports not implemented")
+}
+func (UnimplementedPFCPSimServer) AddUEPool(context.Context, *UEPool) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddUEPool not implemented")
+}
+func (UnimplementedPFCPSimServer) RemoveUEPool(context.Context, *RemoveUEPoolRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveUEPool not implemented")
+}
+func (UnimplementedPFCPSimServer) ListUEPools(context.Context, *EmptyRequest) (*ListUEPoolsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUEPools not implemented")
+}
+func (UnimplementedPFCPSimServer) WatchEvents(*WatchEventsRequest, PFCPSim_WatchEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchEvents not implemented")
+}
 func (UnimplementedPFCPSimServer) mustEmbedUnimplementedPFCPSimServer() {}
 
 // UnsafePFCPSimServer may be embedded to opt out of forward compatibility for this service.
@@ -199,6 +368,42 @@ func _PFCPSim_Disassociate_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PFCPSim_UpdateAssociation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAssociationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PFCPSimServer).UpdateAssociation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.PFCPSim/UpdateAssociation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PFCPSimServer).UpdateAssociation(ctx, req.(*UpdateAssociationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PFCPSim_GetAssociation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PFCPSimServer).GetAssociation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.PFCPSim/GetAssociation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PFCPSimServer).GetAssociation(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PFCPSim_CreateSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateSessionRequest)
 	if err := dec(in); err != nil {
@@ -253,6 +458,135 @@ func _PFCPSim_DeleteSession_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PFCPSim_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PFCPSimServer).ListSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.PFCPSim/ListSessions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PFCPSimServer).ListSessions(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PFCPSim_GetSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PFCPSimServer).GetSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.PFCPSim/GetSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PFCPSimServer).GetSession(ctx, req.(*GetSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PFCPSim_GetUsageReports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUsageReportsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PFCPSimServer).GetUsageReports(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.PFCPSim/GetUsageReports",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PFCPSimServer).GetUsageReports(ctx, req.(*GetUsageReportsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PFCPSim_AddUEPool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UEPool)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PFCPSimServer).AddUEPool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.PFCPSim/AddUEPool",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PFCPSimServer).AddUEPool(ctx, req.(*UEPool))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PFCPSim_RemoveUEPool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveUEPoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PFCPSimServer).RemoveUEPool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.PFCPSim/RemoveUEPool",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PFCPSimServer).RemoveUEPool(ctx, req.(*RemoveUEPoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PFCPSim_ListUEPools_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PFCPSimServer).ListUEPools(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.PFCPSim/ListUEPools",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PFCPSimServer).ListUEPools(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PFCPSim_WatchEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PFCPSimServer).WatchEvents(m, &pFCPSimWatchEventsServer{stream})
+}
+
+type PFCPSim_WatchEventsServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type pFCPSimWatchEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *pFCPSimWatchEventsServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // PFCPSim_ServiceDesc is the grpc.ServiceDesc for PFCPSim service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -272,6 +606,14 @@ var PFCPSim_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Disassociate",
 			Handler:    _PFCPSim_Disassociate_Handler,
 		},
+		{
+			MethodName: "UpdateAssociation",
+			Handler:    _PFCPSim_UpdateAssociation_Handler,
+		},
+		{
+			MethodName: "GetAssociation",
+			Handler:    _PFCPSim_GetAssociation_Handler,
+		},
 		{
 			MethodName: "CreateSession",
 			Handler:    _PFCPSim_CreateSession_Handler,
@@ -284,7 +626,37 @@ var PFCPSim_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteSession",
 			Handler:    _PFCPSim_DeleteSession_Handler,
 		},
+		{
+			MethodName: "ListSessions",
+			Handler:    _PFCPSim_ListSessions_Handler,
+		},
+		{
+			MethodName: "GetSession",
+			Handler:    _PFCPSim_GetSession_Handler,
+		},
+		{
+			MethodName: "GetUsageReports",
+			Handler:    _PFCPSim_GetUsageReports_Handler,
+		},
+		{
+			MethodName: "AddUEPool",
+			Handler:    _PFCPSim_AddUEPool_Handler,
+		},
+		{
+			MethodName: "RemoveUEPool",
+			Handler:    _PFCPSim_RemoveUEPool_Handler,
+		},
+		{
+			MethodName: "ListUEPools",
+			Handler:    _PFCPSim_ListUEPools_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchEvents",
+			Handler:       _PFCPSim_WatchEvents_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "pfcpsim.proto",
 }
@@ -25,6 +25,10 @@ func main() {
 		commands.GetServiceCommands(),
 		// Session commands
 		commands.GetSessionCommands(),
+		// Events commands
+		commands.GetEventsCommands(),
+		// UE pool commands
+		commands.GetPoolCommands(),
 	}
 
 	if err := app.Run(context.Background(), os.Args); err != nil {
@@ -5,15 +5,18 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"os"
 	"os/signal"
 	"sync"
 	"syscall"
+	"time"
 
 	pb "github.com/omec-project/pfcpsim/api"
 	"github.com/omec-project/pfcpsim/internal/pfcpsim"
 	"github.com/omec-project/pfcpsim/logger"
+	"github.com/omec-project/pfcpsim/pkg/pfcpsim/mockupf"
 	"github.com/urfave/cli/v3"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -21,6 +24,8 @@ import (
 
 const (
 	defaultgRPCServerPort = "54321"
+	// associationRetryPeriod is the period of the Association Setup Requests sent by the mock UPF with --cp-addr
+	associationRetryPeriod = 2 * time.Second
 )
 
 func startServer(apiDoneChannel chan bool, iFace string, port string, group *sync.WaitGroup) {
@@ -66,6 +71,41 @@ func main() {
 	app.Usage = "./pfcpsim --interface <interface_name> --port <gRPC_server_port>"
 	app.Flags = getCliFlags()
 	app.Action = action
+	app.Commands = []*cli.Command{
+		{
+			Name:  "mock-upf",
+			Usage: "Run a mock UPF answering PFCP requests, instead of the simulator",
+			// fault settings are comma-separated
+			DisableSliceFlagSeparator: true,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "addr",
+					Value: mockupf.DefaultAddress,
+					Usage: "the N4 address (host:port) to listen on",
+				},
+				&cli.StringFlag{
+					Name:  "node-id",
+					Usage: "the Node ID advertised to the CP function. Defaults to the host of --addr",
+				},
+				&cli.StringSliceFlag{
+					Name: "fault",
+					Usage: "inject a fault, e.g. msg=session-establishment,nth=2,drop. " +
+						"Settings: msg, nth, delay, jitter, cause, drop, wrong-type, duplicate. Can be repeated",
+				},
+				&cli.DurationFlag{
+					Name: "dl-data-delay",
+					Usage: "send a Downlink Data Report this long after a FAR is set to buffer and notify the CP function, " +
+						"emulating downlink data for an idle UE. Disabled if 0",
+				},
+				&cli.StringFlag{
+					Name: "cp-addr",
+					Usage: "initiate the association with the CP function at this address (host:port), " +
+						"retrying until it is accepted",
+				},
+			},
+			Action: mockUPFAction,
+		},
+	}
 
 	logger.PfcpsimLog.Infof("app name: %s", app.Name)
 
@@ -78,6 +118,12 @@ func action(ctx context.Context, c *cli.Command) error {
 	port := c.String("port")
 	iFaceName := c.String("interface")
 
+	if c.IsSet("profiles") {
+		if err := pfcpsim.LoadProfiles(c.String("profiles")); err != nil {
+			return err
+		}
+	}
+
 	// control channels, they are only closed when the goroutine needs to be terminated
 	doneChannel := make(chan bool)
 
@@ -102,6 +148,71 @@ func action(ctx context.Context, c *cli.Command) error {
 	return nil
 }
 
+func mockUPFAction(ctx context.Context, c *cli.Command) error {
+	upf := mockupf.NewMockUPF(c.String("addr"))
+	if c.IsSet("node-id") {
+		upf.SetNodeID(c.String("node-id"))
+	}
+
+	for _, spec := range c.StringSlice("fault") {
+		fault, err := mockupf.ParseFault(spec)
+		if err != nil {
+			return err
+		}
+
+		upf.InjectFault(fault)
+	}
+
+	dlDataDelay := c.Duration("dl-data-delay")
+	if dlDataDelay < 0 {
+		return fmt.Errorf("invalid dl-data-delay %v: negative values are not allowed", dlDataDelay)
+	}
+
+	upf.SetDownlinkDataReportDelay(dlDataDelay)
+
+	if err := upf.Start(); err != nil {
+		return err
+	}
+
+	if c.IsSet("cp-addr") {
+		go setupAssociation(upf, c.String("cp-addr"))
+	}
+
+	sigs := make(chan os.Signal, 1)
+	// SIGHUP emulates a restart of the UPF
+	signal.Notify(sigs, syscall.SIGTERM, syscall.SIGINT, syscall.SIGHUP)
+
+	for sig := range sigs {
+		if sig != syscall.SIGHUP {
+			break
+		}
+
+		upf.Restart()
+		logger.PfcpsimLog.Infof("mock UPF restarted with Recovery Time Stamp %v", upf.RecoveryTimeStamp())
+	}
+
+	upf.Stop()
+
+	logger.PfcpsimLog.Infoln("mock UPF shutting down")
+
+	return nil
+}
+
+// setupAssociation sends Association Setup Requests from upf to the CP function at cpAddr until one is accepted.
+func setupAssociation(upf *mockupf.MockUPF, cpAddr string) {
+	ticker := time.NewTicker(associationRetryPeriod)
+	defer ticker.Stop()
+
+	for !upf.IsAssociated() {
+		if err := upf.SetupAssociation(cpAddr); err != nil {
+			logger.PfcpsimLog.Errorf("mock UPF could not set up the association with %v: %v", cpAddr, err)
+			return
+		}
+
+		<-ticker.C
+	}
+}
+
 func getCliFlags() []cli.Flag {
 	return []cli.Flag{
 		&cli.StringFlag{
@@ -114,7 +225,13 @@ func getCliFlags() []cli.Flag {
 			Name:    "interface",
 			Aliases: []string{"i"},
 			Value:   "",
-			Usage:   "Defines the local address. If left blank, the IP will be taken from the first non-loopback interface",
+			Usage: "Defines the local address. If left blank, the IP will be taken from the first non-loopback interface. " +
+				"The first global IPv6 address of the interface is used when the remote peer is IPv6",
+		},
+		&cli.StringFlag{
+			Name: "profiles",
+			Usage: "Directory of the session profiles (YAML or JSON) selectable when creating sessions. " +
+				"Each profile is named after its file",
 		},
 	}
 }
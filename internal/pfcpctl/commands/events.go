@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	pb "github.com/omec-project/pfcpsim/api"
+	"github.com/omec-project/pfcpsim/logger"
+	"github.com/urfave/cli/v3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func GetEventsCommands() *cli.Command {
+	return &cli.Command{
+		Name:  "events",
+		Usage: "Handle N4 events",
+		Commands: []*cli.Command{
+			{
+				Name:  "watch",
+				Usage: "Print N4 events as they happen",
+				Flags: []cli.Flag{
+					&cli.StringSliceFlag{
+						Name:    "type",
+						Aliases: []string{"t"},
+						Usage: "Only print events of this type, can be repeated. e.g. AssociationUp, AssociationDown, " +
+							"HeartbeatFailure, SessionReport, NodeReport, UnsolicitedMessage, PeerRestarted, PagingCompleted, " +
+							"AssociationUpdated, UserPlanePathFailure, UserPlanePathRecovery",
+					},
+					&cli.BoolFlag{
+						Name:  "json",
+						Usage: "Print events in JSON format, one per line",
+					},
+				},
+				Action: func(ctx context.Context, c *cli.Command) error {
+					return eventsWatchAction(ctx, c)
+				},
+			},
+		},
+	}
+}
+
+func eventsWatchAction(ctx context.Context, c *cli.Command) error {
+	client := connect()
+	defer disconnect()
+
+	stream, err := client.WatchEvents(ctx, &pb.WatchEventsRequest{
+		Types: c.StringSlice("type"),
+	})
+	if err != nil {
+		logger.PfcpsimLog.Fatalf("error while watching events: %v", err)
+	}
+
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) || status.Code(err) == codes.Canceled {
+			return nil
+		}
+
+		if err != nil {
+			logger.PfcpsimLog.Fatalf("error while watching events: %v", err)
+		}
+
+		if c.Bool("json") {
+			// one event per line, so that the output can be consumed as a stream
+			out, err := protojson.Marshal(event)
+			if err != nil {
+				logger.PfcpsimLog.Fatalf("error while formatting event: %v", err)
+			}
+
+			fmt.Println(string(out))
+
+			continue
+		}
+
+		fmt.Printf("%v %-20v %v\n", event.Timestamp.AsTime().Format(time.RFC3339Nano), event.Type, event.Details)
+	}
+}
@@ -4,11 +4,19 @@
 package commands
 
 import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
 	pb "github.com/omec-project/pfcpsim/api"
 	"github.com/omec-project/pfcpsim/internal/pfcpctl/config"
 	"github.com/omec-project/pfcpsim/logger"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 )
 
 var conn *grpc.ClientConn
@@ -31,3 +39,65 @@ func disconnect() {
 		}
 	}
 }
+
+// printJSON prints msg to stdout in indented JSON format.
+func printJSON(msg proto.Message) {
+	out, err := protojson.MarshalOptions{Multiline: true}.Marshal(msg)
+	if err != nil {
+		logger.PfcpsimLog.Fatalf("error while formatting response: %v", err)
+	}
+
+	fmt.Println(string(out))
+}
+
+// sessionsResponseFromError returns the response carried in the details of err, returned by the
+// session procedures when some sessions fail. Returns nil if err does not carry one.
+func sessionsResponseFromError(err error) *pb.SessionsResponse {
+	st, ok := status.FromError(err)
+	if !ok {
+		return nil
+	}
+
+	for _, detail := range st.Details() {
+		if res, ok := detail.(*pb.SessionsResponse); ok {
+			return res
+		}
+	}
+
+	return nil
+}
+
+// printSessionResults prints the results of a session procedure. In JSON format every result is printed,
+// otherwise only the failed sessions are listed.
+func printSessionResults(res *pb.SessionsResponse, jsonOutput bool) {
+	if jsonOutput {
+		printJSON(res)
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	header := false
+
+	for _, result := range res.Results {
+		if result.Outcome == pb.SessionOutcome_SESSION_OUTCOME_SUCCESS {
+			continue
+		}
+
+		if !header {
+			fmt.Fprintln(w, "ID\tOUTCOME\tCAUSE\tUE ADDRESSES\tERROR")
+			header = true
+		}
+
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n",
+			result.Id,
+			strings.TrimPrefix(result.Outcome.String(), "SESSION_OUTCOME_"),
+			result.Cause,
+			strings.Join(result.UeAddresses, ","),
+			result.Message,
+		)
+	}
+
+	if err := w.Flush(); err != nil {
+		logger.PfcpsimLog.Warnln(err)
+	}
+}
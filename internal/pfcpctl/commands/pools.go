@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	pb "github.com/omec-project/pfcpsim/api"
+	"github.com/omec-project/pfcpsim/logger"
+	"github.com/urfave/cli/v3"
+)
+
+func GetPoolCommands() *cli.Command {
+	return &cli.Command{
+		Name:  "pool",
+		Usage: "Handle UE address pools",
+		Commands: []*cli.Command{
+			{
+				Name:  "add",
+				Usage: "Add a UE address pool",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "name",
+						Aliases:  []string{"n"},
+						Usage:    "The name of the pool, used as --ue-pool or --ue-pool-v6 of session create",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "network",
+						Aliases:  []string{"p"},
+						Usage:    "The network of the pool in CIDR notation. IPv6 pools delegate /64 prefixes",
+						Required: true,
+					},
+					&cli.StringSliceFlag{
+						Name:    "exclude",
+						Aliases: []string{"e"},
+						Usage: "Addresses never allocated, can be repeated. e.g. '10.0.0.1', '10.0.0.1-10.0.0.9' " +
+							"or '10.0.0.0/28'",
+					},
+				},
+				Action: func(ctx context.Context, c *cli.Command) error {
+					return poolAddAction(ctx, c)
+				},
+			},
+			{
+				Name:  "remove",
+				Usage: "Remove a UE address pool with no address in use",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "name",
+						Aliases:  []string{"n"},
+						Usage:    "The name of the pool",
+						Required: true,
+					},
+				},
+				Action: func(ctx context.Context, c *cli.Command) error {
+					return poolRemoveAction(ctx, c)
+				},
+			},
+			{
+				Name:  "list",
+				Usage: "List the UE address pools and their utilisation",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "json",
+						Usage: "Print pools in JSON format",
+					},
+				},
+				Action: func(ctx context.Context, c *cli.Command) error {
+					return poolListAction(ctx, c)
+				},
+			},
+		},
+	}
+}
+
+func poolAddAction(ctx context.Context, c *cli.Command) error {
+	client := connect()
+	defer disconnect()
+
+	res, err := client.AddUEPool(ctx, &pb.UEPool{
+		Name:       c.String("name"),
+		Network:    c.String("network"),
+		Exclusions: c.StringSlice("exclude"),
+	})
+	if err != nil {
+		logger.PfcpsimLog.Fatalf("error while adding UE pool: %v", err)
+	}
+
+	logger.PfcpsimLog.Infoln(res.Message)
+
+	return nil
+}
+
+func poolRemoveAction(ctx context.Context, c *cli.Command) error {
+	client := connect()
+	defer disconnect()
+
+	res, err := client.RemoveUEPool(ctx, &pb.RemoveUEPoolRequest{
+		Name: c.String("name"),
+	})
+	if err != nil {
+		logger.PfcpsimLog.Fatalf("error while removing UE pool: %v", err)
+	}
+
+	logger.PfcpsimLog.Infoln(res.Message)
+
+	return nil
+}
+
+func poolListAction(ctx context.Context, c *cli.Command) error {
+	client := connect()
+	defer disconnect()
+
+	res, err := client.ListUEPools(ctx, &pb.EmptyRequest{})
+	if err != nil {
+		logger.PfcpsimLog.Fatalf("error while listing UE pools: %v", err)
+	}
+
+	if c.Bool("json") {
+		printJSON(res)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tNETWORK\tEXCLUSIONS\tALLOCATED\tSIZE\tUTILISATION")
+
+	for _, pool := range res.Pools {
+		utilisation := 0.0
+		if pool.Size != 0 {
+			utilisation = float64(pool.Allocated) * 100 / float64(pool.Size)
+		}
+
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%.1f%%\n",
+			pool.Name,
+			pool.Network,
+			strings.Join(pool.Exclusions, ","),
+			pool.Allocated,
+			pool.Size,
+			utilisation,
+		)
+	}
+
+	return w.Flush()
+}
@@ -9,6 +9,7 @@ import (
 	pb "github.com/omec-project/pfcpsim/api"
 	"github.com/omec-project/pfcpsim/logger"
 	"github.com/urfave/cli/v3"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 func GetServiceCommands() *cli.Command {
@@ -30,6 +31,27 @@ func GetServiceCommands() *cli.Command {
 					return disassociateAction(ctx, c)
 				},
 			},
+			{
+				Name:  "update-association",
+				Usage: "Update the association with remote PFCP agent and print its state",
+				Flags: []cli.Flag{
+					&cli.StringSliceFlag{
+						Name: "cp-feature",
+						Usage: "A CP function feature to advertise (LOAD, OVRL, EPFAR, SSET, BUNDL, MPAS, ARDR, UIAUR, " +
+							"PSUCC, RPGUR). Can be repeated",
+					},
+				},
+				Action: func(ctx context.Context, c *cli.Command) error {
+					return updateAssociationAction(ctx, c)
+				},
+			},
+			{
+				Name:  "association",
+				Usage: "Print the state of the association, with the features negotiated with remote PFCP agent",
+				Action: func(ctx context.Context, c *cli.Command) error {
+					return associationAction(ctx, c)
+				},
+			},
 			{
 				Name:  "configure",
 				Usage: "Configure remote addresses",
@@ -46,6 +68,53 @@ func GetServiceCommands() *cli.Command {
 						Usage:   "UPF's N3 IP address",
 						Value:   "",
 					},
+					&cli.IntFlag{
+						Name:    "max-in-flight",
+						Aliases: []string{"m"},
+						Usage:   "The number of session requests that can wait for a response at the same time",
+						Value:   0,
+					},
+					&cli.DurationFlag{
+						Name:  "t1",
+						Usage: "The time to wait for a response before retransmitting a request (e.g. 3s)",
+						Value: 0,
+					},
+					&cli.BoolFlag{
+						Name:  "auto-recovery",
+						Usage: "Re-associate and re-establish active sessions when the remote peer restarts",
+					},
+					&cli.IntFlag{
+						Name:  "n1",
+						Usage: "The number of retransmissions before a request is considered failed (default: 0, no retransmission)",
+						Value: -1,
+					},
+					&cli.StringSliceFlag{
+						Name:  "teid-range",
+						Usage: "A range of uplink TEIDs to allocate from (e.g. 1000-1999). Can be repeated",
+					},
+					&cli.BoolFlag{
+						Name:  "paging-emulation",
+						Usage: "Switch downlink FARs back to forward after a Downlink Data Report, emulating the paging of idle UEs",
+					},
+					&cli.DurationFlag{
+						Name: "paging-delay",
+						Usage: "The time to wait after a Downlink Data Report before switching the downlink FARs back " +
+							"to forward (e.g. 100ms)",
+						Value: 0,
+					},
+					&cli.BoolFlag{
+						Name:  "passive",
+						Usage: "Wait for the UPF to initiate the Association Setup instead of sending the request",
+					},
+					&cli.StringFlag{
+						Name:  "upf-node-id",
+						Usage: "The Node ID accepted from the UPF in passive mode. Any Node ID is accepted if empty",
+					},
+					&cli.StringSliceFlag{
+						Name: "cp-feature",
+						Usage: "A CP function feature to advertise in the Association Setup (LOAD, OVRL, EPFAR, SSET, " +
+							"BUNDL, MPAS, ARDR, UIAUR, PSUCC, RPGUR). Can be repeated",
+					},
 				},
 				Action: func(ctx context.Context, c *cli.Command) error {
 					return configureAction(ctx, c)
@@ -61,11 +130,43 @@ func configureAction(ctx context.Context, c *cli.Command) error {
 
 	remotePeerAddr := c.String("remote-peer-addr")
 	n3Addr := c.String("n3-addr")
+	maxInFlight := c.Int("max-in-flight")
 
-	res, err := client.Configure(ctx, &pb.ConfigureRequest{
-		UpfN3Address:      n3Addr,
-		RemotePeerAddress: remotePeerAddr,
-	})
+	if maxInFlight < 0 {
+		logger.PfcpsimLog.Fatalln("max-in-flight cannot be a negative number")
+	}
+
+	t1 := c.Duration("t1")
+	if t1 < 0 {
+		logger.PfcpsimLog.Fatalln("t1 cannot be a negative duration")
+	}
+
+	pagingDelay := c.Duration("paging-delay")
+	if pagingDelay < 0 {
+		logger.PfcpsimLog.Fatalln("paging-delay cannot be a negative duration")
+	}
+
+	req := &pb.ConfigureRequest{
+		UpfN3Address:       n3Addr,
+		RemotePeerAddress:  remotePeerAddr,
+		MaxInFlight:        int32(maxInFlight),
+		T1:                 int32(t1.Milliseconds()),
+		AutoRecovery:       c.Bool("auto-recovery"),
+		TeidRanges:         c.StringSlice("teid-range"),
+		PagingEmulation:    c.Bool("paging-emulation"),
+		PagingDelay:        durationpb.New(pagingDelay),
+		PassiveAssociation: c.Bool("passive"),
+		UpfNodeID:          c.String("upf-node-id"),
+		CpFunctionFeatures: c.StringSlice("cp-feature"),
+	}
+
+	// n1 is sent only if explicitly set, 0 is a valid value that disables retransmissions
+	if c.IsSet("n1") {
+		n1 := int32(c.Int("n1"))
+		req.N1 = &n1
+	}
+
+	res, err := client.Configure(ctx, req)
 	if err != nil {
 		logger.PfcpsimLog.Fatalf("error while configuring remote addresses: %v", err)
 	}
@@ -99,3 +200,34 @@ func disassociateAction(ctx context.Context, _ *cli.Command) error {
 	logger.PfcpsimLog.Infoln(res.Message)
 	return nil
 }
+
+func updateAssociationAction(ctx context.Context, c *cli.Command) error {
+	client := connect()
+	defer disconnect()
+
+	res, err := client.UpdateAssociation(ctx, &pb.UpdateAssociationRequest{
+		CpFunctionFeatures: c.StringSlice("cp-feature"),
+	})
+	if err != nil {
+		logger.PfcpsimLog.Fatalf("error while updating association: %v", err)
+	}
+
+	logger.PfcpsimLog.Infoln(res.Message)
+	printJSON(res.Association)
+
+	return nil
+}
+
+func associationAction(ctx context.Context, c *cli.Command) error {
+	client := connect()
+	defer disconnect()
+
+	res, err := client.GetAssociation(ctx, &pb.EmptyRequest{})
+	if err != nil {
+		logger.PfcpsimLog.Fatalf("error while getting association: %v", err)
+	}
+
+	printJSON(res.Association)
+
+	return nil
+}
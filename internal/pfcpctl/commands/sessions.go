@@ -5,12 +5,23 @@ package commands
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	pb "github.com/omec-project/pfcpsim/api"
 	"github.com/omec-project/pfcpsim/logger"
 	"github.com/urfave/cli/v3"
 )
 
+var pdnTypes = map[string]pb.PdnType{
+	"ipv4":   pb.PdnType_PDN_TYPE_IPV4,
+	"ipv6":   pb.PdnType_PDN_TYPE_IPV6,
+	"ipv4v6": pb.PdnType_PDN_TYPE_IPV4V6,
+}
+
 // getCommonFlags returns the common flags used by session commands
 func getCommonFlags() []cli.Flag {
 	return []cli.Flag{
@@ -30,7 +41,17 @@ func getCommonFlags() []cli.Flag {
 			Name:    "ue-pool",
 			Aliases: []string{"u"},
 			Value:   "17.0.0.0/24",
-			Usage:   "The UE pool address",
+			Usage:   "The UE pool name or IPv4 network (e.g. 17.0.0.0/24) from which UE addresses are allocated",
+		},
+		&cli.StringFlag{
+			Name:  "ue-pool-v6",
+			Value: "2001:db8:17::/48",
+			Usage: "The UE pool name or IPv6 network from which a /64 prefix is delegated to each UE",
+		},
+		&cli.StringFlag{
+			Name:  "pdn-type",
+			Value: "ipv4",
+			Usage: "The PDN type of the sessions: ipv4, ipv6 or ipv4v6",
 		},
 		&cli.StringFlag{
 			Name:    "gnb-addr",
@@ -41,7 +62,7 @@ func getCommonFlags() []cli.Flag {
 			Name:    "app-filter",
 			Aliases: []string{"a"},
 			Value:   []string{"ip:any:any:allow:100"},
-			Usage:   "Specify an application filter. Format: '{ip | udp | tcp}:{IPv4 Prefix | any}:{<lower-L4-port>-<upper-L4-port> | any}:{allow | deny}:{rule-precedence}' . e.g. 'udp:10.0.0.0/8:80-88:allow:100'",
+			Usage:   "Specify an application filter. Format: '{ip | udp | tcp}:{IPv4 Prefix | IPv6 Prefix | any}:{<lower-L4-port>-<upper-L4-port> | any}:{allow | deny}:{rule-precedence}' . e.g. 'udp:10.0.0.0/8:80-88:allow:100'",
 		},
 		&cli.UintFlag{
 			Name:    "qfi",
@@ -51,6 +72,39 @@ func getCommonFlags() []cli.Flag {
 	}
 }
 
+var failurePolicies = map[string]pb.FailurePolicy{
+	"continue": pb.FailurePolicy_FAILURE_POLICY_CONTINUE,
+	"stop":     pb.FailurePolicy_FAILURE_POLICY_STOP,
+	"rollback": pb.FailurePolicy_FAILURE_POLICY_ROLLBACK,
+}
+
+// newFailurePolicyFlag returns the flag selecting how the failure of a session is handled.
+func newFailurePolicyFlag(usage string) cli.Flag {
+	return &cli.StringFlag{
+		Name:  "on-failure",
+		Value: "continue",
+		Usage: usage,
+	}
+}
+
+// getFailurePolicy returns the failure policy selected with the on-failure flag.
+func getFailurePolicy(c *cli.Command) pb.FailurePolicy {
+	policy, ok := failurePolicies[c.String("on-failure")]
+	if !ok {
+		logger.PfcpsimLog.Fatalf("unknown failure policy %v. Use continue, stop or rollback", c.String("on-failure"))
+	}
+
+	return policy
+}
+
+// newJSONFlag returns the flag selecting the JSON output of the session procedures results.
+func newJSONFlag() cli.Flag {
+	return &cli.BoolFlag{
+		Name:  "json",
+		Usage: "Print the result of every session in JSON format",
+	}
+}
+
 // validateCommonArgs validates common arguments
 func validateCommonArgs(c *cli.Command) {
 	baseID := c.Int("baseID")
@@ -73,7 +127,23 @@ func GetSessionCommands() *cli.Command {
 			{
 				Name:  "create",
 				Usage: "Create sessions",
-				Flags: getCommonFlags(),
+				Flags: append(getCommonFlags(), []cli.Flag{
+					newJSONFlag(),
+					newFailurePolicyFlag("What to do when a session fails: continue, stop, or rollback to delete the sessions created by the command"),
+					&cli.StringFlag{
+						Name:    "profile",
+						Aliases: []string{"p"},
+						Usage:   "The name of the session profile describing the rules. The built-in default profile is used if not set",
+					},
+					&cli.BoolFlag{
+						Name:  "upf-teid",
+						Usage: "Let the UPF allocate the uplink F-TEID (CHOOSE flag) instead of pfcpsim",
+					},
+					&cli.BoolFlag{
+						Name:  "upf-ue-addr",
+						Usage: "Let the UPF allocate the UE addresses (CHV4/CHV6 flags) instead of pfcpsim. UE pools are ignored",
+					},
+				}...),
 				Action: func(ctx context.Context, c *cli.Command) error {
 					return sessionCreateAction(ctx, c)
 				},
@@ -82,6 +152,8 @@ func GetSessionCommands() *cli.Command {
 				Name:  "modify",
 				Usage: "Modify sessions",
 				Flags: append(getCommonFlags(), []cli.Flag{
+					newJSONFlag(),
+					newFailurePolicyFlag("What to do when a session fails: continue or stop"),
 					&cli.BoolFlag{
 						Name:    "buffer",
 						Aliases: []string{"b"},
@@ -92,15 +164,93 @@ func GetSessionCommands() *cli.Command {
 						Aliases: []string{"n"},
 						Usage:   "Set true to have downlink FARs notify CP",
 					},
+					&cli.Uint32Flag{
+						Name:  "gnb-teid",
+						Usage: "The gNB TEID set in the downlink FARs. The session index is used if not set",
+					},
+					&cli.Uint32SliceFlag{
+						Name:  "qer-id",
+						Usage: "The ID of a QER updated by --ul-mbr, --dl-mbr, --ul-gbr, --dl-gbr and --gate. Every QER is updated if not set",
+					},
+					&cli.Uint64Flag{
+						Name:  "ul-mbr",
+						Usage: "The new uplink MBR of the QERs",
+					},
+					&cli.Uint64Flag{
+						Name:  "dl-mbr",
+						Usage: "The new downlink MBR of the QERs",
+					},
+					&cli.Uint64Flag{
+						Name:  "ul-gbr",
+						Usage: "The new uplink GBR of the QERs",
+					},
+					&cli.Uint64Flag{
+						Name:  "dl-gbr",
+						Usage: "The new downlink GBR of the QERs",
+					},
+					&cli.StringFlag{
+						Name:  "gate",
+						Usage: "The new gate status of the QERs: open or closed",
+					},
+					&cli.StringSliceFlag{
+						Name:  "add-app-filter",
+						Usage: "Add an application filter, with the same format of --app-filter. The QFI of its QERs is set by --qfi",
+					},
+					&cli.StringSliceFlag{
+						Name:  "remove-app-filter",
+						Usage: "Remove the PDRs of an application filter, with the same format of --app-filter",
+					},
+					&cli.BoolFlag{
+						Name:  "query-urr",
+						Usage: "Ask the UPF for an immediate usage report of every URR (Query URR)",
+					},
 				}...),
 				Action: func(ctx context.Context, c *cli.Command) error {
 					return sessionModifyAction(ctx, c)
 				},
 			},
+			{
+				Name:  "list",
+				Usage: "List active sessions",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "json",
+						Usage: "Print sessions in JSON format",
+					},
+				},
+				Action: func(ctx context.Context, c *cli.Command) error {
+					return sessionListAction(ctx, c)
+				},
+			},
+			{
+				Name:  "show",
+				Usage: "Show the state of a session",
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:     "id",
+						Usage:    "The index the session was created with",
+						Required: true,
+					},
+				},
+				Action: func(ctx context.Context, c *cli.Command) error {
+					return sessionShowAction(ctx, c)
+				},
+			},
+			{
+				Name:  "reports",
+				Usage: "Show the usage reports received from the UPF",
+				Flags: getCommonFlags(),
+				Action: func(ctx context.Context, c *cli.Command) error {
+					return sessionReportsAction(ctx, c)
+				},
+			},
 			{
 				Name:  "delete",
 				Usage: "Delete sessions",
-				Flags: getCommonFlags(),
+				Flags: append(getCommonFlags(), []cli.Flag{
+					newJSONFlag(),
+					newFailurePolicyFlag("What to do when a session fails: continue or stop"),
+				}...),
 				Action: func(ctx context.Context, c *cli.Command) error {
 					return sessionDeleteAction(ctx, c)
 				},
@@ -115,23 +265,40 @@ func sessionCreateAction(ctx context.Context, c *cli.Command) error {
 		logger.PfcpsimLog.Fatalf("qfi cannot be greater than 64. Provided qfi: %v", qfi)
 	}
 
+	pdnType, ok := pdnTypes[c.String("pdn-type")]
+	if !ok {
+		logger.PfcpsimLog.Fatalf("unknown pdn-type %v. Use ipv4, ipv6 or ipv4v6", c.String("pdn-type"))
+	}
+
 	client := connect()
 	defer disconnect()
 
 	validateCommonArgs(c)
 
 	res, err := client.CreateSession(ctx, &pb.CreateSessionRequest{
-		Count:         int32(c.Int("count")),
-		BaseID:        int32(c.Int("baseID")),
-		NodeBAddress:  c.String("gnb-addr"),
-		UeAddressPool: c.String("ue-pool"),
-		AppFilters:    c.StringSlice("app-filter"),
-		Qfi:           int32(qfi),
+		Count:                 int32(c.Int("count")),
+		BaseID:                int32(c.Int("baseID")),
+		NodeBAddress:          c.String("gnb-addr"),
+		UeAddressPool:         c.String("ue-pool"),
+		UeAddressPoolV6:       c.String("ue-pool-v6"),
+		PdnType:               pdnType,
+		AppFilters:            c.StringSlice("app-filter"),
+		Qfi:                   int32(qfi),
+		Profile:               c.String("profile"),
+		FailurePolicy:         getFailurePolicy(c),
+		UpfAllocatedTEID:      c.Bool("upf-teid"),
+		UpfAllocatedUEAddress: c.Bool("upf-ue-addr"),
 	})
 	if err != nil {
+		if res := sessionsResponseFromError(err); res != nil {
+			printSessionResults(res, c.Bool("json"))
+		}
+
 		logger.PfcpsimLog.Fatalf("error while creating sessions: %v", err)
 	}
 
+	printSessionResults(res, c.Bool("json"))
+
 	logger.PfcpsimLog.Infoln(res.Message)
 	return nil
 }
@@ -142,20 +309,54 @@ func sessionModifyAction(ctx context.Context, c *cli.Command) error {
 
 	validateCommonArgs(c)
 
+	qfi := c.Uint("qfi")
+	if qfi > 64 {
+		logger.PfcpsimLog.Fatalf("qfi cannot be greater than 64. Provided qfi: %v", qfi)
+	}
+
+	var qerUpdate *pb.QERUpdate
+
+	for _, flag := range []string{"qer-id", "ul-mbr", "dl-mbr", "ul-gbr", "dl-gbr", "gate"} {
+		if c.IsSet(flag) {
+			qerUpdate = &pb.QERUpdate{
+				Ids:         c.Uint32Slice("qer-id"),
+				UplinkMBR:   c.Uint64("ul-mbr"),
+				DownlinkMBR: c.Uint64("dl-mbr"),
+				UplinkGBR:   c.Uint64("ul-gbr"),
+				DownlinkGBR: c.Uint64("dl-gbr"),
+				GateStatus:  c.String("gate"),
+			}
+
+			break
+		}
+	}
+
 	res, err := client.ModifySession(ctx, &pb.ModifySessionRequest{
-		Count:         int32(c.Int("count")),
-		BaseID:        int32(c.Int("baseID")),
-		NodeBAddress:  c.String("gnb-addr"),
-		UeAddressPool: c.String("ue-pool"),
-		BufferFlag:    c.Bool("buffer"),
-		NotifyCPFlag:  c.Bool("notifycp"),
-		AppFilters:    c.StringSlice("app-filter"),
+		Count:            int32(c.Int("count")),
+		BaseID:           int32(c.Int("baseID")),
+		NodeBAddress:     c.String("gnb-addr"),
+		UeAddressPool:    c.String("ue-pool"),
+		BufferFlag:       c.Bool("buffer"),
+		NotifyCPFlag:     c.Bool("notifycp"),
+		FailurePolicy:    getFailurePolicy(c),
+		NodeBTEID:        c.Uint32("gnb-teid"),
+		QerUpdate:        qerUpdate,
+		AddAppFilters:    c.StringSlice("add-app-filter"),
+		RemoveAppFilters: c.StringSlice("remove-app-filter"),
+		QueryURR:         c.Bool("query-urr"),
+		Qfi:              int32(qfi),
 	})
 	if err != nil {
+		if res := sessionsResponseFromError(err); res != nil {
+			printSessionResults(res, c.Bool("json"))
+		}
+
 		logger.PfcpsimLog.Fatalf("error while modifying sessions: %v", err)
 	}
 
-	logger.PfcpsimLog.Infof(res.Message)
+	printSessionResults(res, c.Bool("json"))
+
+	logger.PfcpsimLog.Infoln(res.Message)
 	return nil
 }
 
@@ -166,13 +367,88 @@ func sessionDeleteAction(ctx context.Context, c *cli.Command) error {
 	validateCommonArgs(c)
 
 	res, err := client.DeleteSession(ctx, &pb.DeleteSessionRequest{
-		Count:  int32(c.Int("count")),
-		BaseID: int32(c.Int("baseID")),
+		Count:         int32(c.Int("count")),
+		BaseID:        int32(c.Int("baseID")),
+		FailurePolicy: getFailurePolicy(c),
 	})
 	if err != nil {
+		if res := sessionsResponseFromError(err); res != nil {
+			printSessionResults(res, c.Bool("json"))
+		}
+
 		logger.PfcpsimLog.Fatalf("error while deleting sessions: %v", err)
 	}
 
+	printSessionResults(res, c.Bool("json"))
+
 	logger.PfcpsimLog.Infoln(res.Message)
 	return nil
 }
+
+func sessionReportsAction(ctx context.Context, c *cli.Command) error {
+	client := connect()
+	defer disconnect()
+
+	validateCommonArgs(c)
+
+	res, err := client.GetUsageReports(ctx, &pb.GetUsageReportsRequest{
+		Count:  int32(c.Int("count")),
+		BaseID: int32(c.Int("baseID")),
+	})
+	if err != nil {
+		logger.PfcpsimLog.Fatalf("error while retrieving usage reports: %v", err)
+	}
+
+	printJSON(res)
+
+	return nil
+}
+
+func sessionListAction(ctx context.Context, c *cli.Command) error {
+	client := connect()
+	defer disconnect()
+
+	res, err := client.ListSessions(ctx, &pb.EmptyRequest{})
+	if err != nil {
+		logger.PfcpsimLog.Fatalf("error while listing sessions: %v", err)
+	}
+
+	if c.Bool("json") {
+		printJSON(res)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tLOCAL SEID\tPEER SEID\tUE ADDRESSES\tUPLINK TEID\tGNB ADDRESS\tCREATED\tMODIFIED")
+
+	for _, sess := range res.Sessions {
+		fmt.Fprintf(w, "%v\t%#x\t%#x\t%v\t%v\t%v\t%v\t%v\n",
+			sess.Id,
+			sess.LocalSEID,
+			sess.PeerSEID,
+			strings.Join(sess.UeAddresses, ","),
+			sess.UplinkTEID,
+			sess.NodeBAddress,
+			sess.CreatedAt.AsTime().Format(time.RFC3339),
+			sess.ModifiedAt.AsTime().Format(time.RFC3339),
+		)
+	}
+
+	return w.Flush()
+}
+
+func sessionShowAction(ctx context.Context, c *cli.Command) error {
+	client := connect()
+	defer disconnect()
+
+	res, err := client.GetSession(ctx, &pb.GetSessionRequest{
+		Id: int32(c.Int("id")),
+	})
+	if err != nil {
+		logger.PfcpsimLog.Fatalf("error while retrieving session: %v", err)
+	}
+
+	printJSON(res.Session)
+
+	return nil
+}
@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"sync"
+
+	pb "github.com/omec-project/pfcpsim/api"
+	"github.com/omec-project/pfcpsim/logger"
+	"github.com/omec-project/pfcpsim/pkg/pfcpsim"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// subscriberBufferSize is the number of events queued for a slow WatchEvents client before dropping them.
+const subscriberBufferSize = 256
+
+// eventBroker fans out the events published by PFCPClient to every WatchEvents stream.
+type eventBroker struct {
+	lock        sync.Mutex
+	subscribers map[chan *pb.Event]struct{}
+}
+
+var broker = &eventBroker{
+	subscribers: make(map[chan *pb.Event]struct{}),
+}
+
+func (b *eventBroker) subscribe() chan *pb.Event {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	ch := make(chan *pb.Event, subscriberBufferSize)
+	b.subscribers[ch] = struct{}{}
+
+	return ch
+}
+
+func (b *eventBroker) unsubscribe(ch chan *pb.Event) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	delete(b.subscribers, ch)
+}
+
+func (b *eventBroker) publish(event *pb.Event) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			logger.PfcpsimLog.Debugf("WatchEvents client too slow, dropping event %v", event.Type)
+		}
+	}
+}
+
+// run publishes every event received on events until the channel is closed.
+func (b *eventBroker) run(events <-chan pfcpsim.Event) {
+	for event := range events {
+		b.publish(toPBEvent(event))
+	}
+}
+
+func toPBEvent(event pfcpsim.Event) *pb.Event {
+	ev := &pb.Event{
+		Type:            event.Type.String(),
+		Timestamp:       timestamppb.New(event.Timestamp),
+		Details:         event.Details,
+		RemoteGTPUPeers: event.RemoteGTPUPeers,
+	}
+
+	if event.Message != nil {
+		ev.MessageType = event.Message.MessageTypeName()
+		ev.SequenceNumber = event.Message.Sequence()
+		ev.Seid = event.Message.SEID()
+	}
+
+	return ev
+}
+
+// isEventSelected reports whether event matches one of types. An empty types selects every event.
+func isEventSelected(event *pb.Event, types map[string]struct{}) bool {
+	if len(types) == 0 {
+		return true
+	}
+
+	_, ok := types[event.Type]
+
+	return ok
+}
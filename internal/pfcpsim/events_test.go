@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/omec-project/pfcpsim/api"
+	"github.com/omec-project/pfcpsim/pkg/pfcpsim"
+)
+
+func Test_eventBroker(t *testing.T) {
+	b := &eventBroker{subscribers: make(map[chan *pb.Event]struct{})}
+
+	first := b.subscribe()
+	second := b.subscribe()
+	b.unsubscribe(second)
+
+	events := make(chan pfcpsim.Event, 1)
+	events <- pfcpsim.Event{Type: pfcpsim.EventAssociationDown, Timestamp: time.Now(), Details: "heartbeat failure"}
+	close(events)
+
+	b.run(events)
+
+	select {
+	case event := <-first:
+		if event.Type != "AssociationDown" || event.Details != "heartbeat failure" {
+			t.Errorf("event mismatch. got = %v", event)
+		}
+	default:
+		t.Fatal("expected an event on the subscribed channel")
+	}
+
+	select {
+	case event := <-second:
+		t.Errorf("unexpected event on unsubscribed channel: %v", event)
+	default:
+	}
+}
+
+func Test_isEventSelected(t *testing.T) {
+	event := &pb.Event{Type: "SessionReport"}
+
+	tests := []struct {
+		name  string
+		types map[string]struct{}
+		want  bool
+	}{
+		{name: "No filter", want: true},
+		{name: "Selected type", types: map[string]struct{}{"SessionReport": {}}, want: true},
+		{name: "Other type", types: map[string]struct{}{"NodeReport": {}}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isEventSelected(event, tt.types); got != tt.want {
+				t.Errorf("isEventSelected() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
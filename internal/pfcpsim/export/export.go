@@ -26,8 +26,6 @@ const (
 
 var errNotConnected = errors.New("not connected")
 
-const SessionStep = 10
-
 type PfcpSimCfg struct {
 	interfaceName string
 	upfN3         string
@@ -104,14 +102,15 @@ func (c *PfcpSimCfg) CreateSession(baseID int,
 
 	var qfi uint8 = 6
 
-	for i := baseID; i < (count*SessionStep + baseID); i = i + SessionStep {
+	for i := baseID; i < baseID+count; i++ {
 		// using variables to ease comprehension on how rules are linked together
 		uplinkTEID := uint32(i)
 
 		ueAddress := pfcpsim.NextIP(lastUEAddr)
 		lastUEAddr = ueAddress
 
-		sessQerID := uint32(0)
+		ids := session.NewIDAllocator()
+		sessQerID := ids.New(session.RuleQER)
 
 		var pdrs, fars, urrs []*ieLib.IE
 
@@ -127,8 +126,6 @@ func (c *PfcpSimCfg) CreateSession(baseID int,
 		}
 
 		// create as many PDRs, FARs and App QERs as the number of app filters provided through pfcpctl
-		ID := uint16(i)
-
 		for _, appFilter := range appFilters {
 			SDFFilter, gateStatus, precedence, err := pfcpsim.ParseAppFilter(appFilter)
 			if err != nil {
@@ -137,18 +134,20 @@ func (c *PfcpSimCfg) CreateSession(baseID int,
 
 			logger.PfcpsimLog.Infof("successfully parsed application filter. SDF Filter: %v", SDFFilter)
 
-			uplinkPdrID := ID
-			downlinkPdrID := ID + 1
+			uplinkPdrID := uint16(ids.New(session.RulePDR))
+			downlinkPdrID := uint16(ids.New(session.RulePDR))
+
+			uplinkFarID := ids.New(session.RuleFAR)
+			downlinkFarID := ids.New(session.RuleFAR)
 
-			uplinkFarID := uint32(ID)
-			downlinkFarID := uint32(ID + 1)
+			uplinkAppQerID := ids.New(session.RuleQER)
+			downlinkAppQerID := ids.New(session.RuleQER)
 
-			uplinkAppQerID := uint32(ID)
-			downlinkAppQerID := uint32(ID + 1)
+			uplinkURRID := ids.New(session.RuleURR)
+			downlinkURRID := ids.New(session.RuleURR)
 
-			urrId := uint32(ID)
 			urr := session.NewURRBuilder().
-				WithID(urrId).
+				WithID(uplinkURRID).
 				WithMethod(session.Create).
 				WithMeasurementMethod(0, 1, 0).
 				WithMeasurementPeriod(1*time.Second).
@@ -161,7 +160,7 @@ func (c *PfcpSimCfg) CreateSession(baseID int,
 			urrs = append(urrs, urr)
 
 			urr = session.NewURRBuilder().
-				WithID(urrId+1).
+				WithID(downlinkURRID).
 				WithMethod(session.Create).
 				WithMeasurementMethod(0, 1, 0).
 				WithMeasurementPeriod(1*time.Second).
@@ -182,6 +181,7 @@ func (c *PfcpSimCfg) CreateSession(baseID int,
 				WithFARID(uplinkFarID).
 				AddQERID(sessQerID).
 				AddQERID(uplinkAppQerID).
+				AddURRID(uplinkURRID).
 				WithN3Address(c.upfN3).
 				WithSDFFilter(SDFFilter).
 				WithPrecedence(precedence).
@@ -197,6 +197,7 @@ func (c *PfcpSimCfg) CreateSession(baseID int,
 				WithSDFFilter(SDFFilter).
 				AddQERID(sessQerID).
 				AddQERID(downlinkAppQerID).
+				AddURRID(downlinkURRID).
 				WithFARID(downlinkFarID).
 				MarkAsDownlink().
 				FuzzIE(pdrBuilder, fuzz).
@@ -247,8 +248,6 @@ func (c *PfcpSimCfg) CreateSession(baseID int,
 
 			qers = append(qers, uplinkAppQER)
 			qers = append(qers, downlinkAppQER)
-
-			ID += 2
 		}
 
 		sess, err := c.sim.EstablishSession(pdrs, fars, qers, urrs)
@@ -256,6 +255,7 @@ func (c *PfcpSimCfg) CreateSession(baseID int,
 			return status.Error(codes.Internal, err.Error())
 		}
 
+		sess.SetIDAllocator(ids)
 		sim.InsertSession(i, sess)
 	}
 
@@ -273,19 +273,26 @@ func (c *PfcpSimCfg) ModifySession(baseID int,
 	actions |= session.ActionNotify
 	count := 1
 	nodeBaddress := "192.168.0.1"
-	appFilters := []string{""}
 
-	for i := baseID; i < (count*SessionStep + baseID); i = i + SessionStep {
+	for i := baseID; i < baseID+count; i++ {
+		sess, ok := sim.GetSession(i)
+		if !ok {
+			errMsg := fmt.Sprintf("Could not retrieve session with index %v", i)
+			logger.PfcpsimLog.Errorln(errMsg)
+
+			return status.Error(codes.Internal, errMsg)
+		}
+
 		var newFARs []*ieLib.IE
 
 		var newURRs []*ieLib.IE
 
-		ID := uint32(i + 1)
-		teid := uint32(i + 1)
+		teid := uint32(i)
+		farIDs, urrIDs := sess.DownlinkRules()
 
-		for j := 0; j < len(appFilters); j++ {
+		for _, farID := range farIDs {
 			downlinkFAR := session.NewFARBuilder().
-				WithID(ID). // Same FARID that was generated in create sessions
+				WithID(farID).
 				WithMethod(session.Update).
 				WithAction(actions).
 				WithDstInterface(ieLib.DstInterfaceAccess).
@@ -295,26 +302,17 @@ func (c *PfcpSimCfg) ModifySession(baseID int,
 				BuildFAR()
 
 			newFARs = append(newFARs, downlinkFAR)
+		}
 
-			urrId := ID
+		for _, urrID := range urrIDs {
 			urr := session.NewURRBuilder().
-				WithID(urrId).
+				WithID(urrID).
 				WithMethod(session.Update).
 				WithMeasurementPeriod(1*time.Second).
 				FuzzIE(urrBuilder, fuzz).
 				Build()
 
 			newURRs = append(newURRs, urr)
-
-			ID += 2
-		}
-
-		sess, ok := sim.GetSession(i)
-		if !ok {
-			errMsg := fmt.Sprintf("Could not retrieve session with index %v", i)
-			logger.PfcpsimLog.Errorln(errMsg)
-
-			return status.Error(codes.Internal, errMsg)
 		}
 
 		err := c.sim.ModifySession(sess, nil, newFARs, nil, newURRs)
@@ -338,7 +336,7 @@ func (c *PfcpSimCfg) DeleteSession(baseID int) error {
 		return status.Error(codes.Aborted, err.Error())
 	}
 
-	for i := baseID; i < (count*SessionStep + baseID); i = i + SessionStep {
+	for i := baseID; i < baseID+count; i++ {
 		sess, ok := sim.GetSession(i)
 		if !ok {
 			errMsg := "Session was nil. Check baseID"
@@ -4,21 +4,28 @@
 package pfcpsim
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net"
 	"strconv"
 	"strings"
 
+	pb "github.com/omec-project/pfcpsim/api"
 	"github.com/omec-project/pfcpsim/logger"
 	"github.com/omec-project/pfcpsim/pkg/pfcpsim"
 	"github.com/wmnsk/go-pfcp/ie"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var errNotInit = errors.New("PFCP simulator is not initialized")
 
+// ueIPv6PrefixLength is the length of the IPv6 prefix delegated to each UE.
+const ueIPv6PrefixLength = 64
+
 const (
 	sdfFilterFormatWPort  = "permit out %v from %v to assigned %v-%v"
 	sdfFilterFormatWOPort = "permit out %v from %v to assigned"
@@ -30,14 +37,18 @@ func GetSimulator() *pfcpsim.PFCPClient {
 
 func ConnectPFCPSim() error {
 	if sim == nil {
-		localAddr, err := getLocalAddress(interfaceName)
+		localAddr, err := getLocalAddress(interfaceName, isIPv6Peer(remotePeerAddress))
 		if err != nil {
 			return err
 		}
 
 		sim = pfcpsim.NewPFCPClient(localAddr.String())
+
+		go broker.run(sim.Events())
 	}
 
+	applyClientSettings()
+
 	err := sim.ConnectN4(remotePeerAddress)
 	if err != nil {
 		return err
@@ -48,6 +59,17 @@ func ConnectPFCPSim() error {
 	return nil
 }
 
+// applyClientSettings configures sim with the values received through Configure.
+func applyClientSettings() {
+	sim.SetMaxInFlight(maxInFlight)
+	sim.SetPFCPResponseTimeout(responseTimeout)
+	sim.SetMaxRetransmissions(maxRetransmissions)
+	sim.EnableAutoRecovery(autoRecovery)
+	sim.EnablePagingEmulation(pagingEmulation, pagingDelay)
+	sim.EnablePassiveAssociation(passiveAssociation, upfNodeID)
+	sim.SetCPFunctionFeatures(cpFunctionFeatures)
+}
+
 func DisconnectPFCPSim() error {
 	if sim == nil {
 		return errNotInit
@@ -68,22 +90,124 @@ func isRemotePeerConnected() bool {
 	return remotePeerConnected
 }
 
-// isNumOfAppFiltersCorrect returns error if the number of the passed filter
-// exceed the max number of supported application filters.
-func isNumOfAppFiltersCorrect(filters []string) error {
-	if len(filters) > SessionStep/2 {
-		logger.PfcpsimLog.Errorf("too many application filters: %v", filters)
-		return status.Error(codes.Aborted, "Too many application filters")
+// checkUEAddressPools returns an error if the UE pools required by the PDN type of request
+// do not exist and cannot be created from the given networks. No pool is required if the UPF
+// allocates the UE addresses.
+func checkUEAddressPools(request *pb.CreateSessionRequest) error {
+	if request.GetUpfAllocatedUEAddress() {
+		return nil
+	}
+
+	pdnType := request.GetPdnType()
+
+	if hasIPv4(pdnType) {
+		if err := uePools.check(request.GetUeAddressPool(), false); err != nil {
+			return err
+		}
+	}
+
+	if hasIPv6(pdnType) {
+		if err := uePools.check(request.GetUeAddressPoolV6(), true); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// allocateUEAddresses allocates the UE IPv4 address and the UE IPv6 prefix required by the PDN type
+// of request. Returns the allocated addresses, none if any allocation fails or if the UPF allocates them.
+func allocateUEAddresses(request *pb.CreateSessionRequest) (string, string, error) {
+	var ueAddress, ueIPv6Prefix string
+
+	if request.GetUpfAllocatedUEAddress() {
+		return "", "", nil
+	}
+
+	pdnType := request.GetPdnType()
+
+	if hasIPv4(pdnType) {
+		address, err := uePools.allocate(request.GetUeAddressPool(), false)
+		if err != nil {
+			return "", "", err
+		}
+
+		ueAddress = address
+	}
+
+	if hasIPv6(pdnType) {
+		prefix, err := uePools.allocate(request.GetUeAddressPoolV6(), true)
+		if err != nil {
+			uePools.release(ueAddress)
+			return "", "", err
+		}
+
+		ueIPv6Prefix = prefix
+	}
+
+	return ueAddress, ueIPv6Prefix, nil
+}
+
+// hasIPv4 reports whether sessions of pdnType get a UE IPv4 address.
+func hasIPv4(pdnType pb.PdnType) bool {
+	return pdnType == pb.PdnType_PDN_TYPE_IPV4 || pdnType == pb.PdnType_PDN_TYPE_IPV4V6
+}
+
+// hasIPv6 reports whether sessions of pdnType get a UE IPv6 prefix.
+func hasIPv6(pdnType pb.PdnType) bool {
+	return pdnType == pb.PdnType_PDN_TYPE_IPV6 || pdnType == pb.PdnType_PDN_TYPE_IPV4V6
+}
+
+// releaseSessionResources makes the UE addresses and the TEID allocated to the session id available again.
+func releaseSessionResources(id int) {
+	uePools.releaseSession(id)
+	teids.release(id)
+}
+
+// toPBUEPool converts the utilisation of a UE pool into its protobuf representation.
+func toPBUEPool(usage uePoolUsage) *pb.UEPool {
+	return &pb.UEPool{
+		Name:       usage.name,
+		Network:    usage.network,
+		Exclusions: usage.exclusions,
+		Size:       usage.size,
+		Allocated:  usage.allocated,
+	}
+}
+
+// isIPv6Peer reports whether the remote peer address, optionally including a port,
+// is an IPv6 address or a hostname resolving only to IPv6 addresses.
+func isIPv6Peer(addr string) bool {
+	host := addr
+	if h, _, err := net.SplitHostPort(addr); err == nil {
+		host = h
+	}
+
+	host = strings.Trim(host, "[]")
+
+	if ip := net.ParseIP(host); ip != nil {
+		return ip.To4() == nil
+	}
+
+	ips, err := net.DefaultResolver.LookupIP(context.Background(), "ip", host)
+	if err != nil || len(ips) == 0 {
+		return false
+	}
+
+	for _, ip := range ips {
+		if ip.To4() != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
 // getLocalAddress returns the first IP address of the interfaceName, if
 // specified, otherwise returns the IP address of the first non-loopback
-// interface
+// interface. If ipv6 is true, the first global IPv6 address is returned instead.
 // Returns error if fail occurs at any stage.
-func getLocalAddress(interfaceName string) (net.IP, error) {
+func getLocalAddress(interfaceName string, ipv6 bool) (net.IP, error) {
 	addrs, err := net.InterfaceAddrs()
 	if err != nil {
 		return nil, err
@@ -103,10 +227,18 @@ func getLocalAddress(interfaceName string) (net.IP, error) {
 	}
 
 	for _, address := range addrs {
-		if ipnet, ok := address.(*net.IPNet); ok {
-			if ipnet.IP.To4() != nil {
-				return ipnet.IP, nil
-			}
+		ipnet, ok := address.(*net.IPNet)
+		if !ok {
+			continue
+		}
+
+		if !ipv6 && ipnet.IP.To4() != nil {
+			return ipnet.IP, nil
+		}
+
+		// link-local addresses are skipped as they cannot be used without a zone
+		if ipv6 && ipnet.IP.To4() == nil && ipnet.IP.IsGlobalUnicast() {
+			return ipnet.IP, nil
 		}
 	}
 
@@ -123,14 +255,18 @@ func ParseAppFilter(filter string) (string, uint8, uint32, error) {
 		return "", ie.GateStatusOpen, 100, nil
 	}
 
+	// IPv6 networks contain ':' too: the protocol is the first field and
+	// everything between it and the last three fields is the IP network.
 	result := strings.Split(filter, ":")
-	if len(result) != 5 {
+	if len(result) < 5 {
 		return "", 0, 0, pfcpsim.NewInvalidFormatError(
 			"Parser was not able to generate the correct number of arguments." +
 				" Please make sure to use the right format")
 	}
 
-	proto, ipNetAddr, portRange, action, precedence := result[0], result[1], result[2], result[3], result[4]
+	n := len(result)
+	proto, ipNetAddr := result[0], strings.Join(result[1:n-3], ":")
+	portRange, action, precedence := result[n-3], result[n-2], result[n-1]
 
 	var gateStatus uint8
 
@@ -189,3 +325,233 @@ func ParseAppFilter(filter string) (string, uint8, uint32, error) {
 		return fmt.Sprintf(sdfFilterFormatWOPort, proto, ipNetAddr), gateStatus, precedenceUint, nil
 	}
 }
+
+// toPBUsageReports converts the usage reports stored by PFCPClient to their gRPC representation.
+func toPBUsageReports(reports []pfcpsim.UsageReport) []*pb.UsageReport {
+	result := make([]*pb.UsageReport, 0, len(reports))
+
+	for _, report := range reports {
+		ur := &pb.UsageReport{
+			UrrID:      report.URRID,
+			UrSeqn:     report.URSEQN,
+			Triggers:   report.Triggers,
+			Duration:   durationpb.New(report.Duration),
+			ReceivedAt: timestamppb.New(report.ReceivedAt),
+		}
+
+		if report.Volume != nil {
+			ur.Volume = &pb.VolumeMeasurement{
+				TotalVolume:     report.Volume.TotalVolume,
+				UplinkVolume:    report.Volume.UplinkVolume,
+				DownlinkVolume:  report.Volume.DownlinkVolume,
+				TotalPackets:    report.Volume.TotalNumberOfPackets,
+				UplinkPackets:   report.Volume.UplinkNumberOfPackets,
+				DownlinkPackets: report.Volume.DownlinkNumberOfPackets,
+			}
+		}
+
+		if !report.StartTime.IsZero() {
+			ur.StartTime = timestamppb.New(report.StartTime)
+		}
+
+		if !report.EndTime.IsZero() {
+			ur.EndTime = timestamppb.New(report.EndTime)
+		}
+
+		result = append(result, ur)
+	}
+
+	return result
+}
+
+// toPBAssociationInfo returns the gRPC representation of the association state.
+func toPBAssociationInfo(state pfcpsim.AssociationState) *pb.AssociationInfo {
+	info := &pb.AssociationInfo{
+		PeerNodeID:            state.PeerNodeID,
+		UpFunctionFeatures:    state.UPFunctionFeatures,
+		CpFunctionFeatures:    state.CPFunctionFeatures,
+		ReleaseRequested:      state.ReleaseRequested,
+		GracefulReleasePeriod: durationpb.New(state.GracefulReleasePeriod),
+	}
+
+	if !state.UpdatedAt.IsZero() {
+		info.UpdatedAt = timestamppb.New(state.UpdatedAt)
+	}
+
+	info.UpFeatures = state.UPFunctionFeatures.Names()
+	info.AlternativeSMFIPs = state.AlternativeSMFIPs
+
+	for _, resource := range state.UPIPResources {
+		info.UpIPResources = append(info.UpIPResources, &pb.UPIPResource{
+			Ipv4Address:         resource.IPv4Address,
+			Ipv6Address:         resource.IPv6Address,
+			TeidRangeIndication: uint32(resource.TEIDRangeIndication),
+			TeidRange:           uint32(resource.TEIDRange),
+			NetworkInstance:     resource.NetworkInstance,
+			SourceInterface:     uint32(resource.SourceInterface),
+		})
+	}
+
+	return info
+}
+
+// toPBSessionInfo returns the gRPC representation of the state of sess, created with index id.
+func toPBSessionInfo(id int, sess *pfcpsim.PFCPSession) *pb.SessionInfo {
+	pdrIDs := make([]uint32, 0)
+	for _, pdrID := range sess.PDRIDs() {
+		pdrIDs = append(pdrIDs, uint32(pdrID))
+	}
+
+	pagings := make([]*pb.Paging, 0)
+	for _, paging := range sess.Pagings() {
+		pagings = append(pagings, &pb.Paging{
+			ReportedAt:  timestamppb.New(paging.ReportedAt),
+			CompletedAt: timestamppb.New(paging.CompletedAt),
+			Latency:     durationpb.New(paging.Latency),
+		})
+	}
+
+	nodeBAddress, nodeBTEID := sess.GNBTunnel()
+
+	return &pb.SessionInfo{
+		Id:           int32(id),
+		LocalSEID:    sess.LocalSEID(),
+		PeerSEID:     sess.PeerSEID(),
+		UeAddresses:  sess.UEAddresses(),
+		UplinkTEID:   sess.UplinkTEID(),
+		NodeBAddress: nodeBAddress,
+		PdrIDs:       pdrIDs,
+		FarIDs:       sess.FARIDs(),
+		QerIDs:       sess.QERIDs(),
+		UrrIDs:       sess.URRIDs(),
+		CreatedAt:    timestamppb.New(sess.CreatedAt()),
+		ModifiedAt:   timestamppb.New(sess.ModifiedAt()),
+		NodeBTEID:    nodeBTEID,
+		Pagings:      pagings,
+
+		Released:             sess.IsReleased(),
+		UserPlanePathFailure: sess.HasUserPlanePathFailure(),
+	}
+}
+
+// toPBSessionResult returns the result of a procedure run on the session with index id, failed if err is not nil.
+// sess is nil if the session could not be established or found: ueAddresses are reported instead.
+func toPBSessionResult(id int, sess *pfcpsim.PFCPSession, ueAddresses []string, err error) *pb.SessionResult {
+	result := &pb.SessionResult{
+		Id:          int32(id),
+		Outcome:     pb.SessionOutcome_SESSION_OUTCOME_SUCCESS,
+		Cause:       uint32(ie.CauseRequestAccepted),
+		UeAddresses: ueAddresses,
+	}
+
+	if err != nil {
+		result.Message = err.Error()
+		result.Cause = 0
+
+		cause, rejected := pfcpsim.Cause(err)
+
+		switch {
+		case rejected:
+			result.Outcome = pb.SessionOutcome_SESSION_OUTCOME_REJECTED
+			result.Cause = uint32(cause)
+		case pfcpsim.IsTimeout(err):
+			result.Outcome = pb.SessionOutcome_SESSION_OUTCOME_TIMEOUT
+		default:
+			result.Outcome = pb.SessionOutcome_SESSION_OUTCOME_FAILED
+		}
+	}
+
+	if sess == nil {
+		return result
+	}
+
+	result.LocalFSEID = &pb.FSEID{Seid: sess.LocalSEID(), Address: sess.LocalAddress()}
+	result.UpfFSEID = &pb.FSEID{Seid: sess.PeerSEID(), Address: sess.PeerAddress()}
+	result.UeAddresses = sess.UEAddresses()
+
+	for _, created := range sess.CreatedPDRs() {
+		pdr := &pb.CreatedPDR{
+			PdrID:        uint32(created.PDRID),
+			Teid:         created.TEID,
+			UeIPv6Prefix: created.UEIPv6Prefix,
+		}
+
+		if created.IPv4Address != nil {
+			pdr.Ipv4Address = created.IPv4Address.String()
+		}
+
+		if created.IPv6Address != nil {
+			pdr.Ipv6Address = created.IPv6Address.String()
+		}
+
+		if created.UEAddress != nil {
+			pdr.UeAddress = created.UEAddress.String()
+		}
+
+		result.CreatedPDRs = append(result.CreatedPDRs, pdr)
+	}
+
+	return result
+}
+
+// newSkippedSessionResult returns the result of a session not attempted because of a previous failure.
+func newSkippedSessionResult(id int) *pb.SessionResult {
+	return &pb.SessionResult{
+		Id:      int32(id),
+		Outcome: pb.SessionOutcome_SESSION_OUTCOME_SKIPPED,
+		Message: "skipped because of a previous failure",
+	}
+}
+
+// newSessionsResponse returns the response of a session procedure given the results of every session.
+// If any session did not succeed, the returned error has code Internal, whatever the procedure, and carries
+// the response in its details.
+func newSessionsResponse(results []*pb.SessionResult, infoMsg string) (*pb.SessionsResponse, error) {
+	var (
+		failed, skipped, rolledBack int
+		firstErr                    string
+	)
+
+	for _, result := range results {
+		switch result.Outcome {
+		case pb.SessionOutcome_SESSION_OUTCOME_SUCCESS:
+		case pb.SessionOutcome_SESSION_OUTCOME_SKIPPED:
+			skipped++
+		case pb.SessionOutcome_SESSION_OUTCOME_ROLLED_BACK:
+			rolledBack++
+		default:
+			if failed == 0 {
+				firstErr = result.Message
+			}
+
+			failed++
+		}
+	}
+
+	if failed == 0 && skipped == 0 && rolledBack == 0 {
+		logger.PfcpsimLog.Infoln(infoMsg)
+
+		return &pb.SessionsResponse{
+			StatusCode: int32(codes.OK),
+			Message:    infoMsg,
+			Results:    results,
+		}, nil
+	}
+
+	errMsg := fmt.Sprintf("%v of %v sessions failed, %v skipped, %v rolled back. First error: %v",
+		failed, len(results), skipped, rolledBack, firstErr)
+	logger.PfcpsimLog.Errorln(errMsg)
+
+	res := &pb.SessionsResponse{
+		StatusCode: int32(codes.Internal),
+		Message:    errMsg,
+		Results:    results,
+	}
+
+	st, err := status.New(codes.Internal, errMsg).WithDetails(res)
+	if err != nil {
+		return res, status.Error(codes.Internal, errMsg)
+	}
+
+	return res, st.Err()
+}
@@ -6,6 +6,7 @@ package pfcpsim
 import (
 	"testing"
 
+	pb "github.com/omec-project/pfcpsim/api"
 	"github.com/wmnsk/go-pfcp/ie"
 )
 
@@ -92,6 +93,36 @@ func Test_ParseAppFilter(t *testing.T) {
 				precedence: 103,
 			},
 		},
+		{
+			name: "Correct IPv6 app filter",
+			args: &args{
+				filterString: "tcp:2001:db8::/32:443-443:allow:104",
+			},
+			want: &want{
+				SDFFilter:  "permit out tcp from 2001:db8::/32 to assigned 443-443",
+				gateStatus: ie.GateStatusOpen,
+				precedence: 104,
+			},
+		},
+		{
+			name: "Correct IPv6 app filter with deny-all policy",
+			args: &args{
+				filterString: "ip:::/0:any:deny:105",
+			},
+			want: &want{
+				SDFFilter:  "permit out ip from ::/0 to assigned",
+				gateStatus: ie.GateStatusClosed,
+				precedence: 105,
+			},
+		},
+		{
+			name: "incorrect IPv6 app filter bad IP format",
+			args: &args{
+				filterString: "ip:2001:db8::zz/32:any:allow:100",
+			},
+			want:    &want{},
+			wantErr: true,
+		},
 		{
 			name: "incorrect app filter bad protocol",
 			args: &args{
@@ -156,3 +187,107 @@ func Test_ParseAppFilter(t *testing.T) {
 		)
 	}
 }
+
+func Test_isIPv6Peer(t *testing.T) {
+	for _, scenario := range []struct {
+		addr     string
+		expected bool
+	}{
+		{addr: "10.0.0.1", expected: false},
+		{addr: "10.0.0.1:8805", expected: false},
+		{addr: "2001:db8::1", expected: true},
+		{addr: "[2001:db8::1]:8805", expected: true},
+	} {
+		t.Run(scenario.addr, func(t *testing.T) {
+			if got := isIPv6Peer(scenario.addr); got != scenario.expected {
+				t.Errorf("isIPv6Peer mismatch. got = %v, want = %v", got, scenario.expected)
+			}
+		})
+	}
+}
+
+func Test_checkUEAddressPools(t *testing.T) {
+	tests := []struct {
+		name    string
+		pools   []*pb.UEPool
+		request *pb.CreateSessionRequest
+		wantErr bool
+	}{
+		{
+			name:    "IPv4 network",
+			request: &pb.CreateSessionRequest{UeAddressPool: "10.0.0.0/24"},
+		},
+		{
+			name: "IPv6 network",
+			request: &pb.CreateSessionRequest{
+				UeAddressPoolV6: "2001:db8::/48",
+				PdnType:         pb.PdnType_PDN_TYPE_IPV6,
+			},
+		},
+		{
+			name: "Dual-stack named pools",
+			pools: []*pb.UEPool{
+				{Name: "v4", Network: "10.0.0.0/24"},
+				{Name: "v6", Network: "2001:db8::/48"},
+			},
+			request: &pb.CreateSessionRequest{
+				UeAddressPool:   "v4",
+				UeAddressPoolV6: "v6",
+				PdnType:         pb.PdnType_PDN_TYPE_IPV4V6,
+			},
+		},
+		{
+			name: "Dual-stack missing IPv6 pool",
+			request: &pb.CreateSessionRequest{
+				UeAddressPool: "10.0.0.0/24",
+				PdnType:       pb.PdnType_PDN_TYPE_IPV4V6,
+			},
+			wantErr: true,
+		},
+		{
+			name:    "Unknown pool",
+			request: &pb.CreateSessionRequest{UeAddressPool: "unknown"},
+			wantErr: true,
+		},
+		{
+			name:    "IPv6 network used as IPv4 pool",
+			request: &pb.CreateSessionRequest{UeAddressPool: "2001:db8::/48"},
+			wantErr: true,
+		},
+		{
+			name:    "IPv6 named pool used as IPv4 pool",
+			pools:   []*pb.UEPool{{Name: "v6", Network: "2001:db8::/48"}},
+			request: &pb.CreateSessionRequest{UeAddressPool: "v6"},
+			wantErr: true,
+		},
+		{
+			name: "IPv6 pool smaller than /64",
+			request: &pb.CreateSessionRequest{
+				UeAddressPoolV6: "2001:db8::/96",
+				PdnType:         pb.PdnType_PDN_TYPE_IPV6,
+			},
+			wantErr: true,
+		},
+		{
+			name:    "Network overlapping a named pool",
+			pools:   []*pb.UEPool{{Name: "v4", Network: "10.0.0.0/16"}},
+			request: &pb.CreateSessionRequest{UeAddressPool: "10.0.1.0/24"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uePools = newUEPoolManager()
+
+			for _, pool := range tt.pools {
+				if err := uePools.add(pool.Name, pool.Network, pool.Exclusions); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := checkUEAddressPools(tt.request); (err != nil) != tt.wantErr {
+				t.Errorf("checkUEAddressPools() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
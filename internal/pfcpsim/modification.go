@@ -0,0 +1,195 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	pb "github.com/omec-project/pfcpsim/api"
+	"github.com/omec-project/pfcpsim/pkg/pfcpsim"
+	"github.com/omec-project/pfcpsim/pkg/pfcpsim/session"
+	ieLib "github.com/wmnsk/go-pfcp/ie"
+)
+
+// gateStatuses maps the gate statuses accepted in a QER update to their value in the Gate Status IE.
+var gateStatuses = map[string]uint8{
+	"":       ieLib.GateStatusOpen,
+	"open":   ieLib.GateStatusOpen,
+	"closed": ieLib.GateStatusClosed,
+}
+
+// appFilter is a parsed application filter.
+type appFilter struct {
+	sdfFilter  string
+	gateStatus uint8
+	precedence uint32
+}
+
+// parseAppFilters parses filters with ParseAppFilter.
+func parseAppFilters(filters []string) ([]appFilter, error) {
+	parsed := make([]appFilter, 0, len(filters))
+
+	for _, filter := range filters {
+		sdfFilter, gateStatus, precedence, err := ParseAppFilter(filter)
+		if err != nil {
+			return nil, err
+		}
+
+		parsed = append(parsed, appFilter{sdfFilter: sdfFilter, gateStatus: gateStatus, precedence: precedence})
+	}
+
+	return parsed, nil
+}
+
+// qerUpdateIEs returns the Update QER IEs applying update to the QERs of sess.
+func qerUpdateIEs(sess *pfcpsim.PFCPSession, update *pb.QERUpdate) []*ieLib.IE {
+	ids := update.Ids
+	if len(ids) == 0 {
+		ids = sess.QERIDs()
+	}
+
+	qers := make([]*ieLib.IE, 0, len(ids))
+
+	for _, id := range ids {
+		qer := session.NewQERBuilder().
+			WithID(id).
+			WithMethod(session.Update).
+			WithChangesOnly()
+
+		if update.UplinkMBR != 0 || update.DownlinkMBR != 0 {
+			qer.WithUplinkMBR(update.UplinkMBR).WithDownlinkMBR(update.DownlinkMBR)
+		}
+
+		if update.UplinkGBR != 0 || update.DownlinkGBR != 0 {
+			qer.WithUplinkGBR(update.UplinkGBR).WithDownlinkGBR(update.DownlinkGBR)
+		}
+
+		if update.GateStatus != "" {
+			qer.WithGateStatus(gateStatuses[update.GateStatus])
+		}
+
+		qers = append(qers, qer.Build())
+	}
+
+	return qers
+}
+
+// appFilterModificationIEs returns the IEs removing the PDRs matching the filters remove, with the rules
+// no other PDR uses, and the IEs creating an uplink and a downlink PDR for each filter of add.
+func appFilterModificationIEs(sess *pfcpsim.PFCPSession, add, remove []appFilter, qfi uint8) ([]*ieLib.IE, error) {
+	var pdrIDs []uint16
+
+	for _, filter := range remove {
+		ids := sess.AppFilterPDRIDs(filter.sdfFilter)
+		if len(ids) == 0 {
+			return nil, fmt.Errorf("no PDR matches the application filter %q", filter.sdfFilter)
+		}
+
+		pdrIDs = append(pdrIDs, ids...)
+	}
+
+	var ies []*ieLib.IE
+
+	if len(pdrIDs) != 0 {
+		ies = sess.RemoveIEs(pdrIDs...)
+	}
+
+	if len(add) == 0 {
+		return ies, nil
+	}
+
+	created, err := appFilterIEs(sess, add, qfi)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(ies, created...), nil
+}
+
+// appFilterIEs returns the IEs creating an uplink and a downlink PDR for each filter, each PDR with its own
+// application QER. PDRs match on the uplink F-TEID and on the UE addresses of sess, and use the FARs and
+// the QERs shared by all the PDRs of sess.
+func appFilterIEs(sess *pfcpsim.PFCPSession, filters []appFilter, qfi uint8) ([]*ieLib.IE, error) {
+	teid, n3Address := sess.UplinkFTEID()
+	if teid == 0 || n3Address == nil {
+		return nil, fmt.Errorf("session has no uplink F-TEID")
+	}
+
+	uplinkFARIDs := sess.UplinkFARIDs()
+	downlinkFARIDs, _ := sess.DownlinkRules()
+
+	if len(uplinkFARIDs) == 0 || len(downlinkFARIDs) == 0 {
+		return nil, fmt.Errorf("session has no uplink or no downlink FAR")
+	}
+
+	var ueAddress, ueIPv6Prefix string
+
+	for _, address := range sess.UEAddresses() {
+		if strings.Contains(address, "/") {
+			ueIPv6Prefix = address
+		} else if net.ParseIP(address) != nil {
+			ueAddress = address
+		}
+	}
+
+	if ueAddress == "" && ueIPv6Prefix == "" {
+		return nil, fmt.Errorf("session has no UE address")
+	}
+
+	sharedQERIDs := sess.SharedQERIDs()
+	ids := sess.IDAllocator()
+
+	var ies []*ieLib.IE
+
+	for _, filter := range filters {
+		uplinkPDRID := uint16(ids.New(session.RulePDR))
+		downlinkPDRID := uint16(ids.New(session.RulePDR))
+		uplinkQERID := ids.New(session.RuleQER)
+		downlinkQERID := ids.New(session.RuleQER)
+
+		uplinkPDR := session.NewPDRBuilder().
+			WithID(uplinkPDRID).
+			WithMethod(session.Create).
+			WithTEID(teid).
+			WithN3Address(n3Address.String()).
+			WithSDFFilter(filter.sdfFilter).
+			WithPrecedence(filter.precedence).
+			WithFARID(uplinkFARIDs[0]).
+			MarkAsUplink()
+
+		downlinkPDR := session.NewPDRBuilder().
+			WithID(downlinkPDRID).
+			WithMethod(session.Create).
+			WithUEAddress(ueAddress).
+			WithUEIPv6Prefix(ueIPv6Prefix).
+			WithSDFFilter(filter.sdfFilter).
+			WithPrecedence(filter.precedence).
+			WithFARID(downlinkFARIDs[0]).
+			MarkAsDownlink()
+
+		for _, id := range sharedQERIDs {
+			uplinkPDR.AddQERID(id)
+			downlinkPDR.AddQERID(id)
+		}
+
+		uplinkPDR.AddQERID(uplinkQERID)
+		downlinkPDR.AddQERID(downlinkQERID)
+
+		ies = append(ies, uplinkPDR.BuildPDR(), downlinkPDR.BuildPDR())
+
+		for _, qerID := range []uint32{uplinkQERID, downlinkQERID} {
+			ies = append(ies, session.NewQERBuilder().
+				WithID(qerID).
+				WithMethod(session.Create).
+				WithQFI(qfi).
+				WithGateStatus(filter.gateStatus).
+				Build(),
+			)
+		}
+	}
+
+	return ies, nil
+}
@@ -7,6 +7,8 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	pb "github.com/omec-project/pfcpsim/api"
@@ -18,18 +20,15 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+// passiveAssociationTimeout is the time Associate waits for the UPF to set up the association in passive mode.
+const passiveAssociationTimeout = 60 * time.Second
+
 // pfcpSimService implements the Protobuf interface and keeps a connection to a remote PFCP Agent peer.
 // Its state is handled in internal/pfcpsim/state.go
 type pfcpSimService struct {
 	pb.UnimplementedPFCPSimServer // Embed the unimplemented server to satisfy the interface
 }
 
-// SessionStep identifies the step in loops, used while creating/modifying/deleting sessions and rules IDs.
-// It should be high enough to avoid IDs overlap when creating sessions. 5 Applications should be enough.
-// In theory with ROC limitations, we should expect max 8 applications (5 explicit applications + 3 filters
-// to deny traffic to the RFC1918 IPs, in case we have a ALLOW-PUBLIC)
-const SessionStep = 10
-
 func NewPFCPSimService(iface string) *pfcpSimService {
 	interfaceName = iface
 	return &pfcpSimService{}
@@ -55,6 +54,81 @@ func SetUpfN3(addr string) {
 	upfN3Address = addr
 }
 
+// SetMaxInFlight sets the number of session requests that can wait for a response at the same time.
+func SetMaxInFlight(value int) {
+	maxInFlight = value
+
+	if sim != nil {
+		applyClientSettings()
+	}
+}
+
+// SetAutoRecovery enables or disables the re-establishment of association and sessions on peer restart.
+func SetAutoRecovery(enable bool) {
+	autoRecovery = enable
+
+	if sim != nil {
+		applyClientSettings()
+	}
+}
+
+// SetPagingEmulation enables or disables the emulation of idle UEs: the downlink forwarding of a session
+// is restored delay after a Downlink Data Report.
+func SetPagingEmulation(enable bool, delay time.Duration) {
+	pagingEmulation = enable
+	pagingDelay = delay
+
+	if sim != nil {
+		applyClientSettings()
+	}
+}
+
+// SetPassiveAssociation enables or disables the passive association mode, where the UPF with Node ID nodeID
+// (any if empty) initiates the Association Setup.
+func SetPassiveAssociation(enable bool, nodeID string) {
+	passiveAssociation = enable
+	upfNodeID = nodeID
+
+	if sim != nil {
+		applyClientSettings()
+	}
+}
+
+// SetCPFunctionFeatures sets the octets of the CP Function Features IE advertised to the UPF in the
+// Association Setup, none if empty.
+func SetCPFunctionFeatures(features []uint8) {
+	cpFunctionFeatures = features
+
+	if sim != nil {
+		applyClientSettings()
+	}
+}
+
+// SetRetransmission sets the T1 timer and the N1 counter used to retransmit requests.
+func SetRetransmission(t1 time.Duration, n1 int) {
+	responseTimeout = t1
+	maxRetransmissions = n1
+
+	if sim != nil {
+		applyClientSettings()
+	}
+}
+
+// LoadProfiles loads the session profiles found in dir. A profile named "default" replaces the built-in one.
+func LoadProfiles(dir string) error {
+	loaded, err := session.LoadProfiles(dir)
+	if err != nil {
+		return err
+	}
+
+	for name, profile := range loaded {
+		logger.PfcpsimLog.Infof("loaded session profile %v", name)
+		profiles[name] = profile
+	}
+
+	return nil
+}
+
 func (P pfcpSimService) Configure(ctx context.Context, request *pb.ConfigureRequest) (*pb.Response, error) {
 	if net.ParseIP(request.UpfN3Address) == nil {
 		errMsg := fmt.Sprintf("error while parsing UPF N3 address: %v", request.UpfN3Address)
@@ -62,14 +136,86 @@ func (P pfcpSimService) Configure(ctx context.Context, request *pb.ConfigureRequ
 
 		return &pb.Response{}, status.Error(codes.Aborted, errMsg)
 	}
+	if request.MaxInFlight < 0 {
+		errMsg := fmt.Sprintf("max in-flight requests cannot be negative: %v", request.MaxInFlight)
+		logger.PfcpsimLog.Errorln(errMsg)
+
+		return &pb.Response{}, status.Error(codes.Aborted, errMsg)
+	}
+
+	if request.T1 < 0 || request.GetN1() < 0 {
+		errMsg := fmt.Sprintf("T1 and N1 cannot be negative: T1 %v, N1 %v", request.T1, request.GetN1())
+		logger.PfcpsimLog.Errorln(errMsg)
+
+		return &pb.Response{}, status.Error(codes.Aborted, errMsg)
+	}
+
+	if request.PagingDelay != nil && (!request.PagingDelay.IsValid() || request.PagingDelay.AsDuration() < 0) {
+		errMsg := fmt.Sprintf("invalid paging delay: %v", request.PagingDelay)
+		logger.PfcpsimLog.Errorln(errMsg)
+
+		return &pb.Response{}, status.Error(codes.Aborted, errMsg)
+	}
+
+	var cpFeatures []uint8
+
+	if len(request.CpFunctionFeatures) != 0 {
+		features, err := pfcpsim.ParseCPFunctionFeatures(request.CpFunctionFeatures)
+		if err != nil {
+			logger.PfcpsimLog.Errorln(err)
+			return &pb.Response{}, status.Error(codes.Aborted, err.Error())
+		}
+
+		cpFeatures = features
+	}
+
+	if len(request.TeidRanges) != 0 {
+		if err := teids.setRanges(request.TeidRanges); err != nil {
+			logger.PfcpsimLog.Errorln(err)
+			return &pb.Response{}, status.Error(codes.Aborted, err.Error())
+		}
+	}
+
 	// remotePeerAddress is validated in pfcpsim
 	SetRemotePeer(request.RemotePeerAddress)
 	SetUpfN3(request.UpfN3Address)
 
+	if request.MaxInFlight != 0 {
+		SetMaxInFlight(int(request.MaxInFlight))
+	}
+
+	t1, n1 := responseTimeout, maxRetransmissions
+
+	if request.T1 != 0 {
+		t1 = time.Duration(request.T1) * time.Millisecond
+	}
+
+	if request.N1 != nil {
+		n1 = int(request.GetN1())
+	}
+
+	SetRetransmission(t1, n1)
+	SetAutoRecovery(request.AutoRecovery)
+	SetPagingEmulation(request.PagingEmulation, request.PagingDelay.AsDuration())
+	SetPassiveAssociation(request.PassiveAssociation, request.UpfNodeID)
+	SetCPFunctionFeatures(cpFeatures)
+
 	configurationMsg := fmt.Sprintf(
-		"Server is configured. Remote peer address: %v, N3 interface address: %v ",
+		"Server is configured. Remote peer address: %v, N3 interface address: %v, max in-flight requests: %v, "+
+			"T1: %v, N1: %v, auto recovery: %v, TEID ranges: %v, paging emulation: %v, paging delay: %v, "+
+			"passive association: %v, UPF Node ID: %q, CP function features: %v ",
 		remotePeerAddress,
 		upfN3Address,
+		maxInFlight,
+		responseTimeout,
+		maxRetransmissions,
+		autoRecovery,
+		teids.rangesString(),
+		pagingEmulation,
+		pagingDelay,
+		passiveAssociation,
+		upfNodeID,
+		request.CpFunctionFeatures,
 	)
 
 	return &pb.Response{
@@ -93,6 +239,10 @@ func (P pfcpSimService) Associate(ctx context.Context, empty *pb.EmptyRequest) (
 		}
 	}
 
+	if passiveAssociation {
+		return acceptAssociation(ctx)
+	}
+
 	if err := sim.SetupAssociation(); err != nil {
 		logger.PfcpsimLog.Errorln(err)
 		return &pb.Response{}, status.Error(codes.Aborted, err.Error())
@@ -107,6 +257,27 @@ func (P pfcpSimService) Associate(ctx context.Context, empty *pb.EmptyRequest) (
 	}, nil
 }
 
+// acceptAssociation waits up to passiveAssociationTimeout for the UPF to set up the association.
+func acceptAssociation(ctx context.Context) (*pb.Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, passiveAssociationTimeout)
+	defer cancel()
+
+	if err := sim.AcceptAssociation(ctx); err != nil {
+		errMsg := fmt.Sprintf("no association set up by the UPF: %v", err)
+		logger.PfcpsimLog.Errorln(errMsg)
+
+		return &pb.Response{}, status.Error(codes.DeadlineExceeded, errMsg)
+	}
+
+	infoMsg := fmt.Sprintf("Association established by the UPF with Node ID %v", sim.AssociationState().PeerNodeID)
+	logger.PfcpsimLog.Infoln(infoMsg)
+
+	return &pb.Response{
+		StatusCode: int32(codes.OK),
+		Message:    infoMsg,
+	}, nil
+}
+
 func (P pfcpSimService) Disassociate(ctx context.Context, empty *pb.EmptyRequest) (*pb.Response, error) {
 	if err := checkServerStatus(); err != nil {
 		return &pb.Response{}, err
@@ -130,20 +301,96 @@ func (P pfcpSimService) Disassociate(ctx context.Context, empty *pb.EmptyRequest
 	}, nil
 }
 
-func (P pfcpSimService) CreateSession(ctx context.Context, request *pb.CreateSessionRequest) (*pb.Response, error) {
+func (P pfcpSimService) UpdateAssociation(ctx context.Context, request *pb.UpdateAssociationRequest) (*pb.UpdateAssociationResponse, error) {
 	if err := checkServerStatus(); err != nil {
-		return &pb.Response{}, err
+		return &pb.UpdateAssociationResponse{}, err
+	}
+
+	var (
+		ies        []*ieLib.IE
+		cpFeatures []uint8
+	)
+
+	if len(request.CpFunctionFeatures) != 0 {
+		features, err := pfcpsim.ParseCPFunctionFeatures(request.CpFunctionFeatures)
+		if err != nil {
+			logger.PfcpsimLog.Errorln(err)
+			return &pb.UpdateAssociationResponse{}, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		cpFeatures = features
+		ies = append(ies, ieLib.NewCPFunctionFeatures(features...))
+	}
+
+	if err := sim.UpdateAssociation(ies...); err != nil {
+		logger.PfcpsimLog.Errorln(err)
+		return &pb.UpdateAssociationResponse{}, status.Error(codes.Aborted, err.Error())
+	}
+
+	// the client advertises the updated features from now on, keep the setting in line
+	if cpFeatures != nil {
+		cpFunctionFeatures = cpFeatures
+	}
+
+	infoMsg := "Association updated"
+	logger.PfcpsimLog.Infoln(infoMsg)
+
+	return &pb.UpdateAssociationResponse{
+		StatusCode:  int32(codes.OK),
+		Message:     infoMsg,
+		Association: toPBAssociationInfo(sim.AssociationState()),
+	}, nil
+}
+
+func (P pfcpSimService) GetAssociation(ctx context.Context, empty *pb.EmptyRequest) (*pb.GetAssociationResponse, error) {
+	if err := checkServerStatus(); err != nil {
+		return &pb.GetAssociationResponse{}, err
+	}
+
+	if !sim.IsAssociationAlive() {
+		return &pb.GetAssociationResponse{}, status.Error(codes.FailedPrecondition, "no association established")
+	}
+
+	return &pb.GetAssociationResponse{
+		StatusCode:  int32(codes.OK),
+		Message:     "Association found",
+		Association: toPBAssociationInfo(sim.AssociationState()),
+	}, nil
+}
+
+func (P pfcpSimService) CreateSession(ctx context.Context, request *pb.CreateSessionRequest) (*pb.SessionsResponse, error) {
+	if err := checkServerStatus(); err != nil {
+		return &pb.SessionsResponse{}, err
 	}
 
 	baseID := int(request.BaseID)
 	count := int(request.Count)
 
-	lastUEAddr, _, err := net.ParseCIDR(request.UeAddressPool)
-	if err != nil {
-		errMsg := fmt.Sprintf("Could not parse Address Pool: %v", err)
+	if count < 0 {
+		return &pb.SessionsResponse{}, status.Error(codes.Aborted, "count cannot be negative")
+	}
+
+	if err := checkUEAddressPools(request); err != nil {
+		logger.PfcpsimLog.Errorln(err)
+		return &pb.SessionsResponse{}, status.Error(codes.Aborted, err.Error())
+	}
+
+	upFeatures := sim.AssociationState().UPFunctionFeatures
+
+	if request.UpfAllocatedUEAddress && !upFeatures.Supports("UEIP") {
+		errMsg := "the UPF does not support the allocation of UE addresses (UEIP feature)"
 		logger.PfcpsimLog.Errorln(errMsg)
 
-		return &pb.Response{}, status.Error(codes.Aborted, errMsg)
+		return &pb.SessionsResponse{}, status.Error(codes.FailedPrecondition, errMsg)
+	}
+
+	// a UPF without FTUP cannot allocate the F-TEIDs, they are allocated by pfcpsim instead
+	chooseTEID := request.UpfAllocatedTEID
+	if chooseTEID && !upFeatures.Supports("FTUP") {
+		logger.PfcpsimLog.Warnln("the UPF does not support the allocation of F-TEIDs (FTUP feature), " +
+			"allocating the TEIDs locally")
+
+		chooseTEID = false
 	}
 
 	var qfi uint8 = 0
@@ -152,182 +399,246 @@ func (P pfcpSimService) CreateSession(ctx context.Context, request *pb.CreateSes
 		qfi = uint8(request.Qfi)
 	}
 
-	if err = isNumOfAppFiltersCorrect(request.AppFilters); err != nil {
-		return &pb.Response{}, err
+	profileName := request.Profile
+	if profileName == "" {
+		profileName = session.DefaultProfileName
 	}
 
-	for i := baseID; i < (count*SessionStep + baseID); i = i + SessionStep {
-		// using variables to ease comprehension on how rules are linked together
-		uplinkTEID := uint32(i)
+	profile, ok := profiles[profileName]
+	if !ok {
+		errMsg := fmt.Sprintf("unknown session profile %v", profileName)
+		logger.PfcpsimLog.Errorln(errMsg)
+
+		return &pb.SessionsResponse{}, status.Error(codes.NotFound, errMsg)
+	}
 
-		ueAddress := NextIP(lastUEAddr)
-		lastUEAddr = ueAddress
+	appFilters := make([]session.AppFilter, 0, len(request.AppFilters))
 
-		sessQerID := uint32(0)
+	for _, appFilter := range request.AppFilters {
+		SDFFilter, gateStatus, precedence, err := ParseAppFilter(appFilter)
+		if err != nil {
+			return &pb.SessionsResponse{}, status.Error(codes.Aborted, err.Error())
+		}
 
-		var pdrs, fars, urrs []*ieLib.IE
+		logger.PfcpsimLog.Infof("successfully parsed application filter. SDF Filter: %v", SDFFilter)
 
-		qers := []*ieLib.IE{
-			// session QER
-			session.NewQERBuilder().
-				WithID(sessQerID).
-				WithMethod(session.Create).
-				WithUplinkMBR(60000).
-				WithDownlinkMBR(60000).
-				Build(),
+		gate := "open"
+		if gateStatus == ieLib.GateStatusClosed {
+			gate = "closed"
 		}
 
-		// create as many PDRs, FARs and App QERs as the number of app filters provided through pfcpctl
-		ID := uint16(i)
+		appFilters = append(appFilters, session.AppFilter{
+			SDFFilter:  SDFFilter,
+			GateStatus: gate,
+			Precedence: precedence,
+		})
+	}
 
-		for _, appFilter := range request.AppFilters {
-			SDFFilter, gateStatus, precedence, err := ParseAppFilter(appFilter)
-			if err != nil {
-				return &pb.Response{}, status.Error(codes.Aborted, err.Error())
+	// sessions are established concurrently, at most maxInFlight at a time. This also bounds
+	// the establishments started after a failure with the stop and rollback policies.
+	var (
+		wg     sync.WaitGroup
+		failed atomic.Bool
+	)
+
+	inFlight := make(chan struct{}, maxInFlight)
+	results := make([]*pb.SessionResult, count)
+	stopOnFailure := request.FailurePolicy != pb.FailurePolicy_FAILURE_POLICY_CONTINUE
+
+	for j := range count {
+		i := baseID + j
+
+		inFlight <- struct{}{}
+
+		if stopOnFailure && failed.Load() {
+			<-inFlight
+			results[j] = newSkippedSessionResult(i)
+
+			continue
+		}
+
+		// the resources of an active session are only released when it is deleted
+		if _, ok := pfcpsim.GetSession(i); ok {
+			err := fmt.Errorf("session with index %v is already active", i)
+			logger.PfcpsimLog.Errorln(err)
+			<-inFlight
+			failed.Store(true)
+			results[j] = toPBSessionResult(i, nil, nil, err)
+
+			continue
+		}
+
+		ueAddress, ueIPv6Prefix, err := allocateUEAddresses(request)
+		if err != nil {
+			logger.PfcpsimLog.Errorf("could not allocate the UE addresses of session %v: %v", i, err)
+			<-inFlight
+			failed.Store(true)
+			results[j] = toPBSessionResult(i, nil, nil, err)
+
+			continue
+		}
+
+		var ueAddresses []string
+
+		for _, address := range []string{ueAddress, ueIPv6Prefix} {
+			if address != "" {
+				ueAddresses = append(ueAddresses, address)
 			}
+		}
+
+		uePools.assign(i, ueAddresses)
+
+		// the TEID is left to 0 when the UPF allocates the F-TEID
+		var teid uint32
 
-			logger.PfcpsimLog.Infof("successfully parsed application filter. SDF Filter: %v", SDFFilter)
-
-			uplinkPdrID := ID
-			downlinkPdrID := ID + 1
-
-			uplinkFarID := uint32(ID)
-			downlinkFarID := uint32(ID + 1)
-
-			uplinkAppQerID := uint32(ID)
-			downlinkAppQerID := uint32(ID + 1)
-
-			urrId := uint32(ID)
-			urr := session.NewURRBuilder().
-				WithID(urrId).
-				WithMethod(session.Create).
-				WithMeasurementMethod(0, 1, 0).
-				WithMeasurementPeriod(1 * time.Second).
-				WithReportingTrigger(session.ReportingTrigger{
-					Flags: session.RPT_TRIG_PERIO,
-				}).
-				Build()
-
-			urrs = append(urrs, urr)
-
-			urr = session.NewURRBuilder().
-				WithID(urrId+1).
-				WithMethod(session.Create).
-				WithMeasurementMethod(0, 1, 0).
-				WithMeasurementPeriod(1*time.Second).
-				WithReportingTrigger(session.ReportingTrigger{
-					Flags: session.RPT_TRIG_VOLTH | session.RPT_TRIG_VOLQU,
-				}).
-				WithVolumeThreshold(7, 10000, 20000, 30000).
-				WithVolumeQuota(7, 10000, 20000, 30000).
-				Build()
-
-			urrs = append(urrs, urr)
-
-			uplinkPDR := session.NewPDRBuilder().
-				WithID(uplinkPdrID).
-				WithMethod(session.Create).
-				WithTEID(uplinkTEID).
-				WithFARID(uplinkFarID).
-				AddQERID(sessQerID).
-				AddQERID(uplinkAppQerID).
-				WithN3Address(upfN3Address).
-				WithSDFFilter(SDFFilter).
-				WithPrecedence(precedence).
-				MarkAsUplink().
-				BuildPDR()
-
-			downlinkPDR := session.NewPDRBuilder().
-				WithID(downlinkPdrID).
-				WithMethod(session.Create).
-				WithPrecedence(precedence).
-				WithUEAddress(ueAddress.String()).
-				WithSDFFilter(SDFFilter).
-				AddQERID(sessQerID).
-				AddQERID(downlinkAppQerID).
-				WithFARID(downlinkFarID).
-				MarkAsDownlink().
-				BuildPDR()
-
-			pdrs = append(pdrs, uplinkPDR)
-			pdrs = append(pdrs, downlinkPDR)
-
-			uplinkFAR := session.NewFARBuilder().
-				WithID(uplinkFarID).
-				WithAction(session.ActionForward).
-				WithDstInterface(ieLib.DstInterfaceCore).
-				WithMethod(session.Create).
-				BuildFAR()
-
-			downlinkFAR := session.NewFARBuilder().
-				WithID(downlinkFarID).
-				WithAction(session.ActionDrop).
-				WithMethod(session.Create).
-				WithDstInterface(ieLib.DstInterfaceAccess).
-				WithZeroBasedOuterHeaderCreation().
-				BuildFAR()
-
-			fars = append(fars, uplinkFAR)
-			fars = append(fars, downlinkFAR)
-
-			uplinkAppQER := session.NewQERBuilder().
-				WithID(uplinkAppQerID).
-				WithMethod(session.Create).
-				WithQFI(qfi).
-				WithUplinkMBR(50000).
-				WithDownlinkMBR(30000).
-				WithGateStatus(gateStatus).
-				Build()
-
-			downlinkAppQER := session.NewQERBuilder().
-				WithID(downlinkAppQerID).
-				WithMethod(session.Create).
-				WithQFI(qfi).
-				WithUplinkMBR(50000).
-				WithDownlinkMBR(30000).
-				WithGateStatus(gateStatus).
-				Build()
-
-			qers = append(qers, uplinkAppQER)
-			qers = append(qers, downlinkAppQER)
-
-			ID += 2
+		if !chooseTEID {
+			if teid, err = teids.allocate(i); err != nil {
+				logger.PfcpsimLog.Errorf("could not allocate the TEID of session %v: %v", i, err)
+				releaseSessionResources(i)
+				<-inFlight
+				failed.Store(true)
+				results[j] = toPBSessionResult(i, nil, ueAddresses, err)
+
+				continue
+			}
 		}
 
-		sess, err := sim.EstablishSession(pdrs, fars, qers, urrs)
+		rules, err := profile.Build(session.Variables{
+			ID:           uint32(i),
+			TEID:         teid,
+			ChooseTEID:   chooseTEID,
+			UEAddress:    ueAddress,
+			UEIPv6Prefix: ueIPv6Prefix,
+			// the UE addresses are left empty when the UPF allocates them
+			ChooseUEAddress:    request.UpfAllocatedUEAddress && hasIPv4(request.PdnType),
+			ChooseUEIPv6Prefix: request.UpfAllocatedUEAddress && hasIPv6(request.PdnType),
+			GNBAddress:         request.NodeBAddress,
+			N3Address:          upfN3Address,
+			QFI:                qfi,
+			AppFilters:         appFilters,
+		})
 		if err != nil {
-			return &pb.Response{}, status.Error(codes.Internal, err.Error())
+			logger.PfcpsimLog.Errorf("could not build the rules of session %v: %v", i, err)
+			releaseSessionResources(i)
+			<-inFlight
+			failed.Store(true)
+			results[j] = toPBSessionResult(i, nil, ueAddresses, err)
+
+			continue
 		}
 
-		pfcpsim.InsertSession(i, sess)
+		wg.Add(1)
+
+		go func() {
+			defer func() {
+				<-inFlight
+				wg.Done()
+			}()
+
+			sess, err := sim.EstablishSessionWithBAR(rules.PDRs, rules.FARs, rules.QERs, rules.URRs, rules.BAR)
+			if err != nil {
+				logger.PfcpsimLog.Errorf("could not establish session %v: %v", i, err)
+				releaseSessionResources(i)
+				failed.Store(true)
+				results[j] = toPBSessionResult(i, nil, ueAddresses, err)
+
+				return
+			}
+
+			sess.SetIDAllocator(rules.IDs)
+			pfcpsim.InsertSession(i, sess)
+
+			results[j] = toPBSessionResult(i, sess, nil, nil)
+		}()
 	}
 
-	infoMsg := fmt.Sprintf("%v sessions were established using %v as baseID", count, baseID)
-	logger.PfcpsimLog.Infoln(infoMsg)
+	wg.Wait()
 
-	return &pb.Response{
-		StatusCode: int32(codes.OK),
-		Message:    infoMsg,
-	}, nil
+	if request.FailurePolicy == pb.FailurePolicy_FAILURE_POLICY_ROLLBACK && failed.Load() {
+		rollbackSessions(results)
+	}
+
+	return newSessionsResponse(results,
+		fmt.Sprintf("%v sessions were established using %v as baseID", count, baseID))
 }
 
-func (P pfcpSimService) ModifySession(ctx context.Context, request *pb.ModifySessionRequest) (*pb.Response, error) {
+// rollbackSessions deletes the sessions successfully established according to results.
+// Sessions that cannot be deleted are left active and keep their successful outcome.
+func rollbackSessions(results []*pb.SessionResult) {
+	for _, result := range results {
+		if result.Outcome != pb.SessionOutcome_SESSION_OUTCOME_SUCCESS {
+			continue
+		}
+
+		id := int(result.Id)
+
+		sess, ok := pfcpsim.GetSession(id)
+		if !ok {
+			continue
+		}
+
+		if err := sim.DeleteSession(sess); err != nil {
+			logger.PfcpsimLog.Errorf("could not roll back session %v: %v", id, err)
+			result.Message = fmt.Sprintf("rollback failed: %v", err)
+
+			continue
+		}
+
+		pfcpsim.RemoveSession(id)
+		releaseSessionResources(id)
+
+		result.Outcome = pb.SessionOutcome_SESSION_OUTCOME_ROLLED_BACK
+		result.Message = "deleted because of the failure of another session"
+	}
+}
+
+func (P pfcpSimService) ModifySession(ctx context.Context, request *pb.ModifySessionRequest) (*pb.SessionsResponse, error) {
 	if err := checkServerStatus(); err != nil {
-		return &pb.Response{}, err
+		return &pb.SessionsResponse{}, err
 	}
 
 	// TODO add 5G mode
 	baseID := int(request.BaseID)
 	count := int(request.Count)
+
+	if count < 0 {
+		return &pb.SessionsResponse{}, status.Error(codes.Aborted, "count cannot be negative")
+	}
+
+	if request.FailurePolicy == pb.FailurePolicy_FAILURE_POLICY_ROLLBACK {
+		return &pb.SessionsResponse{}, status.Error(codes.InvalidArgument, "rollback is supported by CreateSession only")
+	}
 	nodeBaddress := request.NodeBAddress
 
 	if pfcpsim.GetActiveSessionNum() < count {
 		err := pfcpsim.NewNotEnoughSessionsError()
 		logger.PfcpsimLog.Errorln(err.Error())
 
-		return &pb.Response{}, status.Error(codes.Aborted, err.Error())
+		return &pb.SessionsResponse{}, status.Error(codes.Aborted, err.Error())
+	}
+
+	if update := request.QerUpdate; update != nil {
+		if _, ok := gateStatuses[update.GateStatus]; !ok {
+			return &pb.SessionsResponse{}, status.Error(codes.InvalidArgument,
+				fmt.Sprintf("unknown gate status %q. Please use 'open' or 'closed'", update.GateStatus))
+		}
+	}
+
+	addFilters, err := parseAppFilters(request.AddAppFilters)
+	if err != nil {
+		return &pb.SessionsResponse{}, status.Error(codes.Aborted, err.Error())
+	}
+
+	removeFilters, err := parseAppFilters(request.RemoveAppFilters)
+	if err != nil {
+		return &pb.SessionsResponse{}, status.Error(codes.Aborted, err.Error())
 	}
 
+	// the downlink rules are updated as before when nothing else is requested
+	otherModifications := request.QerUpdate != nil || len(addFilters) != 0 || len(removeFilters) != 0 || request.QueryURR
+	updateDownlink := nodeBaddress != "" || request.NodeBTEID != 0 || request.BufferFlag || request.NotifyCPFlag ||
+		!otherModifications
+
 	var actions uint8 = 0
 
 	if request.BufferFlag || request.NotifyCPFlag {
@@ -339,109 +650,154 @@ func (P pfcpSimService) ModifySession(ctx context.Context, request *pb.ModifySes
 		actions |= session.ActionForward
 	}
 
-	if err := isNumOfAppFiltersCorrect(request.AppFilters); err != nil {
-		return &pb.Response{}, err
-	}
-
-	for i := baseID; i < (count*SessionStep + baseID); i = i + SessionStep {
-		var newFARs []*ieLib.IE
+	results := make([]*pb.SessionResult, 0, count)
+	failed := false
 
-		var newURRs []*ieLib.IE
+	for i := baseID; i < baseID+count; i++ {
+		if failed && request.FailurePolicy == pb.FailurePolicy_FAILURE_POLICY_STOP {
+			results = append(results, newSkippedSessionResult(i))
+			continue
+		}
 
-		ID := uint32(i + 1)
-		teid := uint32(i + 1)
+		sess, ok := pfcpsim.GetSession(i)
+		if !ok {
+			err := fmt.Errorf("could not retrieve session with index %v", i)
+			logger.PfcpsimLog.Errorln(err)
+			results = append(results, toPBSessionResult(i, nil, nil, err))
+			failed = true
 
-		if request.BufferFlag || request.NotifyCPFlag {
-			teid = 0 // When buffering, TEID = 0.
+			continue
 		}
 
-		for range request.AppFilters {
-			downlinkFAR := session.NewFARBuilder().
-				WithID(ID). // Same FARID that was generated in create sessions
-				WithMethod(session.Update).
-				WithAction(actions).
-				WithDstInterface(ieLib.DstInterfaceAccess).
-				WithTEID(teid).
-				WithDownlinkIP(nodeBaddress).
-				BuildFAR()
+		var ies []*ieLib.IE
 
-			newFARs = append(newFARs, downlinkFAR)
+		if updateDownlink {
+			teid := uint32(i + 1)
+			if request.NodeBTEID != 0 {
+				teid = request.NodeBTEID
+			}
 
-			urrId := ID
-			urr := session.NewURRBuilder().
-				WithID(urrId).
-				WithMethod(session.Update).
-				WithMeasurementPeriod(1 * time.Second).
-				Build()
+			if request.BufferFlag || request.NotifyCPFlag {
+				teid = 0 // When buffering, TEID = 0.
+			}
+
+			// update the downlink rules the session was established with, whatever their IDs
+			farIDs, urrIDs := sess.DownlinkRules()
+
+			for _, farID := range farIDs {
+				downlinkFAR := session.NewFARBuilder().
+					WithID(farID).
+					WithMethod(session.Update).
+					WithAction(actions).
+					WithDstInterface(ieLib.DstInterfaceAccess).
+					WithTEID(teid).
+					WithDownlinkIP(nodeBaddress).
+					BuildFAR()
+
+				ies = append(ies, downlinkFAR)
+			}
 
-			newURRs = append(newURRs, urr)
+			for _, urrID := range urrIDs {
+				urr := session.NewURRBuilder().
+					WithID(urrID).
+					WithMethod(session.Update).
+					WithMeasurementPeriod(1 * time.Second).
+					Build()
 
-			ID += 2
+				ies = append(ies, urr)
+			}
 		}
 
-		sess, ok := pfcpsim.GetSession(i)
-		if !ok {
-			errMsg := fmt.Sprintf("Could not retrieve session with index %v", i)
-			logger.PfcpsimLog.Errorln(errMsg)
+		if request.QerUpdate != nil {
+			ies = append(ies, qerUpdateIEs(sess, request.QerUpdate)...)
+		}
+
+		modIEs, err := appFilterModificationIEs(sess, addFilters, removeFilters, uint8(request.Qfi))
+		if err != nil {
+			logger.PfcpsimLog.Errorf("could not modify session %v: %v", i, err)
+			results = append(results, toPBSessionResult(i, sess, nil, err))
+			failed = true
+
+			continue
+		}
 
-			return &pb.Response{}, status.Error(codes.Internal, errMsg)
+		ies = append(ies, modIEs...)
+
+		if request.QueryURR {
+			for _, urrID := range sess.URRIDs() {
+				ies = append(ies, session.NewQueryURR(urrID))
+			}
 		}
 
-		err := sim.ModifySession(sess, nil, newFARs, nil, newURRs)
+		err = sim.ModifySession(sess, nil, ies, nil, nil)
 		if err != nil {
-			return &pb.Response{}, status.Error(codes.Internal, err.Error())
+			logger.PfcpsimLog.Errorf("could not modify session %v: %v", i, err)
+			failed = true
 		}
-	}
 
-	infoMsg := fmt.Sprintf("%v sessions were modified", count)
-	logger.PfcpsimLog.Infoln(infoMsg)
+		results = append(results, toPBSessionResult(i, sess, nil, err))
+	}
 
-	return &pb.Response{
-		StatusCode: int32(codes.OK),
-		Message:    infoMsg,
-	}, nil
+	return newSessionsResponse(results, fmt.Sprintf("%v sessions were modified", count))
 }
 
-func (P pfcpSimService) DeleteSession(ctx context.Context, request *pb.DeleteSessionRequest) (*pb.Response, error) {
+func (P pfcpSimService) DeleteSession(ctx context.Context, request *pb.DeleteSessionRequest) (*pb.SessionsResponse, error) {
 	if err := checkServerStatus(); err != nil {
-		return &pb.Response{}, err
+		return &pb.SessionsResponse{}, err
 	}
 
 	baseID := int(request.BaseID)
 	count := int(request.Count)
 
+	if count < 0 {
+		return &pb.SessionsResponse{}, status.Error(codes.Aborted, "count cannot be negative")
+	}
+
+	if request.FailurePolicy == pb.FailurePolicy_FAILURE_POLICY_ROLLBACK {
+		return &pb.SessionsResponse{}, status.Error(codes.InvalidArgument, "rollback is supported by CreateSession only")
+	}
+
 	if pfcpsim.GetActiveSessionNum() < count {
 		err := pfcpsim.NewNotEnoughSessionsError()
 		logger.PfcpsimLog.Error(err.Error())
 
-		return &pb.Response{}, status.Error(codes.Aborted, err.Error())
+		return &pb.SessionsResponse{}, status.Error(codes.Aborted, err.Error())
 	}
 
-	for i := baseID; i < (count*SessionStep + baseID); i = i + SessionStep {
+	results := make([]*pb.SessionResult, 0, count)
+	failed := false
+
+	for i := baseID; i < baseID+count; i++ {
+		if failed && request.FailurePolicy == pb.FailurePolicy_FAILURE_POLICY_STOP {
+			results = append(results, newSkippedSessionResult(i))
+			continue
+		}
+
 		sess, ok := pfcpsim.GetSession(i)
 		if !ok {
-			errMsg := "Session was nil. Check baseID"
-			logger.PfcpsimLog.Errorln(errMsg)
+			err := fmt.Errorf("session with index %v not found. Check baseID", i)
+			logger.PfcpsimLog.Errorln(err)
+			results = append(results, toPBSessionResult(i, nil, nil, err))
+			failed = true
 
-			return &pb.Response{}, status.Error(codes.Aborted, errMsg)
+			continue
 		}
 
 		err := sim.DeleteSession(sess)
 		if err != nil {
-			logger.PfcpsimLog.Errorln(err.Error())
-			return &pb.Response{}, status.Error(codes.Aborted, err.Error())
+			logger.PfcpsimLog.Errorf("could not delete session %v: %v", i, err)
+			failed = true
+		} else {
+			// remove from activeSessions
+			pfcpsim.RemoveSession(i)
+			releaseSessionResources(i)
 		}
-		// remove from activeSessions
-		pfcpsim.RemoveSession(i)
-	}
 
-	infoMsg := fmt.Sprintf("%v sessions deleted; activeSessions: %v", count, pfcpsim.GetActiveSessionNum())
-	logger.PfcpsimLog.Infoln(infoMsg)
+		results = append(results, toPBSessionResult(i, sess, nil, err))
+	}
 
-	return &pb.Response{
-		StatusCode: int32(codes.OK),
-		Message:    infoMsg,
-	}, nil
+	return newSessionsResponse(results,
+		fmt.Sprintf("%v sessions deleted; activeSessions: %v", count, pfcpsim.GetActiveSessionNum()))
 }
 
 // NextIP returns the next IP address
@@ -461,3 +817,142 @@ func NextIP(ip net.IP) net.IP {
 
 	return next
 }
+
+func (P pfcpSimService) GetUsageReports(ctx context.Context, request *pb.GetUsageReportsRequest) (*pb.GetUsageReportsResponse, error) {
+	if err := checkServerStatus(); err != nil {
+		return &pb.GetUsageReportsResponse{}, err
+	}
+
+	baseID := int(request.BaseID)
+	count := int(request.Count)
+
+	var sessions []*pb.SessionUsageReports
+
+	for i := baseID; i < baseID+count; i++ {
+		sess, ok := pfcpsim.GetSession(i)
+		if !ok {
+			errMsg := fmt.Sprintf("Could not retrieve session with index %v", i)
+			logger.PfcpsimLog.Errorln(errMsg)
+
+			return &pb.GetUsageReportsResponse{}, status.Error(codes.NotFound, errMsg)
+		}
+
+		sessions = append(sessions, &pb.SessionUsageReports{
+			Id:        int32(i),
+			LocalSEID: sess.LocalSEID(),
+			Reports:   toPBUsageReports(sess.UsageReports()),
+		})
+	}
+
+	return &pb.GetUsageReportsResponse{
+		StatusCode: int32(codes.OK),
+		Message:    fmt.Sprintf("usage reports of %v sessions", count),
+		Sessions:   sessions,
+	}, nil
+}
+
+func (P pfcpSimService) AddUEPool(ctx context.Context, request *pb.UEPool) (*pb.Response, error) {
+	if err := uePools.add(request.Name, request.Network, request.Exclusions); err != nil {
+		logger.PfcpsimLog.Errorln(err)
+		return &pb.Response{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	infoMsg := fmt.Sprintf("UE pool %v added", request.Name)
+	logger.PfcpsimLog.Infoln(infoMsg)
+
+	return &pb.Response{
+		StatusCode: int32(codes.OK),
+		Message:    infoMsg,
+	}, nil
+}
+
+func (P pfcpSimService) RemoveUEPool(ctx context.Context, request *pb.RemoveUEPoolRequest) (*pb.Response, error) {
+	if err := uePools.remove(request.Name); err != nil {
+		logger.PfcpsimLog.Errorln(err)
+		return &pb.Response{}, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	infoMsg := fmt.Sprintf("UE pool %v removed", request.Name)
+	logger.PfcpsimLog.Infoln(infoMsg)
+
+	return &pb.Response{
+		StatusCode: int32(codes.OK),
+		Message:    infoMsg,
+	}, nil
+}
+
+func (P pfcpSimService) ListUEPools(ctx context.Context, empty *pb.EmptyRequest) (*pb.ListUEPoolsResponse, error) {
+	usages := uePools.usage()
+	pools := make([]*pb.UEPool, 0, len(usages))
+
+	for _, usage := range usages {
+		pools = append(pools, toPBUEPool(usage))
+	}
+
+	return &pb.ListUEPoolsResponse{
+		StatusCode: int32(codes.OK),
+		Message:    fmt.Sprintf("%v UE pools", len(pools)),
+		Pools:      pools,
+	}, nil
+}
+
+func (P pfcpSimService) WatchEvents(request *pb.WatchEventsRequest, stream pb.PFCPSim_WatchEventsServer) error {
+	types := make(map[string]struct{}, len(request.Types))
+	for _, t := range request.Types {
+		types[t] = struct{}{}
+	}
+
+	events := broker.subscribe()
+	defer broker.unsubscribe(events)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event := <-events:
+			if !isEventSelected(event, types) {
+				continue
+			}
+
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (P pfcpSimService) ListSessions(ctx context.Context, empty *pb.EmptyRequest) (*pb.ListSessionsResponse, error) {
+	var sessions []*pb.SessionInfo
+
+	for _, id := range pfcpsim.GetSessionIndexes() {
+		sess, ok := pfcpsim.GetSession(id)
+		if !ok {
+			// deleted in the meantime
+			continue
+		}
+
+		sessions = append(sessions, toPBSessionInfo(id, sess))
+	}
+
+	return &pb.ListSessionsResponse{
+		StatusCode: int32(codes.OK),
+		Message:    fmt.Sprintf("%v active sessions", len(sessions)),
+		Sessions:   sessions,
+	}, nil
+}
+
+func (P pfcpSimService) GetSession(ctx context.Context, request *pb.GetSessionRequest) (*pb.GetSessionResponse, error) {
+	sess, ok := pfcpsim.GetSession(int(request.Id))
+	if !ok {
+		errMsg := fmt.Sprintf("Could not retrieve session with index %v", request.Id)
+		logger.PfcpsimLog.Errorln(errMsg)
+
+		return &pb.GetSessionResponse{}, status.Error(codes.NotFound, errMsg)
+	}
+
+	return &pb.GetSessionResponse{
+		StatusCode: int32(codes.OK),
+		Message:    fmt.Sprintf("session %v", request.Id),
+		Session:    toPBSessionInfo(int(request.Id), sess),
+	}, nil
+}
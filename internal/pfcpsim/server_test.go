@@ -0,0 +1,1014 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/omec-project/pfcpsim/api"
+	"github.com/omec-project/pfcpsim/logger"
+	"github.com/omec-project/pfcpsim/pkg/pfcpsim"
+	"github.com/omec-project/pfcpsim/pkg/pfcpsim/mockupf"
+	ieLib "github.com/wmnsk/go-pfcp/ie"
+	"github.com/wmnsk/go-pfcp/message"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_pfcpSimService_WithMockUPF(t *testing.T) {
+	upf := mockupf.NewMockUPF("127.0.0.1:0")
+	if err := upf.Start(); err != nil {
+		t.Fatalf("could not start mock UPF: %v", err)
+	}
+
+	t.Cleanup(upf.Stop)
+
+	ctx := context.Background()
+	service := newTestService(t)
+
+	steps := []struct {
+		name string
+		call func() error
+	}{
+		{
+			name: "Configure",
+			call: func() error {
+				_, err := service.Configure(ctx, &pb.ConfigureRequest{
+					UpfN3Address:      "198.18.0.1",
+					RemotePeerAddress: upf.Addr(),
+					T1:                500,
+				})
+
+				return err
+			},
+		},
+		{
+			name: "Associate",
+			call: func() error {
+				_, err := service.Associate(ctx, &pb.EmptyRequest{})
+				return err
+			},
+		},
+		{
+			name: "UpdateAssociation",
+			call: func() error {
+				_, err := service.UpdateAssociation(ctx, &pb.UpdateAssociationRequest{
+					CpFunctionFeatures: []string{"load", "PSUCC"},
+				})
+
+				return err
+			},
+		},
+		{
+			name: "CreateSession",
+			call: func() error {
+				_, err := service.CreateSession(ctx, &pb.CreateSessionRequest{
+					Count:         2,
+					BaseID:        1,
+					UeAddressPool: "17.0.0.0/24",
+					AppFilters:    []string{"ip:any:any:allow:100"},
+				})
+
+				return err
+			},
+		},
+		{
+			name: "ModifySession",
+			call: func() error {
+				_, err := service.ModifySession(ctx, &pb.ModifySessionRequest{
+					Count:        2,
+					BaseID:       1,
+					NodeBAddress: "198.18.0.10",
+					AppFilters:   []string{"ip:any:any:allow:100"},
+				})
+
+				return err
+			},
+		},
+	}
+
+	for _, step := range steps {
+		if err := step.call(); err != nil {
+			t.Fatalf("%v failed: %v", step.name, err)
+		}
+	}
+
+	if got := upf.CPFunctionFeatures(); !reflect.DeepEqual(got, []uint8{0x01, 0x01}) {
+		t.Errorf("mock UPF CP function features mismatch. got = %#x, want = 0x0101", got)
+	}
+
+	if upf.SessionsNum() != 2 {
+		t.Errorf("mock UPF sessions mismatch. got = %v, want = 2", upf.SessionsNum())
+	}
+
+	list, err := service.ListSessions(ctx, &pb.EmptyRequest{})
+	if err != nil {
+		t.Fatalf("ListSessions failed: %v", err)
+	}
+
+	if len(list.Sessions) != 2 {
+		t.Fatalf("listed sessions mismatch. got = %v, want = 2", len(list.Sessions))
+	}
+
+	for _, sess := range list.Sessions {
+		if sess.NodeBAddress != "198.18.0.10" {
+			t.Errorf("session %v gNB address mismatch. got = %v, want = 198.18.0.10", sess.Id, sess.NodeBAddress)
+		}
+
+		// the gNB TEID defaults to the session ID + 1
+		if want := uint32(sess.Id + 1); sess.NodeBTEID != want {
+			t.Errorf("session %v gNB TEID mismatch. got = %v, want = %v", sess.Id, sess.NodeBTEID, want)
+		}
+
+		if _, ok := upf.Session(sess.PeerSEID); !ok {
+			t.Errorf("session %v with SEID %v not found in mock UPF", sess.Id, sess.PeerSEID)
+		}
+	}
+
+	if _, err := service.DeleteSession(ctx, &pb.DeleteSessionRequest{Count: 2, BaseID: 1}); err != nil {
+		t.Fatalf("DeleteSession failed: %v", err)
+	}
+
+	if upf.SessionsNum() != 0 {
+		t.Errorf("mock UPF sessions mismatch. got = %v, want = 0", upf.SessionsNum())
+	}
+
+	if _, err := service.Disassociate(ctx, &pb.EmptyRequest{}); err != nil {
+		t.Fatalf("Disassociate failed: %v", err)
+	}
+
+	if upf.IsAssociated() {
+		t.Error("mock UPF should not be associated")
+	}
+}
+
+func Test_pfcpSimService_CreateSessionWithProfile(t *testing.T) {
+	dir := t.TempDir()
+
+	profile := `
+pdrs:
+  - {id: {{.ID}}, direction: uplink, teid: {{.TEID}}, n3Address: {{.N3Address}}, farID: {{.ID}}, qerIDs: [{{.ID}}]}
+  - {id: {{add .ID 1}}, direction: downlink, ueAddress: {{.UEAddress}}, farID: {{add .ID 1}}, qerIDs: [{{.ID}}]}
+fars:
+  - {id: {{.ID}}, actions: [forward], dstInterface: core}
+  - {id: {{add .ID 1}}, actions: [forward], dstInterface: access, teid: {{.TEID}}, downlinkIP: {{.GNBAddress}}}
+qers:
+  - {id: {{.ID}}, qfi: 5, uplinkGBR: 1000, downlinkGBR: 2000}
+`
+	if err := os.WriteFile(filepath.Join(dir, "gbr.yaml"), []byte(profile), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := LoadProfiles(dir); err != nil {
+		t.Fatalf("LoadProfiles failed: %v", err)
+	}
+
+	service, upf := setupServiceWithMockUPF(t)
+	ctx := context.Background()
+
+	_, err := service.CreateSession(ctx, &pb.CreateSessionRequest{
+		Count:         1,
+		BaseID:        1,
+		NodeBAddress:  "198.18.0.10",
+		UeAddressPool: "17.0.0.0/24",
+		Profile:       "unknown",
+	})
+	if err == nil {
+		t.Error("CreateSession with an unknown profile should fail")
+	}
+
+	if _, err := service.CreateSession(ctx, &pb.CreateSessionRequest{
+		Count:         1,
+		BaseID:        1,
+		NodeBAddress:  "198.18.0.10",
+		UeAddressPool: "17.0.0.0/24",
+		Profile:       "gbr",
+	}); err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+
+	sessions := upf.Sessions()
+	if len(sessions) != 1 {
+		t.Fatalf("mock UPF sessions mismatch. got = %v, want = 1", len(sessions))
+	}
+
+	qer, ok := sessions[0].QERs[1]
+	if !ok {
+		t.Fatal("QER 1 not found in mock UPF")
+	}
+
+	ulGBR, err := qer.GBRUL()
+	if err != nil {
+		t.Fatalf("QER 1 has no GBR: %v", err)
+	}
+
+	dlGBR, _ := qer.GBRDL()
+
+	if ulGBR != 1000 || dlGBR != 2000 {
+		t.Errorf("GBR mismatch. got UL = %v, DL = %v", ulGBR, dlGBR)
+	}
+
+	if qfi, _ := qer.QFI(); qfi != 5 {
+		t.Errorf("QFI mismatch. got = %v, want = 5", qfi)
+	}
+
+	if len(sessions[0].PDRs) != 2 || len(sessions[0].FARs) != 2 || len(sessions[0].URRs) != 0 {
+		t.Errorf("rules mismatch. got %v PDRs, %v FARs, %v URRs",
+			len(sessions[0].PDRs), len(sessions[0].FARs), len(sessions[0].URRs))
+	}
+
+	if _, err := service.DeleteSession(ctx, &pb.DeleteSessionRequest{Count: 1, BaseID: 1}); err != nil {
+		t.Fatalf("DeleteSession failed: %v", err)
+	}
+}
+
+func Test_pfcpSimService_SessionResults(t *testing.T) {
+	service, upf := setupServiceWithMockUPF(t)
+	ctx := context.Background()
+
+	// the second establishment is rejected
+	upf.InjectFault(mockupf.Fault{
+		MessageType: message.MsgTypeSessionEstablishmentRequest,
+		Nth:         2,
+		Cause:       ieLib.CauseNoResourcesAvailable,
+	})
+
+	res, err := service.CreateSession(ctx, &pb.CreateSessionRequest{
+		Count:         3,
+		BaseID:        1,
+		UeAddressPool: "17.0.0.0/24",
+		AppFilters:    []string{"ip:any:any:allow:100"},
+	})
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("CreateSession should fail with code Internal if a session is rejected. got = %v", err)
+	}
+
+	details := status.Convert(err).Details()
+	if len(details) != 1 {
+		t.Fatalf("error details mismatch. got = %v, want = 1", len(details))
+	}
+
+	if detail, ok := details[0].(*pb.SessionsResponse); !ok || len(detail.Results) != 3 {
+		t.Errorf("error details should carry the results of the 3 sessions. got = %v", details[0])
+	}
+
+	if len(res.Results) != 3 {
+		t.Fatalf("results mismatch. got = %v, want = 3", len(res.Results))
+	}
+
+	var rejected *pb.SessionResult
+
+	for i, result := range res.Results {
+		if wantID := int32(1 + i); result.Id != wantID {
+			t.Errorf("result %v ID mismatch. got = %v, want = %v", i, result.Id, wantID)
+		}
+
+		if len(result.UeAddresses) != 1 {
+			t.Errorf("session %v UE addresses mismatch. got = %v", result.Id, result.UeAddresses)
+		}
+
+		if result.Outcome != pb.SessionOutcome_SESSION_OUTCOME_SUCCESS {
+			rejected = result
+			continue
+		}
+
+		if result.Cause != uint32(ieLib.CauseRequestAccepted) {
+			t.Errorf("session %v cause mismatch. got = %v", result.Id, result.Cause)
+		}
+
+		if result.LocalFSEID.GetSeid() == 0 || result.LocalFSEID.GetAddress() == "" {
+			t.Errorf("session %v local F-SEID not set: %v", result.Id, result.LocalFSEID)
+		}
+
+		if _, ok := upf.Session(result.UpfFSEID.GetSeid()); !ok || result.UpfFSEID.GetAddress() == "" {
+			t.Errorf("session %v UPF F-SEID mismatch: %v", result.Id, result.UpfFSEID)
+		}
+	}
+
+	if rejected == nil {
+		t.Fatal("no rejected session found")
+	}
+
+	if rejected.Outcome != pb.SessionOutcome_SESSION_OUTCOME_REJECTED ||
+		rejected.Cause != uint32(ieLib.CauseNoResourcesAvailable) || rejected.Message == "" {
+		t.Errorf("rejected session result mismatch. got = %v", rejected)
+	}
+
+	if rejected.LocalFSEID != nil || rejected.UpfFSEID != nil {
+		t.Errorf("rejected session should not have F-SEIDs. got = %v", rejected)
+	}
+
+	// the first deletion is rejected, the session is kept and can be deleted again
+	upf.InjectFault(mockupf.Fault{
+		MessageType: message.MsgTypeSessionDeletionRequest,
+		Nth:         1,
+		Cause:       ieLib.CauseRuleCreationModificationFailure,
+	})
+
+	var deleted int
+
+	for _, result := range res.Results {
+		if result == rejected {
+			continue
+		}
+
+		delRes, err := service.DeleteSession(ctx, &pb.DeleteSessionRequest{Count: 1, BaseID: result.Id})
+		if deleted == 0 {
+			if status.Code(err) != codes.Internal || delRes.Results[0].Outcome != pb.SessionOutcome_SESSION_OUTCOME_REJECTED ||
+				delRes.Results[0].Cause != uint32(ieLib.CauseRuleCreationModificationFailure) {
+				t.Fatalf("first deletion should be rejected. got = %v, %v", delRes, err)
+			}
+
+			delRes, err = service.DeleteSession(ctx, &pb.DeleteSessionRequest{Count: 1, BaseID: result.Id})
+		}
+
+		if err != nil {
+			t.Fatalf("DeleteSession failed: %v", err)
+		}
+
+		if delRes.Results[0].Outcome != pb.SessionOutcome_SESSION_OUTCOME_SUCCESS ||
+			delRes.Results[0].UpfFSEID.GetSeid() != result.UpfFSEID.GetSeid() {
+			t.Errorf("deletion result mismatch. got = %v", delRes.Results[0])
+		}
+
+		deleted++
+	}
+
+	if upf.SessionsNum() != 0 {
+		t.Errorf("mock UPF sessions mismatch. got = %v, want = 0", upf.SessionsNum())
+	}
+}
+
+// newTestService returns a service using fresh UE pools and TEID allocator. At the end of the test, the association
+// and the sessions left by the test are torn down and the default settings, UE pools and TEID ranges are restored,
+// so that tests pass in any order.
+func newTestService(t *testing.T) *pfcpSimService {
+	t.Helper()
+
+	uePools = newUEPoolManager()
+	teids = newTEIDAllocator()
+
+	t.Cleanup(resetService)
+
+	return NewPFCPSimService("lo")
+}
+
+// resetService closes the connection to the remote peer, drops the sessions left by the test and restores the
+// state changed by Configure.
+func resetService() {
+	if remotePeerConnected {
+		if sim.IsAssociationAlive() {
+			if err := sim.TeardownAssociation(); err != nil {
+				logger.PfcpsimLog.Warnln("could not tear down the association left by the test:", err)
+			}
+		}
+
+		sim.DisconnectN4()
+
+		remotePeerConnected = false
+	}
+
+	// the sessions left by the test are lost with the mock UPF
+	for _, id := range pfcpsim.GetSessionIndexes() {
+		pfcpsim.RemoveSession(id)
+	}
+
+	uePools = newUEPoolManager()
+	teids = newTEIDAllocator()
+
+	SetRemotePeer("")
+	SetUpfN3("")
+	SetMaxInFlight(pfcpsim.DefaultMaxInFlight)
+	SetRetransmission(pfcpsim.DefaultResponseTimeout, pfcpsim.DefaultMaxRetransmissions)
+	SetAutoRecovery(false)
+	SetPagingEmulation(false, 0)
+	SetPassiveAssociation(false, "")
+	SetCPFunctionFeatures(nil)
+}
+
+// setupServiceWithMockUPF returns a service associated with a new mock UPF. Both are torn down at the end of the test.
+func setupServiceWithMockUPF(t *testing.T) (*pfcpSimService, *mockupf.MockUPF) {
+	t.Helper()
+
+	upf := mockupf.NewMockUPF("127.0.0.1:0")
+	if err := upf.Start(); err != nil {
+		t.Fatalf("could not start mock UPF: %v", err)
+	}
+
+	t.Cleanup(upf.Stop)
+
+	ctx := context.Background()
+	service := newTestService(t)
+
+	if _, err := service.Configure(ctx, &pb.ConfigureRequest{
+		UpfN3Address:      "198.18.0.1",
+		RemotePeerAddress: upf.Addr(),
+		T1:                500,
+	}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+
+	if _, err := service.Associate(ctx, &pb.EmptyRequest{}); err != nil {
+		t.Fatalf("Associate failed: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if _, err := service.Disassociate(ctx, &pb.EmptyRequest{}); err != nil {
+			t.Errorf("Disassociate failed: %v", err)
+		}
+	})
+
+	return service, upf
+}
+
+func Test_pfcpSimService_PassiveAssociation(t *testing.T) {
+	upf := mockupf.NewMockUPF("127.0.0.1:0")
+	if err := upf.Start(); err != nil {
+		t.Fatalf("could not start mock UPF: %v", err)
+	}
+
+	t.Cleanup(upf.Stop)
+
+	ctx := context.Background()
+	service := newTestService(t)
+
+	if _, err := service.Configure(ctx, &pb.ConfigureRequest{
+		UpfN3Address:       "198.18.0.1",
+		RemotePeerAddress:  upf.Addr(),
+		T1:                 500,
+		PassiveAssociation: true,
+		UpfNodeID:          "127.0.0.1",
+	}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+
+	// the N4 socket is bound by Associate, the Association Setup Request is sent until it is accepted
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		cpAddr := net.JoinHostPort("127.0.0.1", fmt.Sprint(pfcpsim.PFCPStandardPort))
+
+		for !upf.IsAssociated() {
+			if err := upf.SetupAssociation(cpAddr); err != nil {
+				t.Errorf("mock UPF could not send Association Setup Request: %v", err)
+				return
+			}
+
+			select {
+			case <-done:
+				return
+			case <-time.After(50 * time.Millisecond):
+			}
+		}
+	}()
+
+	if _, err := service.Associate(ctx, &pb.EmptyRequest{}); err != nil {
+		t.Fatalf("Associate failed: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if _, err := service.Disassociate(ctx, &pb.EmptyRequest{}); err != nil {
+			t.Errorf("Disassociate failed: %v", err)
+		}
+	})
+
+	if upf.Received(message.MsgTypeAssociationSetupRequest) != 0 {
+		t.Error("no Association Setup Request should be sent in passive mode")
+	}
+}
+
+func Test_pfcpSimService_CreateSessionFailurePolicy(t *testing.T) {
+	const (
+		success    = pb.SessionOutcome_SESSION_OUTCOME_SUCCESS
+		rejected   = pb.SessionOutcome_SESSION_OUTCOME_REJECTED
+		skipped    = pb.SessionOutcome_SESSION_OUTCOME_SKIPPED
+		rolledBack = pb.SessionOutcome_SESSION_OUTCOME_ROLLED_BACK
+	)
+
+	tests := []struct {
+		name   string
+		policy pb.FailurePolicy
+		// sessions are established one at a time: the second one is rejected
+		wantOutcomes    []pb.SessionOutcome
+		wantUPFSessions int
+	}{
+		{
+			name:            "continue",
+			policy:          pb.FailurePolicy_FAILURE_POLICY_CONTINUE,
+			wantOutcomes:    []pb.SessionOutcome{success, rejected, success, success},
+			wantUPFSessions: 3,
+		},
+		{
+			name:            "stop",
+			policy:          pb.FailurePolicy_FAILURE_POLICY_STOP,
+			wantOutcomes:    []pb.SessionOutcome{success, rejected, skipped, skipped},
+			wantUPFSessions: 1,
+		},
+		{
+			name:            "rollback",
+			policy:          pb.FailurePolicy_FAILURE_POLICY_ROLLBACK,
+			wantOutcomes:    []pb.SessionOutcome{rolledBack, rejected, skipped, skipped},
+			wantUPFSessions: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, upf := setupServiceWithMockUPF(t)
+			ctx := context.Background()
+
+			upf.InjectFault(mockupf.Fault{
+				MessageType: message.MsgTypeSessionEstablishmentRequest,
+				Nth:         2,
+				Cause:       ieLib.CauseNoResourcesAvailable,
+			})
+
+			res, err := service.CreateSession(ctx, &pb.CreateSessionRequest{
+				Count:         int32(len(tt.wantOutcomes)),
+				BaseID:        1,
+				UeAddressPool: "17.0.0.0/24",
+				AppFilters:    []string{"ip:any:any:allow:100"},
+				FailurePolicy: tt.policy,
+			})
+			if err == nil {
+				t.Error("CreateSession should fail if a session is rejected")
+			}
+
+			if len(res.Results) != len(tt.wantOutcomes) {
+				t.Fatalf("results mismatch. got = %v, want = %v", len(res.Results), len(tt.wantOutcomes))
+			}
+
+			if upf.SessionsNum() != tt.wantUPFSessions {
+				t.Errorf("mock UPF sessions mismatch. got = %v, want = %v", upf.SessionsNum(), tt.wantUPFSessions)
+			}
+
+			for i, result := range res.Results {
+				if result.Outcome != tt.wantOutcomes[i] {
+					t.Errorf("session %v outcome mismatch. got = %v, want = %v", result.Id, result.Outcome, tt.wantOutcomes[i])
+				}
+
+				if result.Outcome != success {
+					continue
+				}
+
+				if _, err := service.DeleteSession(ctx, &pb.DeleteSessionRequest{Count: 1, BaseID: result.Id}); err != nil {
+					t.Errorf("DeleteSession failed: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func Test_pfcpSimService_UEPools(t *testing.T) {
+	service, _ := setupServiceWithMockUPF(t)
+	ctx := context.Background()
+
+	// 17.1.0.1-17.1.0.6 except 17.1.0.1 and 17.1.0.2
+	if _, err := service.AddUEPool(ctx, &pb.UEPool{
+		Name:       "small",
+		Network:    "17.1.0.0/29",
+		Exclusions: []string{"17.1.0.1-17.1.0.2"},
+	}); err != nil {
+		t.Fatalf("AddUEPool failed: %v", err)
+	}
+
+	wantAllocated := func(want uint64) {
+		t.Helper()
+
+		res, err := service.ListUEPools(ctx, &pb.EmptyRequest{})
+		if err != nil {
+			t.Fatalf("ListUEPools failed: %v", err)
+		}
+
+		if len(res.Pools) != 1 || res.Pools[0].Size != 4 || res.Pools[0].Allocated != want {
+			t.Errorf("UE pools mismatch. got = %v, want 1 pool of size 4 with %v addresses allocated", res.Pools, want)
+		}
+	}
+
+	res, err := service.CreateSession(ctx, &pb.CreateSessionRequest{
+		Count:         5,
+		BaseID:        1,
+		NodeBAddress:  "198.18.0.10",
+		UeAddressPool: "small",
+		AppFilters:    []string{"ip:any:any:allow:100"},
+	})
+	if err == nil {
+		t.Error("CreateSession should fail once the UE pool is exhausted")
+	}
+
+	if len(res.Results) != 5 {
+		t.Fatalf("results mismatch. got = %v, want = 5", len(res.Results))
+	}
+
+	for i, result := range res.Results {
+		wantOutcome := pb.SessionOutcome_SESSION_OUTCOME_SUCCESS
+		if i == 4 {
+			wantOutcome = pb.SessionOutcome_SESSION_OUTCOME_FAILED
+		}
+
+		if result.Outcome != wantOutcome {
+			t.Errorf("session %v outcome mismatch. got = %v, want = %v", result.Id, result.Outcome, wantOutcome)
+		}
+	}
+
+	if got := res.Results[0].UeAddresses; len(got) != 1 || got[0] != "17.1.0.3" {
+		t.Errorf("UE addresses mismatch. got = %v, want = [17.1.0.3]", got)
+	}
+
+	wantAllocated(4)
+
+	// an active session is not established again, and keeps its UE address
+	res, err = service.CreateSession(ctx, &pb.CreateSessionRequest{
+		Count:         1,
+		BaseID:        1,
+		NodeBAddress:  "198.18.0.10",
+		UeAddressPool: "small",
+		AppFilters:    []string{"ip:any:any:allow:100"},
+	})
+	if err == nil {
+		t.Error("CreateSession should fail for an active session")
+	}
+
+	if len(res.Results) != 1 || res.Results[0].Outcome != pb.SessionOutcome_SESSION_OUTCOME_FAILED ||
+		!strings.Contains(res.Results[0].Message, "already active") {
+		t.Errorf("results mismatch. got = %v, want session 1 failed as already active", res.Results)
+	}
+
+	got, err := service.GetSession(ctx, &pb.GetSessionRequest{Id: 1})
+	if err != nil {
+		t.Fatalf("GetSession failed: %v", err)
+	}
+
+	if addresses := got.Session.UeAddresses; len(addresses) != 1 || addresses[0] != "17.1.0.3" {
+		t.Errorf("session 1 UE addresses mismatch. got = %v, want = [17.1.0.3]", addresses)
+	}
+
+	wantAllocated(4)
+
+	if _, err := service.RemoveUEPool(ctx, &pb.RemoveUEPoolRequest{Name: "small"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("RemoveUEPool error mismatch. got = %v, want FailedPrecondition", err)
+	}
+
+	if _, err := service.DeleteSession(ctx, &pb.DeleteSessionRequest{Count: 4, BaseID: 1}); err != nil {
+		t.Fatalf("DeleteSession failed: %v", err)
+	}
+
+	wantAllocated(0)
+
+	if _, err := service.RemoveUEPool(ctx, &pb.RemoveUEPoolRequest{Name: "small"}); err != nil {
+		t.Errorf("RemoveUEPool failed: %v", err)
+	}
+}
+
+func Test_pfcpSimService_TEIDs(t *testing.T) {
+	service, upf := setupServiceWithMockUPF(t)
+	ctx := context.Background()
+
+	if _, err := service.Configure(ctx, &pb.ConfigureRequest{
+		UpfN3Address:      "198.18.0.1",
+		RemotePeerAddress: upf.Addr(),
+		T1:                500,
+		TeidRanges:        []string{"1000-1001"},
+	}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+
+	request := &pb.CreateSessionRequest{
+		Count:         3,
+		BaseID:        1,
+		NodeBAddress:  "198.18.0.10",
+		UeAddressPool: "17.0.0.0/24",
+		AppFilters:    []string{"ip:any:any:allow:100"},
+		FailurePolicy: pb.FailurePolicy_FAILURE_POLICY_STOP,
+	}
+
+	res, err := service.CreateSession(ctx, request)
+	if err == nil {
+		t.Error("CreateSession should fail once the TEID ranges are exhausted")
+	}
+
+	wantOutcomes := []pb.SessionOutcome{
+		pb.SessionOutcome_SESSION_OUTCOME_SUCCESS,
+		pb.SessionOutcome_SESSION_OUTCOME_SUCCESS,
+		pb.SessionOutcome_SESSION_OUTCOME_FAILED,
+	}
+
+	if len(res.Results) != len(wantOutcomes) {
+		t.Fatalf("results mismatch. got = %v, want = %v", len(res.Results), len(wantOutcomes))
+	}
+
+	for i, result := range res.Results {
+		if result.Outcome != wantOutcomes[i] {
+			t.Errorf("session %v outcome mismatch. got = %v, want = %v", result.Id, result.Outcome, wantOutcomes[i])
+		}
+	}
+
+	for id, want := range map[int32]uint32{1: 1000, 2: 1001} {
+		got, err := service.GetSession(ctx, &pb.GetSessionRequest{Id: id})
+		if err != nil {
+			t.Fatalf("GetSession failed: %v", err)
+		}
+
+		if got.Session.UplinkTEID != want {
+			t.Errorf("session %v uplink TEID mismatch. got = %v, want = %v", id, got.Session.UplinkTEID, want)
+		}
+	}
+
+	// the UPF allocates the TEIDs, so that the exhausted ranges do not matter
+	request.BaseID = 3
+	request.UpfAllocatedTEID = true
+
+	res, err = service.CreateSession(ctx, request)
+	if err != nil {
+		t.Fatalf("CreateSession with UPF allocated TEIDs failed: %v", err)
+	}
+
+	if len(res.Results) != 3 {
+		t.Fatalf("results mismatch. got = %v, want = 3", len(res.Results))
+	}
+
+	for _, result := range res.Results {
+		if len(result.CreatedPDRs) != 1 || result.CreatedPDRs[0].Teid == 0 {
+			t.Fatalf("session %v created PDRs mismatch. got = %v, want 1 PDR with a TEID", result.Id, result.CreatedPDRs)
+		}
+
+		got, err := service.GetSession(ctx, &pb.GetSessionRequest{Id: result.Id})
+		if err != nil {
+			t.Fatalf("GetSession failed: %v", err)
+		}
+
+		if want := result.CreatedPDRs[0].Teid; got.Session.UplinkTEID != want {
+			t.Errorf("session %v uplink TEID mismatch. got = %v, want = %v", result.Id, got.Session.UplinkTEID, want)
+		}
+	}
+}
+
+func Test_pfcpSimService_UPFAllocatedUEAddresses(t *testing.T) {
+	service, _ := setupServiceWithMockUPF(t)
+	ctx := context.Background()
+
+	// the UE pools are ignored, even if they do not exist
+	res, err := service.CreateSession(ctx, &pb.CreateSessionRequest{
+		Count:                 2,
+		BaseID:                1,
+		NodeBAddress:          "198.18.0.10",
+		UeAddressPool:         "unknown",
+		PdnType:               pb.PdnType_PDN_TYPE_IPV4V6,
+		AppFilters:            []string{"ip:any:any:allow:100"},
+		UpfAllocatedUEAddress: true,
+	})
+	if err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+
+	if len(res.Results) != 2 {
+		t.Fatalf("results mismatch. got = %v, want = 2", len(res.Results))
+	}
+
+	for i, result := range res.Results {
+		// allocated in order from mockupf.DefaultUEPool and mockupf.DefaultUEPoolV6
+		want := []string{fmt.Sprintf("10.250.0.%v", i+1), fmt.Sprintf("2001:db8:250:%v::/64", i+1)}
+
+		if !reflect.DeepEqual(result.UeAddresses, want) {
+			t.Errorf("session %v UE addresses mismatch. got = %v, want = %v", result.Id, result.UeAddresses, want)
+		}
+
+		got, err := service.GetSession(ctx, &pb.GetSessionRequest{Id: result.Id})
+		if err != nil {
+			t.Fatalf("GetSession failed: %v", err)
+		}
+
+		if !reflect.DeepEqual(got.Session.UeAddresses, want) {
+			t.Errorf("session %v stored UE addresses mismatch. got = %v, want = %v", result.Id, got.Session.UeAddresses, want)
+		}
+	}
+
+	if pools, err := service.ListUEPools(ctx, &pb.EmptyRequest{}); err != nil || len(pools.Pools) != 0 {
+		t.Errorf("no UE pool should be used. got = %v, %v", pools, err)
+	}
+}
+
+func Test_pfcpSimService_UPFunctionFeatures(t *testing.T) {
+	service, upf := setupServiceWithMockUPF(t)
+	ctx := context.Background()
+
+	res, err := service.GetAssociation(ctx, &pb.EmptyRequest{})
+	if err != nil {
+		t.Fatalf("GetAssociation failed: %v", err)
+	}
+
+	if want := []string{"FTUP", "UEIP"}; !reflect.DeepEqual(res.Association.UpFeatures, want) {
+		t.Errorf("UP function features mismatch. got = %v, want = %v", res.Association.UpFeatures, want)
+	}
+
+	// the UPF does not allocate F-TEIDs and UE addresses anymore
+	if err := upf.UpdateAssociation(ieLib.NewUPFunctionFeatures(0x00)); err != nil {
+		t.Fatalf("mock UPF could not update association: %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for len(sim.AssociationState().UPFunctionFeatures.Names()) != 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	request := &pb.CreateSessionRequest{
+		Count:                 1,
+		BaseID:                1,
+		NodeBAddress:          "198.18.0.10",
+		UeAddressPool:         "17.0.0.0/24",
+		AppFilters:            []string{"ip:any:any:allow:100"},
+		UpfAllocatedUEAddress: true,
+	}
+
+	_, err = service.CreateSession(ctx, request)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("CreateSession with UPF allocated UE addresses should fail with FailedPrecondition. got = %v", err)
+	}
+
+	// the TEIDs are allocated locally instead
+	request.UpfAllocatedUEAddress = false
+	request.UpfAllocatedTEID = true
+
+	sessions, err := service.CreateSession(ctx, request)
+	if err != nil {
+		t.Fatalf("CreateSession with UPF allocated TEIDs failed: %v", err)
+	}
+
+	if result := sessions.Results[0]; result.Outcome != pb.SessionOutcome_SESSION_OUTCOME_SUCCESS ||
+		len(result.CreatedPDRs) != 0 {
+		t.Errorf("session should be established without Created PDRs. got = %v", result)
+	}
+
+	got, err := service.GetSession(ctx, &pb.GetSessionRequest{Id: 1})
+	if err != nil {
+		t.Fatalf("GetSession failed: %v", err)
+	}
+
+	if got.Session.UplinkTEID != 1 {
+		t.Errorf("uplink TEID mismatch. got = %v, want = 1", got.Session.UplinkTEID)
+	}
+}
+
+func Test_pfcpSimService_ModifySessionOperations(t *testing.T) {
+	service, upf := setupServiceWithMockUPF(t)
+	ctx := context.Background()
+
+	if _, err := service.CreateSession(ctx, &pb.CreateSessionRequest{
+		Count:         1,
+		BaseID:        1,
+		UeAddressPool: "17.0.0.0/24",
+		AppFilters:    []string{"ip:any:any:allow:100"},
+		Qfi:           9,
+	}); err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+
+	info, err := service.GetSession(ctx, &pb.GetSessionRequest{Id: 1})
+	if err != nil {
+		t.Fatalf("GetSession failed: %v", err)
+	}
+
+	upfSession := func() *mockupf.Session {
+		t.Helper()
+
+		sess, ok := upf.Session(info.Session.PeerSEID)
+		if !ok {
+			t.Fatal("session not found in mock UPF")
+		}
+
+		return sess
+	}
+
+	const appFilter = "udp:10.0.0.0/8:80-88:deny:200"
+
+	// the default profile installs 2 PDRs, 2 FARs, 3 QERs and 2 URRs
+	steps := []struct {
+		name    string
+		request *pb.ModifySessionRequest
+		check   func(t *testing.T, sess *mockupf.Session)
+	}{
+		{
+			name:    "add application filter",
+			request: &pb.ModifySessionRequest{AddAppFilters: []string{appFilter}, Qfi: 5},
+			check: func(t *testing.T, sess *mockupf.Session) {
+				if len(sess.PDRs) != 4 || len(sess.QERs) != 5 || len(sess.FARs) != 2 {
+					t.Errorf("rules mismatch. got = %v PDRs, %v QERs, %v FARs, want = 4, 5, 2",
+						len(sess.PDRs), len(sess.QERs), len(sess.FARs))
+				}
+
+				if gate, err := sess.QERs[4].GateStatusUL(); err != nil || gate != ieLib.GateStatusClosed {
+					t.Errorf("new QER gate status mismatch. got = %v, %v, want = closed", gate, err)
+				}
+			},
+		},
+		{
+			name: "update QER",
+			request: &pb.ModifySessionRequest{QerUpdate: &pb.QERUpdate{
+				Ids: []uint32{1}, UplinkMBR: 1000, DownlinkMBR: 2000, GateStatus: "closed",
+			}},
+			check: func(t *testing.T, sess *mockupf.Session) {
+				ul, errUL := sess.QERs[1].MBRUL()
+				dl, errDL := sess.QERs[1].MBRDL()
+
+				if errUL != nil || errDL != nil || ul != 1000 || dl != 2000 {
+					t.Errorf("MBR mismatch. got = %v, %v, want = 1000, 2000", ul, dl)
+				}
+
+				if gate, err := sess.QERs[1].GateStatusUL(); err != nil || gate != ieLib.GateStatusClosed {
+					t.Errorf("gate status mismatch. got = %v, %v, want = closed", gate, err)
+				}
+
+				// QERs not listed are left untouched
+				if _, err := sess.QERs[2].MBRUL(); err != nil {
+					t.Errorf("QER 2 MBR should be kept: %v", err)
+				}
+			},
+		},
+		{
+			name:    "remove application filter",
+			request: &pb.ModifySessionRequest{RemoveAppFilters: []string{appFilter}},
+			check: func(t *testing.T, sess *mockupf.Session) {
+				// the shared session QER and the FARs are kept
+				if len(sess.PDRs) != 2 || len(sess.QERs) != 3 || len(sess.FARs) != 2 {
+					t.Errorf("rules mismatch. got = %v PDRs, %v QERs, %v FARs, want = 2, 3, 2",
+						len(sess.PDRs), len(sess.QERs), len(sess.FARs))
+				}
+			},
+		},
+		{
+			name:    "change gNB F-TEID",
+			request: &pb.ModifySessionRequest{NodeBAddress: "198.18.0.20", NodeBTEID: 77},
+			check: func(t *testing.T, sess *mockupf.Session) {
+				params, err := sess.FARs[2].ForwardingParameters()
+				if err != nil {
+					t.Fatalf("downlink FAR without forwarding parameters: %v", err)
+				}
+
+				var ohc *ieLib.OuterHeaderCreationFields
+
+				for _, i := range params {
+					if i.Type == ieLib.OuterHeaderCreation {
+						ohc, err = i.OuterHeaderCreation()
+					}
+				}
+
+				if err != nil || ohc == nil || ohc.TEID != 77 || !ohc.IPv4Address.Equal(net.ParseIP("198.18.0.20")) {
+					t.Errorf("downlink FAR outer header creation mismatch. got = %+v, %v", ohc, err)
+				}
+			},
+		},
+	}
+
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			step.request.Count, step.request.BaseID = 1, 1
+
+			if _, err := service.ModifySession(ctx, step.request); err != nil {
+				t.Fatalf("ModifySession failed: %v", err)
+			}
+
+			step.check(t, upfSession())
+		})
+	}
+
+	if _, err := service.ModifySession(ctx, &pb.ModifySessionRequest{Count: 1, BaseID: 1, QueryURR: true}); err != nil {
+		t.Fatalf("ModifySession with Query URR failed: %v", err)
+	}
+
+	reports, err := service.GetUsageReports(ctx, &pb.GetUsageReportsRequest{Count: 1, BaseID: 1})
+	if err != nil {
+		t.Fatalf("GetUsageReports failed: %v", err)
+	}
+
+	if len(reports.Sessions) != 1 || len(reports.Sessions[0].Reports) != 2 {
+		t.Fatalf("usage reports mismatch. got = %v, want = 2 reports", reports.Sessions)
+	}
+
+	for _, report := range reports.Sessions[0].Reports {
+		if !reflect.DeepEqual(report.Triggers, []string{"IMMER"}) {
+			t.Errorf("URR %v triggers mismatch. got = %v, want = [IMMER]", report.UrrID, report.Triggers)
+		}
+	}
+
+	if _, err := service.ModifySession(ctx, &pb.ModifySessionRequest{
+		Count: 1, BaseID: 1, QerUpdate: &pb.QERUpdate{GateStatus: "half-open"},
+	}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("invalid gate status error mismatch. got = %v, want = %v", err, codes.InvalidArgument)
+	}
+
+	if _, err := service.ModifySession(ctx, &pb.ModifySessionRequest{
+		Count: 1, BaseID: 1, RemoveAppFilters: []string{appFilter},
+	}); err == nil {
+		t.Error("removing an application filter not installed should fail")
+	}
+}
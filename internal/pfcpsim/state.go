@@ -4,7 +4,10 @@
 package pfcpsim
 
 import (
+	"time"
+
 	"github.com/omec-project/pfcpsim/pkg/pfcpsim"
+	"github.com/omec-project/pfcpsim/pkg/pfcpsim/session"
 )
 
 var (
@@ -13,6 +16,32 @@ var (
 
 	interfaceName string
 
+	// maxInFlight is the number of session requests that can wait for a response at the same time
+	maxInFlight = pfcpsim.DefaultMaxInFlight
+	// responseTimeout (T1) and maxRetransmissions (N1) drive requests retransmission
+	responseTimeout    = pfcpsim.DefaultResponseTimeout
+	maxRetransmissions = pfcpsim.DefaultMaxRetransmissions
+	// autoRecovery re-establishes association and sessions when the remote peer restarts
+	autoRecovery bool
+	// pagingEmulation answers Downlink Data Reports by restoring the downlink forwarding after pagingDelay
+	pagingEmulation bool
+	pagingDelay     time.Duration
+	// passiveAssociation waits for the UPF with Node ID upfNodeID (any if empty) to set up the association
+	passiveAssociation bool
+	upfNodeID          string
+	// cpFunctionFeatures are the octets of the CP Function Features IE advertised to the UPF
+	cpFunctionFeatures []uint8
+
+	// profiles describe the rules of the sessions, indexed by name
+	profiles = map[string]*session.Profile{
+		session.DefaultProfileName: session.DefaultProfile(),
+	}
+
+	// uePools allocate the UE addresses of the sessions
+	uePools = newUEPoolManager()
+	// teids allocates the uplink TEIDs of the sessions, unless the UPF allocates them
+	teids = newTEIDAllocator()
+
 	// Emulates 5G SMF/ 4G SGW
 	sim                 *pfcpsim.PFCPClient
 	remotePeerConnected bool
@@ -0,0 +1,173 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/omec-project/pfcpsim/pkg/pfcpsim"
+)
+
+// teidAllocator allocates the uplink TEIDs of the sessions from a set of ranges. TEIDs are allocated
+// in order starting after the last allocated one, so that a released TEID is not reused right away.
+type teidAllocator struct {
+	lock sync.Mutex
+	// ranges are the TEID ranges, sorted and not overlapping
+	ranges []offsetRange
+	used   map[uint32]struct{}
+	next   uint32
+	// sessions are the TEIDs allocated to the active sessions, indexed by session ID
+	sessions map[int]uint32
+}
+
+// defaultTEIDRange is used until ranges are configured. TEID 0 is left out, as it has a special meaning in GTP-U.
+var defaultTEIDRange = offsetRange{first: 1, last: math.MaxUint32}
+
+func newTEIDAllocator() *teidAllocator {
+	return &teidAllocator{
+		ranges:   []offsetRange{defaultTEIDRange},
+		used:     make(map[uint32]struct{}),
+		next:     1,
+		sessions: make(map[int]uint32),
+	}
+}
+
+// parseTEIDRanges parses ranges, each one either a single TEID or a range (first-last).
+func parseTEIDRanges(ranges []string) ([]offsetRange, error) {
+	parsed := make([]offsetRange, 0, len(ranges))
+
+	for _, r := range ranges {
+		bounds := strings.SplitN(r, "-", 2)
+
+		first, err := strconv.ParseUint(strings.TrimSpace(bounds[0]), 0, 32)
+		if err != nil {
+			return nil, pfcpsim.NewInvalidFormatError(fmt.Sprintf("TEID range %v", r), err)
+		}
+
+		last := first
+
+		if len(bounds) == 2 {
+			if last, err = strconv.ParseUint(strings.TrimSpace(bounds[1]), 0, 32); err != nil {
+				return nil, pfcpsim.NewInvalidFormatError(fmt.Sprintf("TEID range %v", r), err)
+			}
+		}
+
+		if first == 0 || first > last {
+			return nil, pfcpsim.NewInvalidFormatError(fmt.Sprintf("TEID range %v. TEIDs must be between 1 and %v, "+
+				"the first TEID not greater than the last one", r, uint32(math.MaxUint32)))
+		}
+
+		parsed = append(parsed, offsetRange{first: first, last: last})
+	}
+
+	return mergeRanges(parsed), nil
+}
+
+// setRanges replaces the ranges TEIDs are allocated from. TEIDs already allocated are kept until released.
+func (a *teidAllocator) setRanges(ranges []string) error {
+	parsed, err := parseTEIDRanges(ranges)
+	if err != nil {
+		return err
+	}
+
+	if len(parsed) == 0 {
+		return fmt.Errorf("no TEID range")
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.ranges = parsed
+	a.next = uint32(parsed[0].first)
+
+	return nil
+}
+
+// rangesString returns the ranges TEIDs are allocated from.
+func (a *teidAllocator) rangesString() string {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	ranges := make([]string, 0, len(a.ranges))
+	for _, r := range a.ranges {
+		ranges = append(ranges, fmt.Sprintf("%v-%v", r.first, r.last))
+	}
+
+	return strings.Join(ranges, ",")
+}
+
+// allocate returns an unused TEID and records it as allocated to the session id, in place of the TEID
+// previously allocated to it.
+func (a *teidAllocator) allocate(id int) (uint32, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if previous, ok := a.sessions[id]; ok {
+		delete(a.used, previous)
+		delete(a.sessions, id)
+	}
+
+	teid := a.next
+	if !a.contains(teid) {
+		teid = a.following(teid)
+	}
+
+	for start := teid; ; {
+		if _, ok := a.used[teid]; !ok {
+			break
+		}
+
+		if teid = a.following(teid); teid == start {
+			return 0, pfcpsim.NewTEIDExhaustedError()
+		}
+	}
+
+	a.used[teid] = struct{}{}
+	a.sessions[id] = teid
+	a.next = a.following(teid)
+
+	return teid, nil
+}
+
+// contains reports whether teid belongs to a range. The lock must be held.
+func (a *teidAllocator) contains(teid uint32) bool {
+	for _, r := range a.ranges {
+		if r.first <= uint64(teid) && uint64(teid) <= r.last {
+			return true
+		}
+	}
+
+	return false
+}
+
+// following returns the first TEID of the ranges after teid, wrapping around after the last range.
+// The lock must be held.
+func (a *teidAllocator) following(teid uint32) uint32 {
+	for _, r := range a.ranges {
+		if uint64(teid) < r.first {
+			return uint32(r.first)
+		}
+
+		if uint64(teid) < r.last {
+			return teid + 1
+		}
+	}
+
+	return uint32(a.ranges[0].first)
+}
+
+// release makes the TEID allocated to the session id available again.
+func (a *teidAllocator) release(id int) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if teid, ok := a.sessions[id]; ok {
+		delete(a.used, teid)
+		delete(a.sessions, id)
+	}
+}
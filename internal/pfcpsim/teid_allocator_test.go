@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_parseTEIDRanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		ranges  []string
+		want    []offsetRange
+		wantErr bool
+	}{
+		{
+			name:   "single TEID and range",
+			ranges: []string{"100-199", "0x400"},
+			want:   []offsetRange{{first: 100, last: 199}, {first: 1024, last: 1024}},
+		},
+		{
+			name:   "overlapping ranges are merged",
+			ranges: []string{"150-300", "100-199"},
+			want:   []offsetRange{{first: 100, last: 300}},
+		},
+		{
+			name:    "TEID 0",
+			ranges:  []string{"0-10"},
+			wantErr: true,
+		},
+		{
+			name:    "first greater than last",
+			ranges:  []string{"20-10"},
+			wantErr: true,
+		},
+		{
+			name:    "TEID out of range",
+			ranges:  []string{"1-4294967296"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid format",
+			ranges:  []string{"ten"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTEIDRanges(tt.ranges)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTEIDRanges() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseTEIDRanges() got = %v, want = %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_teidAllocator(t *testing.T) {
+	teids := newTEIDAllocator()
+	if err := teids.setRanges([]string{"10-11", "20"}); err != nil {
+		t.Fatalf("setRanges() error = %v", err)
+	}
+
+	for id, want := range []uint32{10, 11, 20} {
+		if got, err := teids.allocate(id); err != nil || got != want {
+			t.Errorf("allocate(%v) got = %v, %v, want = %v", id, got, err, want)
+		}
+	}
+
+	if _, err := teids.allocate(3); err == nil {
+		t.Error("allocate() should fail once the ranges are exhausted")
+	}
+
+	teids.release(1)
+
+	// allocation wraps around to the released TEID
+	if got, err := teids.allocate(3); err != nil || got != 11 {
+		t.Errorf("allocate() after release got = %v, %v, want = 11", got, err)
+	}
+
+	// allocating again to a session frees its previous TEID
+	if got, err := teids.allocate(3); err != nil || got != 11 {
+		t.Errorf("allocate() again got = %v, %v, want = 11", got, err)
+	}
+
+	if _, err := teids.allocate(4); err == nil {
+		t.Error("allocate() should fail once the ranges are exhausted")
+	}
+
+	if err := teids.setRanges(nil); err == nil {
+		t.Error("setRanges() should fail without ranges")
+	}
+}
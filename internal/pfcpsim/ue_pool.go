@@ -0,0 +1,453 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/omec-project/pfcpsim/pkg/pfcpsim"
+)
+
+// uePool allocates UE addresses from a network. IPv4 pools allocate host addresses, leaving out the
+// network and broadcast addresses, IPv6 pools delegate /64 prefixes. Addresses are handled as offsets
+// from the network address, in units of one address for IPv4 pools and of one /64 prefix for IPv6 pools.
+type uePool struct {
+	name       string
+	network    *net.IPNet
+	exclusions []string
+	// first and last are the offsets of the first and last allocatable addresses
+	first, last uint64
+	// excluded are the offset ranges that are never allocated, sorted and not overlapping
+	excluded  []offsetRange
+	allocated map[uint64]struct{}
+	// next is the offset the search for a free address starts from, so that released addresses
+	// are not reused right away
+	next uint64
+}
+
+// offsetRange is the range of offsets from first to last, both included.
+type offsetRange struct {
+	first, last uint64
+}
+
+// uePoolUsage is a snapshot of the utilisation of a pool.
+type uePoolUsage struct {
+	name       string
+	network    string
+	exclusions []string
+	size       uint64
+	allocated  uint64
+}
+
+// newUEPool returns a pool named name allocating the addresses of network, a CIDR, except
+// exclusions. Exclusions are single addresses, ranges (first-last) or networks in CIDR notation.
+func newUEPool(name, network string, exclusions []string) (*uePool, error) {
+	_, ipNet, err := net.ParseCIDR(network)
+	if err != nil {
+		return nil, pfcpsim.NewInvalidFormatError("UE address pool", err)
+	}
+
+	ones, bits := ipNet.Mask.Size()
+	if ones == 0 {
+		return nil, pfcpsim.NewInvalidFormatError(fmt.Sprintf("UE address pool %v. Prefix length must not be 0", network))
+	}
+
+	pool := &uePool{
+		name:       name,
+		network:    ipNet,
+		exclusions: exclusions,
+		allocated:  make(map[uint64]struct{}),
+	}
+
+	if bits == net.IPv4len*8 {
+		pool.network.IP = ipNet.IP.To4()
+		pool.last = 1<<(bits-ones) - 1
+
+		// /31 and /32 pools have no network and broadcast addresses (RFC 3021)
+		if ones < 31 {
+			pool.first = 1
+			pool.last--
+		}
+	} else {
+		if ones > ueIPv6PrefixLength {
+			return nil, pfcpsim.NewInvalidFormatError(
+				fmt.Sprintf("IPv6 UE address pool. Prefix length must not exceed /%v", ueIPv6PrefixLength))
+		}
+
+		pool.last = ^uint64(0) >> ones
+	}
+
+	for _, exclusion := range exclusions {
+		r, err := pool.parseExclusion(exclusion)
+		if err != nil {
+			return nil, err
+		}
+
+		pool.excluded = append(pool.excluded, r)
+	}
+
+	pool.excluded = mergeRanges(pool.excluded)
+	pool.next = pool.first
+
+	return pool, nil
+}
+
+// isIPv6 reports whether the pool delegates IPv6 prefixes.
+func (p *uePool) isIPv6() bool {
+	return p.network.IP.To4() == nil
+}
+
+// offset returns the offset of ip in the pool, false if ip does not belong to the pool.
+func (p *uePool) offset(ip net.IP) (uint64, bool) {
+	if !p.network.Contains(ip) {
+		return 0, false
+	}
+
+	if p.isIPv6() {
+		return binary.BigEndian.Uint64(ip.To16()) - binary.BigEndian.Uint64(p.network.IP.To16()), true
+	}
+
+	return uint64(binary.BigEndian.Uint32(ip.To4()) - binary.BigEndian.Uint32(p.network.IP)), true
+}
+
+// address returns the address at offset in the pool: an IPv4 address or an IPv6 /64 prefix in CIDR notation.
+func (p *uePool) address(offset uint64) string {
+	if p.isIPv6() {
+		ip := make(net.IP, net.IPv6len)
+		binary.BigEndian.PutUint64(ip, binary.BigEndian.Uint64(p.network.IP.To16())+offset)
+
+		return fmt.Sprintf("%v/%v", ip, ueIPv6PrefixLength)
+	}
+
+	ip := make(net.IP, net.IPv4len)
+	binary.BigEndian.PutUint32(ip, binary.BigEndian.Uint32(p.network.IP)+uint32(offset))
+
+	return ip.String()
+}
+
+// parseExclusion returns the offsets excluded by exclusion, which must belong to the pool.
+func (p *uePool) parseExclusion(exclusion string) (offsetRange, error) {
+	var first, last net.IP
+
+	switch {
+	case strings.Contains(exclusion, "/"):
+		_, ipNet, err := net.ParseCIDR(exclusion)
+		if err != nil {
+			return offsetRange{}, pfcpsim.NewInvalidFormatError("UE pool exclusion", err)
+		}
+
+		first = ipNet.IP
+		last = make(net.IP, len(ipNet.IP))
+
+		for i := range ipNet.IP {
+			last[i] = ipNet.IP[i] | ^ipNet.Mask[i]
+		}
+	case strings.Contains(exclusion, "-"):
+		bounds := strings.SplitN(exclusion, "-", 2)
+		first = net.ParseIP(strings.TrimSpace(bounds[0]))
+		last = net.ParseIP(strings.TrimSpace(bounds[1]))
+	default:
+		first = net.ParseIP(exclusion)
+		last = first
+	}
+
+	if first == nil || last == nil {
+		return offsetRange{}, pfcpsim.NewInvalidFormatError(fmt.Sprintf("UE pool exclusion %v", exclusion))
+	}
+
+	firstOffset, firstOk := p.offset(first)
+	lastOffset, lastOk := p.offset(last)
+
+	if !firstOk || !lastOk || firstOffset > lastOffset {
+		return offsetRange{}, fmt.Errorf("exclusion %v is not a range of pool %v", exclusion, p.network)
+	}
+
+	return offsetRange{first: firstOffset, last: lastOffset}, nil
+}
+
+// mergeRanges sorts ranges and merges the overlapping and adjacent ones.
+func mergeRanges(ranges []offsetRange) []offsetRange {
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].first < ranges[j].first
+	})
+
+	var merged []offsetRange
+
+	for _, r := range ranges {
+		if n := len(merged); n > 0 && r.first <= merged[n-1].last+1 {
+			merged[n-1].last = max(merged[n-1].last, r.last)
+			continue
+		}
+
+		merged = append(merged, r)
+	}
+
+	return merged
+}
+
+// size returns the number of addresses of the pool that can be allocated.
+func (p *uePool) size() uint64 {
+	size := p.last - p.first + 1
+
+	for _, r := range p.excluded {
+		first, last := max(r.first, p.first), min(r.last, p.last)
+		if first <= last {
+			size -= last - first + 1
+		}
+	}
+
+	return size
+}
+
+// allocate returns a free address of the pool.
+func (p *uePool) allocate() (string, error) {
+	if uint64(len(p.allocated)) >= p.size() {
+		return "", pfcpsim.NewUEPoolExhaustedError(p.name)
+	}
+
+	offset := p.next
+
+	for {
+		if offset < p.first || offset > p.last {
+			offset = p.first
+		}
+
+		if i := slices.IndexFunc(p.excluded, func(r offsetRange) bool {
+			return r.first <= offset && offset <= r.last
+		}); i >= 0 {
+			// skip the whole range, the wrap around is handled on the next iteration
+			offset = p.excluded[i].last + 1
+			continue
+		}
+
+		if _, ok := p.allocated[offset]; !ok {
+			break
+		}
+
+		offset++
+	}
+
+	p.allocated[offset] = struct{}{}
+	p.next = offset + 1
+
+	return p.address(offset), nil
+}
+
+// release makes the address at offset available again.
+func (p *uePool) release(offset uint64) {
+	delete(p.allocated, offset)
+}
+
+// uePoolManager keeps the UE address pools, indexed by name. Pools never overlap, so that the pool
+// an address belongs to is found from the address alone when it is released.
+type uePoolManager struct {
+	lock  sync.Mutex
+	pools map[string]*uePool
+	// sessions are the UE addresses allocated to the active sessions, indexed by session ID
+	sessions map[int][]string
+}
+
+func newUEPoolManager() *uePoolManager {
+	return &uePoolManager{
+		pools:    make(map[string]*uePool),
+		sessions: make(map[int][]string),
+	}
+}
+
+// add adds a pool named name allocating the addresses of network except exclusions.
+func (m *uePoolManager) add(name, network string, exclusions []string) error {
+	if name == "" {
+		return fmt.Errorf("UE pool name cannot be empty")
+	}
+
+	pool, err := newUEPool(name, network, exclusions)
+	if err != nil {
+		return err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.pools[name]; ok {
+		return fmt.Errorf("UE pool %v already exists", name)
+	}
+
+	return m.insert(pool)
+}
+
+// insert adds pool if it does not overlap any other pool. The lock must be held.
+func (m *uePoolManager) insert(pool *uePool) error {
+	for _, other := range m.pools {
+		if other.network.Contains(pool.network.IP) || pool.network.Contains(other.network.IP) {
+			return fmt.Errorf("UE pool %v (%v) overlaps UE pool %v (%v)", pool.name, pool.network, other.name, other.network)
+		}
+	}
+
+	m.pools[pool.name] = pool
+
+	return nil
+}
+
+// remove removes the pool named name, which must have no address allocated.
+func (m *uePoolManager) remove(name string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	pool, ok := m.pools[name]
+	if !ok {
+		return fmt.Errorf("UE pool %v not found", name)
+	}
+
+	if len(pool.allocated) != 0 {
+		return fmt.Errorf("UE pool %v has %v addresses in use", name, len(pool.allocated))
+	}
+
+	delete(m.pools, name)
+
+	return nil
+}
+
+// check returns an error if ref cannot be allocated from, see allocate.
+func (m *uePoolManager) check(ref string, ipv6 bool) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	_, err := m.resolve(ref, ipv6)
+
+	return err
+}
+
+// allocate returns a free address of the pool ref, which is either the name of a pool or a network.
+// A network that is not the network of an existing pool gets its own pool, named after the network.
+func (m *uePoolManager) allocate(ref string, ipv6 bool) (string, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	pool, err := m.resolve(ref, ipv6)
+	if err != nil {
+		return "", err
+	}
+
+	return pool.allocate()
+}
+
+// resolve returns the pool of the given family ref refers to, see allocate. The lock must be held.
+func (m *uePoolManager) resolve(ref string, ipv6 bool) (*uePool, error) {
+	family := "IPv4"
+	if ipv6 {
+		family = "IPv6"
+	}
+
+	pool, ok := m.pools[ref]
+	if !ok {
+		var err error
+
+		if pool, err = m.poolOf(ref); err != nil {
+			return nil, err
+		}
+	}
+
+	if pool.isIPv6() != ipv6 {
+		return nil, pfcpsim.NewInvalidFormatError(fmt.Sprintf("%v UE address pool %v. Please provide an %v pool", family, ref, family))
+	}
+
+	return pool, nil
+}
+
+// poolOf returns the pool of network, creating it if no pool has that network. The lock must be held.
+func (m *uePoolManager) poolOf(network string) (*uePool, error) {
+	pool, err := newUEPool("", network, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, other := range m.pools {
+		if other.network.String() == pool.network.String() {
+			return other, nil
+		}
+	}
+
+	pool.name = pool.network.String()
+	if err := m.insert(pool); err != nil {
+		return nil, err
+	}
+
+	return pool, nil
+}
+
+// assign records addresses as allocated to the session id, so that they are released with releaseSession.
+func (m *uePoolManager) assign(id int, addresses []string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.sessions[id] = addresses
+}
+
+// releaseSession makes the UE addresses assigned to the session id available again.
+func (m *uePoolManager) releaseSession(id int) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.releaseLocked(m.sessions[id])
+	delete(m.sessions, id)
+}
+
+// release makes the given UE addresses available again. Addresses that are not allocated from a pool are ignored.
+func (m *uePoolManager) release(addresses ...string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.releaseLocked(addresses)
+}
+
+// releaseLocked is release with the lock held.
+func (m *uePoolManager) releaseLocked(addresses []string) {
+	for _, address := range addresses {
+		ip, _, err := net.ParseCIDR(address)
+		if err != nil {
+			ip = net.ParseIP(address)
+		}
+
+		if ip == nil {
+			continue
+		}
+
+		for _, pool := range m.pools {
+			if offset, ok := pool.offset(ip); ok {
+				pool.release(offset)
+				break
+			}
+		}
+	}
+}
+
+// usage returns the utilisation of every pool, sorted by name.
+func (m *uePoolManager) usage() []uePoolUsage {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	usages := make([]uePoolUsage, 0, len(m.pools))
+
+	for _, pool := range m.pools {
+		usages = append(usages, uePoolUsage{
+			name:       pool.name,
+			network:    pool.network.String(),
+			exclusions: pool.exclusions,
+			size:       pool.size(),
+			allocated:  uint64(len(pool.allocated)),
+		})
+	}
+
+	sort.Slice(usages, func(i, j int) bool {
+		return usages[i].name < usages[j].name
+	})
+
+	return usages
+}
@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_uePool(t *testing.T) {
+	tests := []struct {
+		name       string
+		network    string
+		ipv6       bool
+		exclusions []string
+		// release is called with the addresses allocated at these indexes before the last allocation
+		release  []int
+		count    int
+		want     []string
+		wantSize uint64
+		wantErr  bool
+	}{
+		{
+			name:     "network and broadcast addresses are not allocated",
+			network:  "10.0.0.0/30",
+			count:    2,
+			want:     []string{"10.0.0.1", "10.0.0.2"},
+			wantSize: 2,
+		},
+		{
+			name:     "every address of a /31 is allocated",
+			network:  "10.0.0.0/31",
+			count:    2,
+			want:     []string{"10.0.0.0", "10.0.0.1"},
+			wantSize: 2,
+		},
+		{
+			name:       "excluded addresses are skipped",
+			network:    "10.0.0.0/28",
+			exclusions: []string{"10.0.0.1-10.0.0.3", "10.0.0.5", "10.0.0.8/30", "10.0.0.2"},
+			count:      4,
+			want:       []string{"10.0.0.4", "10.0.0.6", "10.0.0.7", "10.0.0.12"},
+			wantSize:   6,
+		},
+		{
+			name:     "IPv6 pools delegate /64 prefixes",
+			network:  "2001:db8::/62",
+			ipv6:     true,
+			count:    2,
+			want:     []string{"2001:db8::/64", "2001:db8:0:1::/64"},
+			wantSize: 4,
+		},
+		{
+			name:     "released addresses are reused after wrapping around",
+			network:  "10.0.0.0/29",
+			release:  []int{1},
+			count:    7,
+			want:     []string{"10.0.0.1", "10.0.0.2", "10.0.0.3", "10.0.0.4", "10.0.0.5", "10.0.0.6", "10.0.0.2"},
+			wantSize: 6,
+		},
+		{
+			name:    "exhausted pool",
+			network: "10.0.0.0/30",
+			count:   3,
+			wantErr: true,
+		},
+		{
+			name:       "exclusion outside of the pool",
+			network:    "10.0.0.0/24",
+			exclusions: []string{"10.0.1.1"},
+			wantErr:    true,
+		},
+		{
+			name:    "IPv6 pool smaller than /64",
+			network: "2001:db8::/96",
+			ipv6:    true,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pools := newUEPoolManager()
+
+			err := pools.add("test", tt.network, tt.exclusions)
+			if err == nil {
+				var got []string
+
+				for range tt.count {
+					if len(got) == tt.count-1 {
+						for _, i := range tt.release {
+							pools.release(got[i])
+						}
+					}
+
+					var address string
+
+					address, err = pools.allocate("test", tt.ipv6)
+					if err != nil {
+						break
+					}
+
+					got = append(got, address)
+				}
+
+				if err == nil && !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("allocated addresses mismatch. got = %v, want = %v", got, tt.want)
+				}
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if usage := pools.usage(); usage[0].size != tt.wantSize {
+				t.Errorf("pool size mismatch. got = %v, want = %v", usage[0].size, tt.wantSize)
+			}
+		})
+	}
+}
+
+func Test_uePoolManager(t *testing.T) {
+	pools := newUEPoolManager()
+
+	if err := pools.add("a", "10.0.0.0/24", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := pools.add("b", "10.0.0.128/25", nil); err == nil {
+		t.Error("adding an overlapping pool should fail")
+	}
+
+	if err := pools.add("a", "10.1.0.0/24", nil); err == nil {
+		t.Error("adding a pool with an existing name should fail")
+	}
+
+	// the network of a named pool refers to the named pool
+	address, err := pools.allocate("10.0.0.0/24", false)
+	if err != nil || address != "10.0.0.1" {
+		t.Errorf("allocate() = %v, %v, want 10.0.0.1", address, err)
+	}
+
+	if err := pools.remove("a"); err == nil {
+		t.Error("removing a pool with addresses in use should fail")
+	}
+
+	pools.release(address)
+
+	if err := pools.remove("a"); err != nil {
+		t.Errorf("remove() error = %v", err)
+	}
+
+	if usage := pools.usage(); len(usage) != 0 {
+		t.Errorf("pools mismatch. got = %v, want none", usage)
+	}
+}
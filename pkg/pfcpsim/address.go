@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/omec-project/pfcpsim/pkg/pfcpsim/session"
+	ieLib "github.com/wmnsk/go-pfcp/ie"
+)
+
+// withDefaultPort returns addr in host:port format, adding port if addr does not contain one.
+// IPv6 literals are accepted both bare (2001:db8::1) and bracketed ([2001:db8::1] or [2001:db8::1]:8805).
+func withDefaultPort(addr string, port int) string {
+	if host, p, err := net.SplitHostPort(addr); err == nil {
+		return net.JoinHostPort(host, p)
+	}
+
+	return net.JoinHostPort(strings.Trim(addr, "[]"), strconv.Itoa(port))
+}
+
+// hostOnly returns the host part of addr, dropping the port and the brackets of IPv6 literals.
+func hostOnly(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+
+	return strings.Trim(addr, "[]")
+}
+
+// splitByFamily parses addr and returns it either as IPv4 or IPv6 address, leaving the other nil.
+func splitByFamily(addr string) (net.IP, net.IP) {
+	ip := net.ParseIP(hostOnly(addr))
+	if ip == nil {
+		return nil, nil
+	}
+
+	if v4 := ip.To4(); v4 != nil {
+		return v4, nil
+	}
+
+	return nil, ip
+}
+
+// newNodeID returns a Node ID IE of type IPv4, IPv6 or FQDN depending on addr.
+func newNodeID(addr string) *ieLib.IE {
+	return ieLib.NewNodeIDHeuristic(hostOnly(addr))
+}
+
+// newFSEID returns a F-SEID IE with the V4 or V6 flag set depending on addr.
+func newFSEID(seid uint64, addr string) *ieLib.IE {
+	v4, v6 := splitByFamily(addr)
+	return ieLib.NewFSEID(seid, v4, v6)
+}
+
+// newSourceIPAddress returns a Source IP Address IE with the V4 or V6 flag set depending on addr.
+func newSourceIPAddress(addr string) *ieLib.IE {
+	v4, v6 := splitByFamily(addr)
+	return ieLib.NewSourceIPAddress(v4, v6, 0)
+}
+
+// pdnTypeFromPDRs returns the PDN Type matching the UE IP Address IEs found in pdrs.
+// IPv4 is returned when no UE address is found.
+func pdnTypeFromPDRs(pdrs []*ieLib.IE) uint8 {
+	var hasIPv4, hasIPv6 bool
+
+	for _, pdr := range pdrs {
+		ueIP, err := pdr.UEIPAddress()
+		if err != nil {
+			continue
+		}
+
+		hasIPv4 = hasIPv4 || ueIP.Flags&session.UEIPAddressV4 != 0
+		hasIPv6 = hasIPv6 || ueIP.Flags&session.UEIPAddressV6 != 0
+	}
+
+	switch {
+	case hasIPv4 && hasIPv6:
+		return ieLib.PDNTypeIPv4v6
+	case hasIPv6:
+		return ieLib.PDNTypeIPv6
+	default:
+		return ieLib.PDNTypeIPv4
+	}
+}
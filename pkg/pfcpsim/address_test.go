@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"testing"
+
+	ieLib "github.com/wmnsk/go-pfcp/ie"
+)
+
+func TestWithDefaultPort(t *testing.T) {
+	for _, scenario := range []struct {
+		input    string
+		expected string
+	}{
+		{input: "10.0.0.1", expected: "10.0.0.1:8805"},
+		{input: "10.0.0.1:8888", expected: "10.0.0.1:8888"},
+		{input: "2001:db8::1", expected: "[2001:db8::1]:8805"},
+		{input: "[2001:db8::1]", expected: "[2001:db8::1]:8805"},
+		{input: "[2001:db8::1]:8888", expected: "[2001:db8::1]:8888"},
+		{input: "upf.example.com", expected: "upf.example.com:8805"},
+	} {
+		t.Run(scenario.input, func(t *testing.T) {
+			if got := withDefaultPort(scenario.input, PFCPStandardPort); got != scenario.expected {
+				t.Errorf("address mismatch. got = %v, want = %v", got, scenario.expected)
+			}
+		})
+	}
+}
+
+func TestNewFSEID(t *testing.T) {
+	for _, scenario := range []struct {
+		input  string
+		isIPv4 bool
+		isIPv6 bool
+	}{
+		{input: "10.0.0.1", isIPv4: true},
+		{input: "2001:db8::1", isIPv6: true},
+	} {
+		t.Run(scenario.input, func(t *testing.T) {
+			fseid, err := newFSEID(1, scenario.input).FSEID()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if fseid.HasIPv4() != scenario.isIPv4 || fseid.HasIPv6() != scenario.isIPv6 {
+				t.Errorf("F-SEID flags mismatch. got = %#x", fseid.Flags)
+			}
+		})
+	}
+}
+
+func TestPDNTypeFromPDRs(t *testing.T) {
+	newPDR := func(flags uint8, v4, v6 string) *ieLib.IE {
+		return ieLib.NewCreatePDR(
+			ieLib.NewPDRID(1),
+			ieLib.NewPDI(
+				ieLib.NewSourceInterface(ieLib.SrcInterfaceCore),
+				ieLib.NewUEIPAddress(flags, v4, v6, 0, 0),
+			),
+		)
+	}
+
+	uplink := ieLib.NewCreatePDR(
+		ieLib.NewPDRID(2),
+		ieLib.NewPDI(ieLib.NewSourceInterface(ieLib.SrcInterfaceAccess)),
+	)
+
+	for _, scenario := range []struct {
+		description string
+		pdrs        []*ieLib.IE
+		expected    uint8
+	}{
+		{description: "No UE address", pdrs: []*ieLib.IE{uplink}, expected: ieLib.PDNTypeIPv4},
+		{description: "IPv4", pdrs: []*ieLib.IE{uplink, newPDR(0x2, "10.0.0.1", "")}, expected: ieLib.PDNTypeIPv4},
+		{description: "IPv6", pdrs: []*ieLib.IE{uplink, newPDR(0x1, "", "2001:db8::")}, expected: ieLib.PDNTypeIPv6},
+		{
+			description: "Dual-stack",
+			pdrs:        []*ieLib.IE{uplink, newPDR(0x3, "10.0.0.1", "2001:db8::")},
+			expected:    ieLib.PDNTypeIPv4v6,
+		},
+		{
+			description: "Dual-stack chosen by the UPF",
+			pdrs:        []*ieLib.IE{uplink, newPDR(0x33, "", "")},
+			expected:    ieLib.PDNTypeIPv4v6,
+		},
+	} {
+		t.Run(scenario.description, func(t *testing.T) {
+			if got := pdnTypeFromPDRs(scenario.pdrs); got != scenario.expected {
+				t.Errorf("PDN type mismatch. got = %v, want = %v", got, scenario.expected)
+			}
+		})
+	}
+}
+
+func TestNewNodeID(t *testing.T) {
+	for _, scenario := range []struct {
+		input    string
+		expected string
+	}{
+		{input: "10.0.0.1", expected: "10.0.0.1"},
+		{input: "10.0.0.1:8805", expected: "10.0.0.1"},
+		{input: "[2001:db8::1]:8805", expected: "2001:db8::1"},
+		{input: "2001:db8::1", expected: "2001:db8::1"},
+	} {
+		t.Run(scenario.input, func(t *testing.T) {
+			nodeID, err := newNodeID(scenario.input).NodeID()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if nodeID != scenario.expected {
+				t.Errorf("Node ID mismatch. got = %v, want = %v", nodeID, scenario.expected)
+			}
+		})
+	}
+}
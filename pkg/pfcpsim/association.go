@@ -0,0 +1,298 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/omec-project/pfcpsim/logger"
+	ieLib "github.com/wmnsk/go-pfcp/ie"
+	"github.com/wmnsk/go-pfcp/message"
+)
+
+// cpFunctionFeatures maps the CP function features to their octet and bit in the CP Function Features IE.
+// Refer to section 8.2.58 in PFCP specs Release 16
+var cpFunctionFeatures = map[string]struct {
+	octet int
+	bit   uint8
+}{
+	"LOAD":  {0, 0x01},
+	"OVRL":  {0, 0x02},
+	"EPFAR": {0, 0x04},
+	"SSET":  {0, 0x08},
+	"BUNDL": {0, 0x10},
+	"MPAS":  {0, 0x20},
+	"ARDR":  {0, 0x40},
+	"UIAUR": {0, 0x80},
+	"PSUCC": {1, 0x01},
+	"RPGUR": {1, 0x02},
+}
+
+// AssociationState is the state of the association, as negotiated with the peer by the Association Setup
+// and Association Update procedures.
+type AssociationState struct {
+	// PeerNodeID is the Node ID of the peer.
+	PeerNodeID string
+	// UPFunctionFeatures are the octets of the last UP Function Features IE received from the peer.
+	UPFunctionFeatures UPFunctionFeatures
+	// UPIPResources are the User Plane IP Resource Information IEs received from the peer.
+	UPIPResources []UPIPResource
+	// AlternativeSMFIPs are the addresses of the Alternative SMF IP Address IEs received from the peer.
+	AlternativeSMFIPs []string
+	// CPFunctionFeatures are the octets of the last CP Function Features IE sent to the peer.
+	CPFunctionFeatures []byte
+	// ReleaseRequested is set when the peer asked to release the association with an Association Update Request.
+	ReleaseRequested bool
+	// GracefulReleasePeriod is the time the peer grants to release the association, if it requested so.
+	GracefulReleasePeriod time.Duration
+	// UpdatedAt is the time of the last Association Setup or Update.
+	UpdatedAt time.Time
+}
+
+// ParseCPFunctionFeatures returns the octets of the CP Function Features IE advertising the features
+// named in names (e.g. LOAD, OVRL, BUNDL). Names are case-insensitive.
+func ParseCPFunctionFeatures(names []string) ([]uint8, error) {
+	octets := make([]uint8, 1)
+
+	for _, name := range names {
+		feature, ok := cpFunctionFeatures[strings.ToUpper(name)]
+		if !ok {
+			return nil, NewInvalidFormatError(fmt.Sprintf("unknown CP function feature %q", name))
+		}
+
+		for len(octets) <= feature.octet {
+			octets = append(octets, 0)
+		}
+
+		octets[feature.octet] |= feature.bit
+	}
+
+	return octets, nil
+}
+
+// AssociationState returns the state of the current association.
+func (c *PFCPClient) AssociationState() AssociationState {
+	c.associationLock.Lock()
+	defer c.associationLock.Unlock()
+
+	state := c.association
+	state.UPFunctionFeatures = slices.Clone(state.UPFunctionFeatures)
+	state.CPFunctionFeatures = slices.Clone(state.CPFunctionFeatures)
+	state.UPIPResources = slices.Clone(state.UPIPResources)
+	state.AlternativeSMFIPs = slices.Clone(state.AlternativeSMFIPs)
+
+	return state
+}
+
+// resetAssociationState records the state negotiated by an Association Setup procedure, with the IEs
+// received from the peer (Node ID, UP Function Features, User Plane IP Resource Information, etc.).
+// The CP Function Features are the ones advertised by the client.
+func (c *PFCPClient) resetAssociationState(ies ...*ieLib.IE) {
+	c.associationLock.Lock()
+	c.association = AssociationState{CPFunctionFeatures: slices.Clone(c.cpFeatures)}
+	c.associationLock.Unlock()
+
+	c.updateAssociationState(ies...)
+
+	state := c.AssociationState()
+	logger.PfcpsimLog.Infof("association with %v: %v", state.PeerNodeID,
+		describeUPFunctionFeatures(state.UPFunctionFeatures))
+}
+
+// updateAssociationState records the changes carried by the IEs of an Association Update procedure.
+// User Plane IP Resource Information and Alternative SMF IP Address IEs replace the ones previously received.
+func (c *PFCPClient) updateAssociationState(ies ...*ieLib.IE) {
+	c.associationLock.Lock()
+	defer c.associationLock.Unlock()
+
+	var resources []UPIPResource
+
+	var alternativeSMFIPs []string
+
+	for _, ie := range ies {
+		if ie == nil {
+			continue
+		}
+
+		switch ie.Type {
+		case ieLib.NodeID:
+			if nodeID, err := ie.NodeID(); err == nil {
+				c.association.PeerNodeID = nodeID
+			}
+		case ieLib.UPFunctionFeatures:
+			if features, err := ie.UPFunctionFeatures(); err == nil {
+				c.association.UPFunctionFeatures = slices.Clone(features)
+			}
+		case ieLib.UserPlaneIPResourceInformation:
+			resource, err := parseUPIPResource(ie)
+			if err != nil {
+				logger.PfcpsimLog.Warnf("could not parse User Plane IP Resource Information: %v", err)
+				continue
+			}
+
+			resources = append(resources, resource)
+		case ieLib.AlternativeSMFIPAddress:
+			addresses, err := parseAlternativeSMFIPs(ie)
+			if err != nil {
+				logger.PfcpsimLog.Warnf("could not parse Alternative SMF IP Address: %v", err)
+				continue
+			}
+
+			alternativeSMFIPs = append(alternativeSMFIPs, addresses...)
+		case ieLib.CPFunctionFeatures:
+			if features, err := ie.CPFunctionFeatures(); err == nil {
+				c.association.CPFunctionFeatures = slices.Clone(features)
+			}
+		case ieLib.PFCPAssociationReleaseRequest:
+			c.association.ReleaseRequested = ie.HasSARR()
+		case ieLib.GracefulReleasePeriod:
+			if period, err := ie.GracefulReleasePeriod(); err == nil {
+				c.association.GracefulReleasePeriod = period
+			}
+		}
+	}
+
+	if resources != nil {
+		c.association.UPIPResources = resources
+	}
+
+	if alternativeSMFIPs != nil {
+		c.association.AlternativeSMFIPs = alternativeSMFIPs
+	}
+
+	c.association.UpdatedAt = time.Now()
+}
+
+// newAssociationUpdateRequest returns an Association Update Request carrying the Node ID of the sender.
+func (c *PFCPClient) newAssociationUpdateRequest(ie ...*ieLib.IE) *message.AssociationUpdateRequest {
+	updateReq := message.NewAssociationUpdateRequest(c.getNextSequenceNumber(),
+		newNodeID(c.localAddr),
+	)
+
+	updateReq.IEs = append(updateReq.IEs, ie...)
+
+	return updateReq
+}
+
+// UpdateAssociation sends an Association Update Request carrying ies (e.g. CP Function Features) and waits
+// for the Association Update Response. The association state is updated once the peer accepted the request.
+func (c *PFCPClient) UpdateAssociation(ies ...*ieLib.IE) error {
+	if !c.IsAssociationAlive() {
+		return NewAssociationInactiveError()
+	}
+
+	resp, err := c.transact(c.newAssociationUpdateRequest(ies...), 0)
+	if err != nil {
+		return err
+	}
+
+	updateResp, ok := resp.(*message.AssociationUpdateResponse)
+	if !ok {
+		return NewInvalidResponseError(errWrongRspType)
+	}
+
+	if updateResp.Cause == nil {
+		return NewInvalidResponseError(errMissingCause)
+	}
+
+	cause, err := updateResp.Cause.Cause()
+	if err != nil {
+		return NewInvalidResponseError(err)
+	}
+
+	if cause != ieLib.CauseRequestAccepted {
+		return newRejectedError(cause)
+	}
+
+	// the CP Function Features sent are advertised again if the association is set up anew
+	for _, ie := range ies {
+		if ie.Type != ieLib.CPFunctionFeatures {
+			continue
+		}
+
+		if features, err := ie.CPFunctionFeatures(); err == nil {
+			c.SetCPFunctionFeatures(features)
+		}
+	}
+
+	// the Node ID of the association state is the one of the peer
+	sent := slices.DeleteFunc(slices.Clone(ies), func(ie *ieLib.IE) bool { return ie.Type == ieLib.NodeID })
+	c.updateAssociationState(append(sent, updateResp.NodeID, updateResp.UPFunctionFeatures)...)
+
+	return nil
+}
+
+// handleAssociationUpdateRequest applies an Association Update Request sent by the peer from raddr, e.g. with new
+// UP Function Features or asking to release the association within a Graceful Release Period.
+func (c *PFCPClient) handleAssociationUpdateRequest(msg *message.AssociationUpdateRequest, raddr net.Addr) {
+	cause := uint8(ieLib.CauseRequestAccepted)
+
+	switch {
+	case !c.IsAssociationAlive():
+		cause = ieLib.CauseNoEstablishedPFCPAssociation
+	case msg.NodeID == nil:
+		cause = ieLib.CauseMandatoryIEMissing
+	}
+
+	res := message.NewAssociationUpdateResponse(msg.Sequence(),
+		newNodeID(c.localAddr),
+		ieLib.NewCause(cause),
+	)
+
+	if err := c.sendMsgTo(res, raddr); err != nil {
+		logger.PfcpsimLog.Errorln("Error sending Association Update Response:", err)
+	}
+
+	if cause != ieLib.CauseRequestAccepted {
+		logger.PfcpsimLog.Warnf("rejected Association Update Request with sequence number %v, cause %v",
+			msg.Sequence(), cause)
+
+		return
+	}
+
+	c.updateAssociationState(append(append([]*ieLib.IE{msg.NodeID, msg.UPFunctionFeatures,
+		msg.PFCPAssociationReleaseRequest, msg.GracefulReleasePeriod}, msg.AlternativeSMFIPAddress...),
+		upIPResourceIEs(msg.IEs)...)...)
+
+	details := describeAssociationUpdate(msg)
+	logger.PfcpsimLog.Infoln(details)
+	c.emitEvent(EventAssociationUpdated, msg, details)
+}
+
+// upIPResourceIEs returns the User Plane IP Resource Information IEs of ies. go-pfcp does not decode them
+// in Association Update Requests, they are left with the unknown IEs.
+func upIPResourceIEs(ies []*ieLib.IE) []*ieLib.IE {
+	return slices.DeleteFunc(slices.Clone(ies), func(ie *ieLib.IE) bool {
+		return ie == nil || ie.Type != ieLib.UserPlaneIPResourceInformation
+	})
+}
+
+// describeAssociationUpdate summarizes the changes requested by an Association Update Request.
+func describeAssociationUpdate(msg *message.AssociationUpdateRequest) string {
+	details := fmt.Sprintf("Association Update Request with sequence number %v received", msg.Sequence())
+
+	if msg.UPFunctionFeatures != nil {
+		details += fmt.Sprintf(", UP function features %#x", msg.UPFunctionFeatures.Payload)
+	}
+
+	if resources := upIPResourceIEs(msg.IEs); len(resources) != 0 {
+		details += fmt.Sprintf(", %v User Plane IP Resource Information", len(resources))
+	}
+
+	if msg.PFCPAssociationReleaseRequest != nil && msg.PFCPAssociationReleaseRequest.HasSARR() {
+		details += ", release requested"
+
+		if msg.GracefulReleasePeriod != nil {
+			if period, err := msg.GracefulReleasePeriod.GracefulReleasePeriod(); err == nil {
+				details += fmt.Sprintf(" within %v", period)
+			}
+		}
+	}
+
+	return details
+}
@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCPFunctionFeatures(t *testing.T) {
+	tests := []struct {
+		name    string
+		names   []string
+		want    []uint8
+		wantErr bool
+	}{
+		{
+			name:  "no feature",
+			names: nil,
+			want:  []uint8{0},
+		},
+		{
+			name:  "first octet",
+			names: []string{"LOAD", "OVRL", "UIAUR"},
+			want:  []uint8{0x83},
+		},
+		{
+			name:  "second octet, case-insensitive",
+			names: []string{"bundl", "Rpgur"},
+			want:  []uint8{0x10, 0x02},
+		},
+		{
+			name:    "unknown feature",
+			names:   []string{"LOAD", "FTUP"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCPFunctionFeatures(tt.names)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCPFunctionFeatures() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCPFunctionFeatures() = %#x, want %#x", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,939 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/omec-project/pfcpsim/pkg/pfcpsim/mockupf"
+	"github.com/omec-project/pfcpsim/pkg/pfcpsim/session"
+	ieLib "github.com/wmnsk/go-pfcp/ie"
+	"github.com/wmnsk/go-pfcp/message"
+)
+
+// startMockUPF starts a mock UPF on a free loopback port and returns a client connected to it.
+func startMockUPF(t *testing.T) (*mockupf.MockUPF, *PFCPClient) {
+	t.Helper()
+
+	upf := mockupf.NewMockUPF("127.0.0.1:0")
+	if err := upf.Start(); err != nil {
+		t.Fatalf("could not start mock UPF: %v", err)
+	}
+
+	t.Cleanup(upf.Stop)
+
+	client := NewPFCPClient("127.0.0.1:0")
+	client.SetPFCPResponseTimeout(500 * time.Millisecond)
+
+	if err := client.ConnectN4(upf.Addr()); err != nil {
+		t.Fatalf("could not connect to mock UPF: %v", err)
+	}
+
+	t.Cleanup(client.DisconnectN4)
+
+	return upf, client
+}
+
+// waitForEvent returns the first event of type eventType raised by client, skipping the other events.
+func waitForEvent(t *testing.T, client *PFCPClient, eventType EventType) Event {
+	t.Helper()
+
+	timeout := time.After(2 * time.Second)
+
+	for {
+		select {
+		case event := <-client.Events():
+			if event.Type == eventType {
+				return event
+			}
+		case <-timeout:
+			t.Fatalf("timed out waiting for event %v", eventType)
+		}
+	}
+}
+
+// waitForReceived returns the number of messages of type msgType received by upf, waiting up to a second for
+// the first one. Responses are sent before the events are raised, but they may not be received yet.
+func waitForReceived(upf *mockupf.MockUPF, msgType uint8) int {
+	deadline := time.Now().Add(time.Second)
+	for upf.Received(msgType) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	return upf.Received(msgType)
+}
+
+// newTestRules returns the rules of a session with one uplink and one downlink PDR.
+func newTestRules(ueAddress string) ([]*ieLib.IE, []*ieLib.IE, []*ieLib.IE) {
+	pdrs := []*ieLib.IE{
+		session.NewPDRBuilder().
+			WithID(1).
+			WithMethod(session.Create).
+			WithTEID(100).
+			WithFARID(1).
+			AddQERID(1).
+			WithN3Address("198.18.0.1").
+			MarkAsUplink().
+			BuildPDR(),
+		session.NewPDRBuilder().
+			WithID(2).
+			WithMethod(session.Create).
+			WithUEAddress(ueAddress).
+			WithFARID(2).
+			AddQERID(1).
+			MarkAsDownlink().
+			BuildPDR(),
+	}
+
+	fars := []*ieLib.IE{
+		session.NewFARBuilder().
+			WithID(1).
+			WithMethod(session.Create).
+			WithAction(session.ActionForward).
+			WithDstInterface(ieLib.DstInterfaceCore).
+			BuildFAR(),
+		session.NewFARBuilder().
+			WithID(2).
+			WithMethod(session.Create).
+			WithAction(session.ActionDrop).
+			WithDstInterface(ieLib.DstInterfaceAccess).
+			WithZeroBasedOuterHeaderCreation().
+			BuildFAR(),
+	}
+
+	qers := []*ieLib.IE{
+		session.NewQERBuilder().
+			WithID(1).
+			WithMethod(session.Create).
+			WithUplinkMBR(60000).
+			WithDownlinkMBR(60000).
+			Build(),
+	}
+
+	return pdrs, fars, qers
+}
+
+func TestSessionLifecycleWithMockUPF(t *testing.T) {
+	upf, client := startMockUPF(t)
+
+	if err := client.SetupAssociation(); err != nil {
+		t.Fatalf("could not set up association: %v", err)
+	}
+
+	if !upf.IsAssociated() {
+		t.Fatal("mock UPF should be associated")
+	}
+
+	if !client.PeerRecoveryTimeStamp().Equal(upf.RecoveryTimeStamp().Truncate(time.Second)) {
+		t.Errorf("peer Recovery Time Stamp mismatch. got = %v, want = %v",
+			client.PeerRecoveryTimeStamp(), upf.RecoveryTimeStamp())
+	}
+
+	pdrs, fars, qers := newTestRules("17.0.0.1")
+
+	urrs := []*ieLib.IE{
+		session.NewURRBuilder().
+			WithID(1).
+			WithMethod(session.Create).
+			WithMeasurementMethod(0, 1, 0).
+			WithReportingTrigger(session.ReportingTrigger{Flags: session.RPT_TRIG_PERIO}).
+			WithMeasurementPeriod(time.Second).
+			Build(),
+	}
+
+	sess, err := client.EstablishSession(pdrs, fars, qers, urrs)
+	if err != nil {
+		t.Fatalf("could not establish session: %v", err)
+	}
+
+	upfSess, ok := upf.Session(sess.PeerSEID())
+	if !ok {
+		t.Fatalf("session with SEID %v not found in mock UPF", sess.PeerSEID())
+	}
+
+	if upfSess.CPSEID != sess.LocalSEID() {
+		t.Errorf("CP SEID mismatch. got = %v, want = %v", upfSess.CPSEID, sess.LocalSEID())
+	}
+
+	if len(upfSess.PDRs) != 2 || len(upfSess.FARs) != 2 || len(upfSess.QERs) != 1 {
+		t.Errorf("rules mismatch. got %v PDRs, %v FARs, %v QERs", len(upfSess.PDRs), len(upfSess.FARs), len(upfSess.QERs))
+	}
+
+	updateFAR := session.NewFARBuilder().
+		WithID(2).
+		WithMethod(session.Update).
+		WithAction(session.ActionForward).
+		WithDstInterface(ieLib.DstInterfaceAccess).
+		WithTEID(200).
+		WithDownlinkIP("198.18.0.10").
+		BuildFAR()
+
+	if err := client.ModifySession(sess, nil, []*ieLib.IE{updateFAR}, nil, nil); err != nil {
+		t.Fatalf("could not modify session: %v", err)
+	}
+
+	upfSess, _ = upf.Session(sess.PeerSEID())
+
+	params, err := upfSess.FARs[2].ForwardingParameters()
+	if err != nil {
+		t.Fatalf("modified FAR has no Forwarding Parameters: %v", err)
+	}
+
+	ohc, err := ieLib.NewGroupedIE(ieLib.ForwardingParameters, params...).OuterHeaderCreation()
+	if err != nil {
+		t.Fatalf("modified FAR has no Outer Header Creation: %v", err)
+	}
+
+	if ohc.TEID != 200 || ohc.IPv4Address.String() != "198.18.0.10" {
+		t.Errorf("Outer Header Creation mismatch. got TEID = %v, address = %v", ohc.TEID, ohc.IPv4Address)
+	}
+
+	unknownFAR := session.NewFARBuilder().
+		WithID(10).
+		WithMethod(session.Update).
+		WithAction(session.ActionForward).
+		WithDstInterface(ieLib.DstInterfaceAccess).
+		WithTEID(200).
+		WithDownlinkIP("198.18.0.10").
+		BuildFAR()
+
+	if err := client.ModifySession(sess, nil, []*ieLib.IE{unknownFAR}, nil, nil); err == nil {
+		t.Error("updating an unknown FAR should fail")
+	}
+
+	if err := client.QueryURRs(sess); err != nil {
+		t.Fatalf("could not query URRs: %v", err)
+	}
+
+	reports := sess.UsageReports()
+	if len(reports) != 1 || reports[0].URRID != 1 || !reflect.DeepEqual(reports[0].Triggers, []string{"IMMER"}) {
+		t.Errorf("usage reports mismatch. got = %+v", reports)
+	}
+
+	if err := client.QueryURRs(sess, 10); err == nil {
+		t.Error("querying an unknown URR should fail")
+	}
+
+	if err := client.DeleteSession(sess); err != nil {
+		t.Fatalf("could not delete session: %v", err)
+	}
+
+	if upf.SessionsNum() != 0 {
+		t.Errorf("mock UPF should have no sessions, got %v", upf.SessionsNum())
+	}
+
+	if err := client.TeardownAssociation(); err != nil {
+		t.Fatalf("could not tear down association: %v", err)
+	}
+
+	if upf.IsAssociated() {
+		t.Error("mock UPF should not be associated")
+	}
+}
+
+func TestEstablishSessionWithUPFAllocationOnMockUPF(t *testing.T) {
+	upf, client := startMockUPF(t)
+
+	if err := client.SetupAssociation(); err != nil {
+		t.Fatalf("could not set up association: %v", err)
+	}
+
+	_, fars, qers := newTestRules("17.0.0.1")
+
+	// the uplink F-TEID and the dual-stack UE addresses are chosen by the UPF
+	pdrs := []*ieLib.IE{
+		session.NewPDRBuilder().
+			WithID(1).
+			WithMethod(session.Create).
+			WithFTEIDChooseID(1).
+			WithFARID(1).
+			AddQERID(1).
+			MarkAsUplink().
+			BuildPDR(),
+		session.NewPDRBuilder().
+			WithID(2).
+			WithMethod(session.Create).
+			WithUEAddressChoose().
+			WithUEIPv6PrefixChoose().
+			WithFARID(2).
+			AddQERID(1).
+			MarkAsDownlink().
+			BuildPDR(),
+	}
+
+	sess, err := client.EstablishSession(pdrs, fars, qers, nil)
+	if err != nil {
+		t.Fatalf("could not establish session: %v", err)
+	}
+
+	upfSess, ok := upf.Session(sess.PeerSEID())
+	if !ok {
+		t.Fatalf("session with SEID %v not found in mock UPF", sess.PeerSEID())
+	}
+
+	if got, want := sess.UplinkTEID(), upfSess.TEIDs[1]; got == 0 || got != want {
+		t.Errorf("uplink TEID mismatch. got = %v, want = %v", got, want)
+	}
+
+	want := []string{upfSess.UEAddress.String(), upfSess.UEIPv6Prefix.String()}
+	if got := sess.UEAddresses(); !reflect.DeepEqual(got, want) {
+		t.Errorf("UE addresses mismatch. got = %v, want = %v", got, want)
+	}
+}
+
+func TestFunctionFeaturesWithMockUPF(t *testing.T) {
+	upf, client := startMockUPF(t)
+	// FTUP only, the UE addresses cannot be allocated by the UPF
+	upf.SetUPFunctionFeatures(0x10)
+	client.SetCPFunctionFeatures([]uint8{0x01})
+
+	if err := client.SetupAssociation(); err != nil {
+		t.Fatalf("could not set up association: %v", err)
+	}
+
+	state := client.AssociationState()
+	if !reflect.DeepEqual(state.UPFunctionFeatures.Names(), []string{"FTUP"}) ||
+		!reflect.DeepEqual(state.CPFunctionFeatures, []byte{0x01}) {
+		t.Errorf("association state mismatch. got = %+v", state)
+	}
+
+	if got := upf.CPFunctionFeatures(); !reflect.DeepEqual(got, []uint8{0x01}) {
+		t.Errorf("CP function features received by the mock UPF mismatch. got = %#x", got)
+	}
+
+	_, fars, qers := newTestRules("17.0.0.1")
+
+	uplink := session.NewPDRBuilder().
+		WithID(1).
+		WithMethod(session.Create).
+		WithFTEIDChoose().
+		WithFARID(1).
+		AddQERID(1).
+		MarkAsUplink().
+		BuildPDR()
+	downlink := session.NewPDRBuilder().
+		WithID(2).
+		WithMethod(session.Create).
+		WithUEAddressChoose().
+		WithFARID(2).
+		AddQERID(1).
+		MarkAsDownlink().
+		BuildPDR()
+
+	if _, err := client.EstablishSession([]*ieLib.IE{uplink, downlink}, fars, qers, nil); err == nil {
+		t.Error("a session relying on the UEIP feature should be refused")
+	}
+
+	if upf.Received(message.MsgTypeSessionEstablishmentRequest) != 0 {
+		t.Error("no Session Establishment Request should be sent for a refused session")
+	}
+
+	downlink = session.NewPDRBuilder().
+		WithID(2).
+		WithMethod(session.Create).
+		WithUEAddress("17.0.0.1").
+		WithFARID(2).
+		AddQERID(1).
+		MarkAsDownlink().
+		BuildPDR()
+
+	if _, err := client.EstablishSession([]*ieLib.IE{uplink, downlink}, fars, qers, nil); err != nil {
+		t.Errorf("a session relying on the FTUP feature should be established: %v", err)
+	}
+
+	if err := upf.UpdateAssociation(ieLib.NewAlternativeSMFIPAddress(net.ParseIP("198.18.0.2"), nil)); err != nil {
+		t.Fatalf("mock UPF could not update association: %v", err)
+	}
+
+	waitForEvent(t, client, EventAssociationUpdated)
+
+	if got := client.AssociationState().AlternativeSMFIPs; !reflect.DeepEqual(got, []string{"198.18.0.2"}) {
+		t.Errorf("alternative SMF IPs mismatch. got = %v", got)
+	}
+}
+
+func TestEstablishSessionWithoutAssociationOnMockUPF(t *testing.T) {
+	upf, client := startMockUPF(t)
+
+	pdrs, fars, qers := newTestRules("17.0.0.1")
+
+	// bypass the association check of EstablishSession, to verify the mock UPF rejects the request
+	client.setAssociationStatus(true, "test")
+
+	if _, err := client.EstablishSession(pdrs, fars, qers, nil); err == nil {
+		t.Error("session establishment without association should fail")
+	}
+
+	if upf.SessionsNum() != 0 {
+		t.Errorf("mock UPF should have no sessions, got %v", upf.SessionsNum())
+	}
+}
+
+func TestEstablishSessionWithFaultyMockUPF(t *testing.T) {
+	tests := []struct {
+		name  string
+		fault mockupf.Fault
+		// wantErr is the message of the expected error, empty if the establishment succeeds
+		wantErr string
+		// wantCause is the PFCP Cause carried by the expected error, 0 if none
+		wantCause       uint8
+		wantSessions    int
+		wantRetransmits uint64
+	}{
+		{
+			name:         "rejected with cause",
+			fault:        mockupf.Fault{Cause: ieLib.CauseNoResourcesAvailable},
+			wantErr:      NewInvalidCauseError().message,
+			wantCause:    ieLib.CauseNoResourcesAvailable,
+			wantSessions: 0,
+		},
+		{
+			name:            "every response dropped",
+			fault:           mockupf.Fault{Drop: true},
+			wantErr:         NewTimeoutExpiredError().message,
+			wantRetransmits: 1,
+		},
+		{
+			name:            "first response dropped",
+			fault:           mockupf.Fault{Nth: 1, Drop: true},
+			wantSessions:    1,
+			wantRetransmits: 1,
+		},
+		{
+			// a response of another type does not match the request, each retransmission creates a session
+			name:            "wrong response type",
+			fault:           mockupf.Fault{WrongType: true},
+			wantErr:         NewTimeoutExpiredError().message,
+			wantSessions:    2,
+			wantRetransmits: 1,
+		},
+		{
+			name:         "duplicate response",
+			fault:        mockupf.Fault{Duplicate: true},
+			wantSessions: 1,
+		},
+		{
+			name:         "delayed response",
+			fault:        mockupf.Fault{Delay: 100 * time.Millisecond, Jitter: 50 * time.Millisecond},
+			wantSessions: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upf, client := startMockUPF(t)
+			client.SetMaxRetransmissions(1)
+
+			if err := client.SetupAssociation(); err != nil {
+				t.Fatalf("could not set up association: %v", err)
+			}
+
+			tt.fault.MessageType = message.MsgTypeSessionEstablishmentRequest
+			upf.InjectFault(tt.fault)
+
+			pdrs, fars, qers := newTestRules("17.0.0.1")
+
+			_, err := client.EstablishSession(pdrs, fars, qers, nil)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if tt.wantErr != "" {
+				var simErr *pfcpSimError
+				if !errors.As(err, &simErr) || simErr.message != tt.wantErr {
+					t.Errorf("error mismatch. got = %v, want = %v", err, tt.wantErr)
+				}
+			}
+
+			if cause, _ := Cause(err); cause != tt.wantCause {
+				t.Errorf("cause mismatch. got = %v, want = %v", cause, tt.wantCause)
+			}
+
+			if IsTimeout(err) != (tt.wantErr == NewTimeoutExpiredError().message) {
+				t.Errorf("IsTimeout mismatch for error %v", err)
+			}
+
+			if upf.SessionsNum() != tt.wantSessions {
+				t.Errorf("mock UPF sessions mismatch. got = %v, want = %v", upf.SessionsNum(), tt.wantSessions)
+			}
+
+			if got := client.Stats().Retransmissions; got != tt.wantRetransmits {
+				t.Errorf("retransmissions mismatch. got = %v, want = %v", got, tt.wantRetransmits)
+			}
+		})
+	}
+}
+
+func TestPeerRestartWithMockUPF(t *testing.T) {
+	upf, client := startMockUPF(t)
+
+	if err := client.SetupAssociation(); err != nil {
+		t.Fatalf("could not set up association: %v", err)
+	}
+
+	before := client.PeerRecoveryTimeStamp()
+
+	upf.Restart()
+
+	if upf.IsAssociated() {
+		t.Error("mock UPF should lose the association on restart")
+	}
+
+	if err := client.SendAndRecvHeartbeat(); err != nil {
+		t.Fatalf("heartbeat failed: %v", err)
+	}
+
+	if !client.PeerRecoveryTimeStamp().After(before) {
+		t.Errorf("peer Recovery Time Stamp should change. got = %v, before restart = %v",
+			client.PeerRecoveryTimeStamp(), before)
+	}
+}
+
+func TestRecoverModifiedSessionWithMockUPF(t *testing.T) {
+	upf, client := startMockUPF(t)
+
+	if err := client.SetupAssociation(); err != nil {
+		t.Fatalf("could not set up association: %v", err)
+	}
+
+	pdrs, fars, qers := newTestRules("17.0.0.1")
+
+	sess, err := client.EstablishSession(pdrs, fars, qers, nil)
+	if err != nil {
+		t.Fatalf("could not establish session: %v", err)
+	}
+
+	// the sessions to recover are the active ones
+	InsertSession(0, sess)
+	t.Cleanup(func() { RemoveSession(0) })
+
+	forward := session.NewFARBuilder().
+		WithID(2).
+		WithMethod(session.Update).
+		WithAction(session.ActionForward).
+		WithDstInterface(ieLib.DstInterfaceAccess).
+		WithTEID(200).
+		WithDownlinkIP("198.18.0.10").
+		BuildFAR()
+	closed := session.NewQERBuilder().
+		WithID(1).
+		WithMethod(session.Update).
+		WithGateStatus(ieLib.GateStatusClosed).
+		Build()
+
+	if err := client.ModifySession(sess, nil, []*ieLib.IE{forward}, []*ieLib.IE{closed}, nil); err != nil {
+		t.Fatalf("could not modify session: %v", err)
+	}
+
+	upf.Restart()
+	client.recoverAssociation()
+
+	recovered := upf.Sessions()
+	if len(recovered) != 1 {
+		t.Fatalf("mock UPF sessions mismatch after recovery. got = %v, want = 1", len(recovered))
+	}
+
+	if addr, teid := gnbTunnelFromFAR(recovered[0].FARs[2]); addr != "198.18.0.10" || teid != 200 {
+		t.Errorf("recovered downlink FAR mismatch. got = %v %v, want = 198.18.0.10 200", addr, teid)
+	}
+
+	want, _ := closed.GateStatus()
+	if gate, err := recovered[0].QERs[1].GateStatus(); err != nil || gate != want {
+		t.Errorf("recovered QER gate status mismatch. got = %v (%v), want = %v", gate, err, want)
+	}
+}
+
+func TestPagingEmulationWithMockUPF(t *testing.T) {
+	const pagingDelay = 50 * time.Millisecond
+
+	upf, client := startMockUPF(t)
+	upf.SetDownlinkDataReportDelay(10 * time.Millisecond)
+	client.EnablePagingEmulation(true, pagingDelay)
+
+	if err := client.SetupAssociation(); err != nil {
+		t.Fatalf("could not set up association: %v", err)
+	}
+
+	pdrs, fars, qers := newTestRules("17.0.0.1")
+
+	sess, err := client.EstablishSession(pdrs, fars, qers, nil)
+	if err != nil {
+		t.Fatalf("could not establish session: %v", err)
+	}
+
+	// Downlink Data Reports are matched against the active sessions
+	InsertSession(0, sess)
+	t.Cleanup(func() { RemoveSession(0) })
+
+	forward := session.NewFARBuilder().
+		WithID(2).
+		WithMethod(session.Update).
+		WithAction(session.ActionForward).
+		WithDstInterface(ieLib.DstInterfaceAccess).
+		WithTEID(200).
+		WithDownlinkIP("198.18.0.10").
+		BuildFAR()
+
+	if err := client.ModifySession(sess, nil, []*ieLib.IE{forward}, nil, nil); err != nil {
+		t.Fatalf("could not modify session: %v", err)
+	}
+
+	buffer := session.NewFARBuilder().
+		WithID(2).
+		WithMethod(session.Update).
+		WithAction(session.ActionBuffer | session.ActionNotify).
+		WithDstInterface(ieLib.DstInterfaceAccess).
+		WithZeroBasedOuterHeaderCreation().
+		BuildFAR()
+
+	if err := client.ModifySession(sess, nil, []*ieLib.IE{buffer}, nil, nil); err != nil {
+		t.Fatalf("could not modify session: %v", err)
+	}
+
+	timeout := time.After(2 * time.Second)
+
+	for paged := false; !paged; {
+		select {
+		case event := <-client.Events():
+			switch event.Type {
+			case EventPagingCompleted:
+				paged = true
+			case EventPagingFailed:
+				t.Fatalf("paging failed: %v", event.Details)
+			}
+		case <-timeout:
+			t.Fatal("timed out waiting for the paging to complete")
+		}
+	}
+
+	pagings := sess.Pagings()
+	if len(pagings) != 1 {
+		t.Fatalf("pagings mismatch. got = %+v", pagings)
+	}
+
+	if pagings[0].Latency < pagingDelay {
+		t.Errorf("paging latency %v should not be lower than the paging delay %v", pagings[0].Latency, pagingDelay)
+	}
+
+	upfSess, _ := upf.Session(sess.PeerSEID())
+	if upfSess.FARs[2].HasBUFF() || !upfSess.FARs[2].HasFORW() {
+		t.Error("downlink FAR should be set back to forward")
+	}
+
+	params, err := upfSess.FARs[2].ForwardingParameters()
+	if err != nil {
+		t.Fatalf("downlink FAR has no Forwarding Parameters: %v", err)
+	}
+
+	ohc, err := ieLib.NewGroupedIE(ieLib.ForwardingParameters, params...).OuterHeaderCreation()
+	if err != nil {
+		t.Fatalf("downlink FAR has no Outer Header Creation: %v", err)
+	}
+
+	if ohc.TEID != 200 || ohc.IPv4Address.String() != "198.18.0.10" {
+		t.Errorf("Outer Header Creation mismatch. got TEID = %v, address = %v", ohc.TEID, ohc.IPv4Address)
+	}
+}
+
+func TestAssociationUpdateWithMockUPF(t *testing.T) {
+	upf, client := startMockUPF(t)
+	upf.SetUPFunctionFeatures(0x01, 0x02)
+
+	if err := client.SetupAssociation(); err != nil {
+		t.Fatalf("could not set up association: %v", err)
+	}
+
+	state := client.AssociationState()
+	if state.PeerNodeID != "127.0.0.1" || !reflect.DeepEqual(state.UPFunctionFeatures, UPFunctionFeatures{0x01, 0x02}) {
+		t.Errorf("association state mismatch after setup. got = %+v", state)
+	}
+
+	features, err := ParseCPFunctionFeatures([]string{"LOAD", "PSUCC"})
+	if err != nil {
+		t.Fatalf("could not parse CP function features: %v", err)
+	}
+
+	if err := client.UpdateAssociation(ieLib.NewCPFunctionFeatures(features...)); err != nil {
+		t.Fatalf("could not update association: %v", err)
+	}
+
+	if got := upf.CPFunctionFeatures(); !reflect.DeepEqual(got, features) {
+		t.Errorf("mock UPF CP function features mismatch. got = %#x, want = %#x", got, features)
+	}
+
+	if got := client.AssociationState().CPFunctionFeatures; !reflect.DeepEqual(got, features) {
+		t.Errorf("CP function features mismatch. got = %#x, want = %#x", got, features)
+	}
+
+	// the updated features are advertised again by the next Association Setup
+	if got := client.cpFunctionFeaturesIE(); got == nil || !reflect.DeepEqual(got.Payload, features) {
+		t.Errorf("advertised CP function features mismatch. got = %v, want = %#x", got, features)
+	}
+
+	// the UPF changes its F-TEID resources and asks to release the association
+	err = upf.UpdateAssociation(
+		ieLib.NewUPFunctionFeatures(0x03),
+		ieLib.NewUserPlaneIPResourceInformation(0x01, 0, "198.18.0.5", "", "", 0),
+		ieLib.NewPFCPAssociationReleaseRequest(1, 0),
+		ieLib.NewGracefulReleasePeriod(time.Minute),
+	)
+	if err != nil {
+		t.Fatalf("mock UPF could not update association: %v", err)
+	}
+
+	waitForEvent(t, client, EventAssociationUpdated)
+
+	state = client.AssociationState()
+	if !state.ReleaseRequested || state.GracefulReleasePeriod != time.Minute ||
+		!reflect.DeepEqual(state.UPFunctionFeatures, UPFunctionFeatures{0x03, 0x00}) {
+		t.Errorf("association state mismatch after UPF update. got = %+v", state)
+	}
+
+	if want := []UPIPResource{{IPv4Address: "198.18.0.5"}}; !reflect.DeepEqual(state.UPIPResources, want) {
+		t.Errorf("UP IP resources mismatch after UPF update. got = %+v, want = %+v", state.UPIPResources, want)
+	}
+
+	if waitForReceived(upf, message.MsgTypeAssociationUpdateResponse) != 1 {
+		t.Error("mock UPF should receive an Association Update Response")
+	}
+}
+
+func TestUPFInitiatedNodeProceduresWithMockUPF(t *testing.T) {
+	upf, client := startMockUPF(t)
+
+	if err := client.SetupAssociation(); err != nil {
+		t.Fatalf("could not set up association: %v", err)
+	}
+
+	pdrs, fars, qers := newTestRules("17.0.0.1")
+
+	sess, err := client.EstablishSession(pdrs, fars, qers, nil)
+	if err != nil {
+		t.Fatalf("could not establish session: %v", err)
+	}
+
+	// node procedures affect the active sessions
+	InsertSession(0, sess)
+	t.Cleanup(func() { RemoveSession(0) })
+
+	forward := session.NewFARBuilder().
+		WithID(2).
+		WithMethod(session.Update).
+		WithAction(session.ActionForward).
+		WithDstInterface(ieLib.DstInterfaceAccess).
+		WithTEID(200).
+		WithDownlinkIP("198.18.0.10").
+		BuildFAR()
+
+	if err := client.ModifySession(sess, nil, []*ieLib.IE{forward}, nil, nil); err != nil {
+		t.Fatalf("could not modify session: %v", err)
+	}
+
+	peers := []string{"198.18.0.10", "2001:db8::10"}
+
+	if err := upf.ReportUserPlanePath(false, peers...); err != nil {
+		t.Fatalf("mock UPF could not report user plane path failure: %v", err)
+	}
+
+	event := waitForEvent(t, client, EventUserPlanePathFailure)
+	if !reflect.DeepEqual(event.RemoteGTPUPeers, peers) {
+		t.Errorf("remote GTP-U peers mismatch. got = %v, want = %v", event.RemoteGTPUPeers, peers)
+	}
+
+	if !sess.HasUserPlanePathFailure() {
+		t.Error("session should have a user plane path failure")
+	}
+
+	if err := upf.ReportUserPlanePath(true, peers[0]); err != nil {
+		t.Fatalf("mock UPF could not report user plane path recovery: %v", err)
+	}
+
+	waitForEvent(t, client, EventUserPlanePathRecovery)
+
+	if sess.HasUserPlanePathFailure() {
+		t.Error("session user plane path should be recovered")
+	}
+
+	if err := upf.ReleaseAssociation(); err != nil {
+		t.Fatalf("mock UPF could not release association: %v", err)
+	}
+
+	waitForEvent(t, client, EventAssociationDown)
+
+	if client.IsAssociationAlive() || !sess.IsReleased() {
+		t.Error("association and session should be released")
+	}
+
+	if err := client.DeleteSession(sess); err != nil {
+		t.Errorf("deleting a released session should not fail: %v", err)
+	}
+
+	if err := client.TeardownAssociation(); err != nil {
+		t.Errorf("tearing down an association released by the peer should not fail: %v", err)
+	}
+
+	if upf.Received(message.MsgTypeSessionDeletionRequest) != 0 ||
+		upf.Received(message.MsgTypeAssociationReleaseRequest) != 0 {
+		t.Error("no request should be sent for a session or an association released by the peer")
+	}
+
+	for _, msgType := range []uint8{message.MsgTypeNodeReportResponse, message.MsgTypeAssociationReleaseResponse} {
+		if waitForReceived(upf, msgType) == 0 {
+			t.Errorf("mock UPF should receive a response of type %v", msgType)
+		}
+	}
+}
+
+func TestPassiveAssociationWithMockUPF(t *testing.T) {
+	upf, client := startMockUPF(t)
+	upf.SetUPFunctionFeatures(0x01)
+
+	client.EnablePassiveAssociation(true, "127.0.0.2")
+
+	if err := upf.SetupAssociation(client.LocalAddr()); err != nil {
+		t.Fatalf("mock UPF could not send Association Setup Request: %v", err)
+	}
+
+	if waitForReceived(upf, message.MsgTypeAssociationSetupResponse) == 0 {
+		t.Fatal("mock UPF should receive an Association Setup Response")
+	}
+
+	if client.IsAssociationAlive() || upf.IsAssociated() {
+		t.Fatal("association with an unexpected Node ID should be rejected")
+	}
+
+	client.EnablePassiveAssociation(true, "127.0.0.1")
+
+	if err := upf.SetupAssociation(client.LocalAddr()); err != nil {
+		t.Fatalf("mock UPF could not send Association Setup Request: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := client.AcceptAssociation(ctx); err != nil {
+		t.Fatalf("association should be accepted: %v", err)
+	}
+
+	state := client.AssociationState()
+	if state.PeerNodeID != "127.0.0.1" || !reflect.DeepEqual(state.UPFunctionFeatures, UPFunctionFeatures{0x01, 0x00}) {
+		t.Errorf("association state mismatch. got = %+v", state)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for !upf.IsAssociated() && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	pdrs, fars, qers := newTestRules("17.0.0.1")
+
+	sess, err := client.EstablishSession(pdrs, fars, qers, nil)
+	if err != nil {
+		t.Fatalf("could not establish session after a UPF-initiated association: %v", err)
+	}
+
+	if err := client.DeleteSession(sess); err != nil {
+		t.Errorf("could not delete session: %v", err)
+	}
+
+	if upf.Received(message.MsgTypeAssociationSetupRequest) != 0 {
+		t.Error("no Association Setup Request should be sent in passive mode")
+	}
+}
+
+func TestPassiveAssociationFromOtherAddressWithMockUPF(t *testing.T) {
+	upf, client := startMockUPF(t)
+	client.EnablePassiveAssociation(true, "")
+
+	// the Association Setup Request comes from another address than the configured peer one
+	other := mockupf.NewMockUPF("127.0.0.1:0")
+	if err := other.Start(); err != nil {
+		t.Fatalf("could not start mock UPF: %v", err)
+	}
+
+	t.Cleanup(other.Stop)
+
+	if err := other.SetupAssociation(client.LocalAddr()); err != nil {
+		t.Fatalf("mock UPF could not send Association Setup Request: %v", err)
+	}
+
+	if waitForReceived(other, message.MsgTypeAssociationSetupResponse) == 0 {
+		t.Fatal("the Association Setup Response should be sent to the source of the request")
+	}
+
+	if upf.Received(message.MsgTypeAssociationSetupResponse) != 0 {
+		t.Error("the configured peer should not receive the Association Setup Response")
+	}
+
+	if !client.IsAssociationAlive() {
+		t.Error("association should be accepted")
+	}
+}
+
+func TestHeartbeatFromOtherAddressWithMockUPF(t *testing.T) {
+	upf, client := startMockUPF(t)
+
+	// the Heartbeat Request comes from another address than the configured peer one
+	other := mockupf.NewMockUPF("127.0.0.1:0")
+	if err := other.Start(); err != nil {
+		t.Fatalf("could not start mock UPF: %v", err)
+	}
+
+	t.Cleanup(other.Stop)
+
+	if err := other.SendHeartbeat(client.LocalAddr()); err != nil {
+		t.Fatalf("mock UPF could not send Heartbeat Request: %v", err)
+	}
+
+	if waitForReceived(other, message.MsgTypeHeartbeatResponse) == 0 {
+		t.Fatal("the Heartbeat Response should be sent to the source of the request")
+	}
+
+	if upf.Received(message.MsgTypeHeartbeatResponse) != 0 {
+		t.Error("the configured peer should not receive the Heartbeat Response")
+	}
+}
+
+func TestSendSessionModificationRequestWithMockUPF(t *testing.T) {
+	upf, client := startMockUPF(t)
+
+	if err := client.SetupAssociation(); err != nil {
+		t.Fatalf("could not set up association: %v", err)
+	}
+
+	pdrs, fars, qers := newTestRules("17.0.0.1")
+
+	sess, err := client.EstablishSession(pdrs, fars, qers, nil)
+	if err != nil {
+		t.Fatalf("could not establish session: %v", err)
+	}
+
+	InsertSession(0, sess)
+	t.Cleanup(func() { RemoveSession(0) })
+
+	// the request stays outstanding, to inspect its transaction
+	upf.InjectFault(mockupf.Fault{MessageType: message.MsgTypeSessionModificationRequest, Drop: true})
+
+	if err := client.SendSessionModificationRequest(sess.PeerSEID(), nil, nil, nil, nil); err != nil {
+		t.Fatalf("could not send Session Modification Request: %v", err)
+	}
+
+	client.transactions.lock.Lock()
+	defer client.transactions.lock.Unlock()
+
+	for _, tx := range client.transactions.pending {
+		if tx.rspType == message.MsgTypeSessionModificationResponse && tx.seid != sess.LocalSEID() {
+			t.Errorf("expected response SEID mismatch. got = %v, want = %v", tx.seid, sess.LocalSEID())
+		}
+	}
+
+	if len(client.transactions.pending) != 1 {
+		t.Errorf("pending transactions mismatch. got = %v, want = 1", len(client.transactions.pending))
+	}
+}
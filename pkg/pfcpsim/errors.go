@@ -4,6 +4,7 @@
 package pfcpsim
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 )
@@ -11,6 +12,8 @@ import (
 type pfcpSimError struct {
 	message string
 	error   []error
+	// cause is the PFCP Cause of the rejected request, 0 if the peer did not reject it
+	cause uint8
 }
 
 func (e *pfcpSimError) unwrap() string {
@@ -25,9 +28,31 @@ func (e *pfcpSimError) unwrap() string {
 }
 
 func (e *pfcpSimError) Error() string {
+	if e.cause != 0 {
+		return fmt.Sprintf("Message: %v. Cause: %v. %v", e.message, e.cause, e.unwrap())
+	}
+
 	return fmt.Sprintf("Message: %v. %v", e.message, e.unwrap())
 }
 
+// Cause returns the PFCP Cause the peer rejected the request with, if err is due to a rejection.
+func Cause(err error) (uint8, bool) {
+	var simErr *pfcpSimError
+	if !errors.As(err, &simErr) || simErr.cause == 0 {
+		return 0, false
+	}
+
+	return simErr.cause, true
+}
+
+// IsTimeout reports whether err is due to a request that got no response after all retransmissions.
+func IsTimeout(err error) bool {
+	var simErr *pfcpSimError
+	return errors.As(err, &simErr) && simErr.message == timeoutExpiredMsg
+}
+
+const timeoutExpiredMsg = "Timeout has expired"
+
 func NewInvalidCauseError(err ...error) *pfcpSimError {
 	return &pfcpSimError{
 		message: "Invalid Cause from response",
@@ -35,6 +60,14 @@ func NewInvalidCauseError(err ...error) *pfcpSimError {
 	}
 }
 
+// newRejectedError returns the error of a request the peer answered with a Cause other than Request accepted.
+func newRejectedError(cause uint8) *pfcpSimError {
+	rejected := NewInvalidCauseError()
+	rejected.cause = cause
+
+	return rejected
+}
+
 func NewNotEnoughSessionsError(err ...error) *pfcpSimError {
 	return &pfcpSimError{
 		message: "Not enough active sessions",
@@ -65,7 +98,7 @@ func NewAssociationInactiveError(err ...error) *pfcpSimError {
 
 func NewTimeoutExpiredError(err ...error) *pfcpSimError {
 	return &pfcpSimError{
-		message: "Timeout has expired",
+		message: timeoutExpiredMsg,
 		error:   err,
 	}
 }
@@ -76,3 +109,24 @@ func NewInvalidResponseError(err ...error) *pfcpSimError {
 		error:   err,
 	}
 }
+
+func NewUEPoolExhaustedError(pool string, err ...error) *pfcpSimError {
+	return &pfcpSimError{
+		message: fmt.Sprintf("No UE address left in pool %v", pool),
+		error:   err,
+	}
+}
+
+func NewTEIDExhaustedError(err ...error) *pfcpSimError {
+	return &pfcpSimError{
+		message: "No TEID left",
+		error:   err,
+	}
+}
+
+func NewUnsupportedFeatureError(feature string, err ...error) *pfcpSimError {
+	return &pfcpSimError{
+		message: fmt.Sprintf("UP function feature %v not supported by the peer", feature),
+		error:   err,
+	}
+}
@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/omec-project/pfcpsim/logger"
+	"github.com/wmnsk/go-pfcp/message"
+)
+
+// eventsBufferSize is the number of events kept when nobody is consuming them.
+const eventsBufferSize = 256
+
+type EventType int
+
+const (
+	// EventUnmatchedResponse is raised when a response does not belong to any outstanding request.
+	EventUnmatchedResponse EventType = iota
+	// EventDuplicateResponse is raised when a response is received for an already answered request.
+	EventDuplicateResponse
+	// EventPeerRestarted is raised when the Recovery Time Stamp of the peer changes.
+	EventPeerRestarted
+	// EventAssociationRecovered is raised when auto recovery re-established the association and the sessions.
+	EventAssociationRecovered
+	// EventRecoveryFailed is raised when auto recovery could not re-establish the association.
+	EventRecoveryFailed
+	// EventAssociationUp is raised when the association becomes active.
+	EventAssociationUp
+	// EventAssociationDown is raised when the association is released or lost.
+	EventAssociationDown
+	// EventHeartbeatFailure is raised when a Heartbeat Request is not answered.
+	EventHeartbeatFailure
+	// EventSessionReport is raised when a Session Report Request is received.
+	EventSessionReport
+	// EventNodeReport is raised when a Node Report Request reporting neither a user plane path failure nor a
+	// user plane path recovery is received.
+	EventNodeReport
+	// EventUnsolicitedMessage is raised when the peer sends a request pfcpsim does not handle.
+	EventUnsolicitedMessage
+	// EventPagingCompleted is raised when the downlink forwarding of a session is restored after a Downlink Data Report.
+	EventPagingCompleted
+	// EventPagingFailed is raised when the downlink forwarding of a session cannot be restored after a
+	// Downlink Data Report.
+	EventPagingFailed
+	// EventAssociationUpdated is raised when the peer updates the association with an Association Update Request.
+	EventAssociationUpdated
+	// EventUserPlanePathFailure is raised when the peer reports a user plane path failure towards remote GTP-U peers.
+	EventUserPlanePathFailure
+	// EventUserPlanePathRecovery is raised when the peer reports the recovery of user plane paths.
+	EventUserPlanePathRecovery
+)
+
+func (t EventType) String() string {
+	switch t {
+	case EventUnmatchedResponse:
+		return "UnmatchedResponse"
+	case EventDuplicateResponse:
+		return "DuplicateResponse"
+	case EventPeerRestarted:
+		return "PeerRestarted"
+	case EventAssociationRecovered:
+		return "AssociationRecovered"
+	case EventRecoveryFailed:
+		return "RecoveryFailed"
+	case EventAssociationUp:
+		return "AssociationUp"
+	case EventAssociationDown:
+		return "AssociationDown"
+	case EventHeartbeatFailure:
+		return "HeartbeatFailure"
+	case EventSessionReport:
+		return "SessionReport"
+	case EventNodeReport:
+		return "NodeReport"
+	case EventUnsolicitedMessage:
+		return "UnsolicitedMessage"
+	case EventPagingCompleted:
+		return "PagingCompleted"
+	case EventPagingFailed:
+		return "PagingFailed"
+	case EventAssociationUpdated:
+		return "AssociationUpdated"
+	case EventUserPlanePathFailure:
+		return "UserPlanePathFailure"
+	case EventUserPlanePathRecovery:
+		return "UserPlanePathRecovery"
+	default:
+		return "Unknown"
+	}
+}
+
+// Event is something that happened on N4 asynchronously to the PFCPClient user.
+type Event struct {
+	Type      EventType
+	Timestamp time.Time
+	// Message is the PFCP message that raised the event, if any.
+	Message message.Message
+	Details string
+	// RemoteGTPUPeers are the addresses of the remote GTP-U peers of a user plane path failure or recovery.
+	RemoteGTPUPeers []string
+}
+
+// Events returns the channel where PFCPClient publishes events.
+// Events are dropped when the channel is full.
+func (c *PFCPClient) Events() <-chan Event {
+	return c.eventsChan
+}
+
+func (c *PFCPClient) emitEvent(eventType EventType, msg message.Message, details string) {
+	c.emitPeersEvent(eventType, msg, details, nil)
+}
+
+// emitPeersEvent raises an event concerning the remote GTP-U peers peers.
+func (c *PFCPClient) emitPeersEvent(eventType EventType, msg message.Message, details string, peers []string) {
+	event := Event{
+		Type:            eventType,
+		Timestamp:       time.Now(),
+		Message:         msg,
+		Details:         details,
+		RemoteGTPUPeers: peers,
+	}
+
+	select {
+	case c.eventsChan <- event:
+	default:
+		logger.PfcpsimLog.Debugf("events channel full, dropping event %v: %v", eventType, details)
+	}
+}
+
+// describeSessionReport summarizes the report types and content of a Session Report Request.
+func describeSessionReport(msg *message.SessionReportRequest) string {
+	var reportTypes []string
+
+	if rt := msg.ReportType; rt != nil {
+		if rt.HasDLDR() {
+			reportTypes = append(reportTypes, "DLDR")
+		}
+
+		if rt.HasUSAR() {
+			reportTypes = append(reportTypes, "USAR")
+		}
+
+		if rt.HasERIR() {
+			reportTypes = append(reportTypes, "ERIR")
+		}
+
+		if rt.HasUPIR() {
+			reportTypes = append(reportTypes, "UPIR")
+		}
+	}
+
+	return fmt.Sprintf("Session Report Request for SEID %v: report types [%v], %v usage reports",
+		msg.SEID(), strings.Join(reportTypes, " "), len(msg.UsageReport))
+}
+
+// isRequest reports whether msg is a request, i.e. it is not meant to answer a request sent by pfcpsim.
+func isRequest(msg message.Message) bool {
+	switch msg.MessageType() {
+	case message.MsgTypeHeartbeatRequest,
+		message.MsgTypePFDManagementRequest,
+		message.MsgTypeAssociationSetupRequest,
+		message.MsgTypeAssociationUpdateRequest,
+		message.MsgTypeAssociationReleaseRequest,
+		message.MsgTypeNodeReportRequest,
+		message.MsgTypeSessionSetDeletionRequest,
+		message.MsgTypeSessionEstablishmentRequest,
+		message.MsgTypeSessionModificationRequest,
+		message.MsgTypeSessionDeletionRequest,
+		message.MsgTypeSessionReportRequest:
+		return true
+	default:
+		return false
+	}
+}
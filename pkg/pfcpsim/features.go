@@ -0,0 +1,214 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/omec-project/pfcpsim/pkg/pfcpsim/session"
+	ieLib "github.com/wmnsk/go-pfcp/ie"
+)
+
+// upFunctionFeatures are the UP function features, in the order of their octet and bit in the UP Function
+// Features IE. Refer to section 8.2.25 in PFCP specs Release 16
+var upFunctionFeatures = []struct {
+	name  string
+	octet int
+	bit   uint8
+}{
+	{"BUCP", 0, 0x01}, {"DDND", 0, 0x02}, {"DLBD", 0, 0x04}, {"TRST", 0, 0x08},
+	{"FTUP", 0, 0x10}, {"PFDM", 0, 0x20}, {"HEEU", 0, 0x40}, {"TREU", 0, 0x80},
+	{"EMPU", 1, 0x01}, {"PDIU", 1, 0x02}, {"UDBC", 1, 0x04}, {"QUOAC", 1, 0x08},
+	{"TRACE", 1, 0x10}, {"FRRT", 1, 0x20}, {"PFDE", 1, 0x40}, {"EPFAR", 1, 0x80},
+	{"DPDRA", 2, 0x01}, {"ADPDP", 2, 0x02}, {"UEIP", 2, 0x04}, {"SSET", 2, 0x08},
+	{"MNOP", 2, 0x10}, {"MTE", 2, 0x20}, {"BUNDL", 2, 0x40}, {"GCOM", 2, 0x80},
+	{"MPAS", 3, 0x01}, {"RTTL", 3, 0x02}, {"VTIME", 3, 0x04}, {"NORP", 3, 0x08},
+	{"IPTV", 3, 0x10}, {"IP6PL", 3, 0x20}, {"TSCU", 3, 0x40}, {"MPTCP", 3, 0x80},
+	{"ATSSSLL", 4, 0x01}, {"QFQM", 4, 0x02}, {"GPQM", 4, 0x04}, {"MTEDT", 4, 0x08},
+	{"CIOT", 4, 0x10}, {"ETHAR", 4, 0x20}, {"DDDS", 4, 0x40}, {"RDS", 4, 0x80},
+}
+
+// User Plane IP Resource Information IE flags. Refer to section 8.2.82 in PFCP specs Release 16
+const (
+	upIPResourceV4     uint8 = 0x01
+	upIPResourceV6     uint8 = 0x02
+	upIPResourceASSONI uint8 = 0x20
+	upIPResourceASSOSI uint8 = 0x40
+)
+
+// UPFunctionFeatures are the octets of the UP Function Features IE, advertising the features supported by
+// the UPF.
+type UPFunctionFeatures []byte
+
+// Supports reports whether the feature named name (e.g. FTUP, UEIP) is advertised. Names are case-insensitive.
+func (f UPFunctionFeatures) Supports(name string) bool {
+	for _, feature := range upFunctionFeatures {
+		if feature.name == strings.ToUpper(name) {
+			return feature.octet < len(f) && f[feature.octet]&feature.bit != 0
+		}
+	}
+
+	return false
+}
+
+// Names returns the names of the advertised features.
+func (f UPFunctionFeatures) Names() []string {
+	var names []string
+
+	for _, feature := range upFunctionFeatures {
+		if feature.octet < len(f) && f[feature.octet]&feature.bit != 0 {
+			names = append(names, feature.name)
+		}
+	}
+
+	return names
+}
+
+// UPIPResource is a User Plane IP Resource Information IE advertised by the UPF, the addresses its F-TEIDs
+// are allocated from.
+type UPIPResource struct {
+	IPv4Address string
+	IPv6Address string
+	// TEIDRangeIndication is the number of bits of the TEIDs set to TEIDRange, 0 if no range is advertised
+	TEIDRangeIndication uint8
+	TEIDRange           uint8
+	// NetworkInstance and SourceInterface are empty and 0 if the resource is not associated with them
+	NetworkInstance string
+	SourceInterface uint8
+}
+
+// parseUPIPResource parses a User Plane IP Resource Information IE. The payload is parsed here, as go-pfcp
+// fails to parse the IEs carrying a Network Instance. Refer to section 8.2.82 in PFCP specs Release 16
+func parseUPIPResource(ie *ieLib.IE) (UPIPResource, error) {
+	b := ie.Payload
+	if len(b) < 1 {
+		return UPIPResource{}, io.ErrUnexpectedEOF
+	}
+
+	flags := b[0]
+	resource := UPIPResource{TEIDRangeIndication: (flags >> 2) & 0x07}
+	b = b[1:]
+
+	if resource.TEIDRangeIndication != 0 {
+		if len(b) < 1 {
+			return UPIPResource{}, io.ErrUnexpectedEOF
+		}
+
+		resource.TEIDRange, b = b[0], b[1:]
+	}
+
+	if flags&upIPResourceV4 != 0 {
+		if len(b) < net.IPv4len {
+			return UPIPResource{}, io.ErrUnexpectedEOF
+		}
+
+		resource.IPv4Address, b = net.IP(b[:net.IPv4len]).String(), b[net.IPv4len:]
+	}
+
+	if flags&upIPResourceV6 != 0 {
+		if len(b) < net.IPv6len {
+			return UPIPResource{}, io.ErrUnexpectedEOF
+		}
+
+		resource.IPv6Address, b = net.IP(b[:net.IPv6len]).String(), b[net.IPv6len:]
+	}
+
+	// the Source Interface is the last octet, the Network Instance fills the octets before
+	if flags&upIPResourceASSOSI != 0 {
+		if len(b) < 1 {
+			return UPIPResource{}, io.ErrUnexpectedEOF
+		}
+
+		resource.SourceInterface, b = b[len(b)-1]&0x0f, b[:len(b)-1]
+	}
+
+	if flags&upIPResourceASSONI != 0 {
+		resource.NetworkInstance = string(b)
+	}
+
+	return resource, nil
+}
+
+// parseAlternativeSMFIPs returns the addresses of an Alternative SMF IP Address IE.
+func parseAlternativeSMFIPs(ie *ieLib.IE) ([]string, error) {
+	fields, err := ie.AlternativeSMFIPAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	var addresses []string
+
+	if fields.IPv4Address != nil {
+		addresses = append(addresses, fields.IPv4Address.String())
+	}
+
+	if fields.IPv6Address != nil {
+		addresses = append(addresses, fields.IPv6Address.String())
+	}
+
+	return addresses, nil
+}
+
+// SetCPFunctionFeatures sets the octets of the CP Function Features IE advertised in the Association Setup
+// procedures (see ParseCPFunctionFeatures). The IE is not sent if features is empty.
+func (c *PFCPClient) SetCPFunctionFeatures(features []uint8) {
+	c.associationLock.Lock()
+	defer c.associationLock.Unlock()
+
+	c.cpFeatures = slices.Clone(features)
+}
+
+// cpFunctionFeaturesIE returns the CP Function Features IE to advertise, nil if none is configured.
+func (c *PFCPClient) cpFunctionFeaturesIE() *ieLib.IE {
+	c.associationLock.Lock()
+	defer c.associationLock.Unlock()
+
+	if len(c.cpFeatures) == 0 {
+		return nil
+	}
+
+	return ieLib.NewCPFunctionFeatures(c.cpFeatures...)
+}
+
+// checkUPFunctionFeatures returns an error if a Create PDR of pdrs relies on a UP function feature the peer
+// did not advertise: FTUP to allocate the F-TEID (CH flag) and UEIP to allocate the UE addresses (CHV4
+// and CHV6 flags). Nothing is checked without an established association.
+func (c *PFCPClient) checkUPFunctionFeatures(pdrs []*ieLib.IE) error {
+	if !c.IsAssociationAlive() {
+		return nil
+	}
+
+	features := c.AssociationState().UPFunctionFeatures
+
+	for _, pdr := range pdrs {
+		if pdr == nil || pdr.Type != ieLib.CreatePDR {
+			continue
+		}
+
+		if fteid, err := pdr.FTEID(); err == nil && fteid.HasCh() && !features.Supports("FTUP") {
+			return NewUnsupportedFeatureError("FTUP")
+		}
+
+		if ueIP, err := pdr.UEIPAddress(); err == nil &&
+			ueIP.Flags&(session.UEIPAddressCHV4|session.UEIPAddressCHV6) != 0 && !features.Supports("UEIP") {
+			return NewUnsupportedFeatureError("UEIP")
+		}
+	}
+
+	return nil
+}
+
+// describeUPFunctionFeatures summarizes the features advertised by the peer, for logging.
+func describeUPFunctionFeatures(features UPFunctionFeatures) string {
+	names := features.Names()
+	if len(names) == 0 {
+		return "no UP function feature"
+	}
+
+	return fmt.Sprintf("UP function features %v", strings.Join(names, ", "))
+}
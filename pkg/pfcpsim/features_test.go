@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	ieLib "github.com/wmnsk/go-pfcp/ie"
+)
+
+func TestUPFunctionFeatures(t *testing.T) {
+	tests := []struct {
+		name     string
+		features UPFunctionFeatures
+		want     []string
+	}{
+		{
+			name:     "no feature",
+			features: nil,
+			want:     nil,
+		},
+		{
+			name:     "first octet",
+			features: UPFunctionFeatures{0x11},
+			want:     []string{"BUCP", "FTUP"},
+		},
+		{
+			name:     "several octets",
+			features: UPFunctionFeatures{0x10, 0x00, 0x04, 0x00, 0x80},
+			want:     []string{"FTUP", "UEIP", "RDS"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.features.Names(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Names() = %v, want %v", got, tt.want)
+			}
+
+			for _, name := range tt.want {
+				if !tt.features.Supports(name) {
+					t.Errorf("Supports(%v) = false, want true", name)
+				}
+			}
+
+			if tt.features.Supports("ftup") != slices.Contains(tt.want, "FTUP") {
+				t.Errorf("Supports(ftup) should be case-insensitive")
+			}
+
+			if tt.features.Supports("UNKNOWN") {
+				t.Error("Supports(UNKNOWN) = true, want false")
+			}
+		})
+	}
+}
+
+func TestParseUPIPResource(t *testing.T) {
+	tests := []struct {
+		name    string
+		ie      *ieLib.IE
+		want    UPIPResource
+		wantErr bool
+	}{
+		{
+			name: "IPv4 with TEID range",
+			ie:   ieLib.NewUserPlaneIPResourceInformation(0x09, 0x02, "198.18.0.1", "", "", 0),
+			want: UPIPResource{IPv4Address: "198.18.0.1", TEIDRangeIndication: 2, TEIDRange: 2},
+		},
+		{
+			name: "dual-stack with network instance and source interface",
+			ie:   ieLib.NewUserPlaneIPResourceInformation(0x63, 0, "198.18.0.1", "2001:db8::1", "internet", 1),
+			want: UPIPResource{
+				IPv4Address:     "198.18.0.1",
+				IPv6Address:     "2001:db8::1",
+				NetworkInstance: "internet",
+				SourceInterface: 1,
+			},
+		},
+		{
+			name:    "truncated IPv6 address",
+			ie:      ieLib.New(ieLib.UserPlaneIPResourceInformation, []byte{0x02, 0x20, 0x01}),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseUPIPResource(tt.ie)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseUPIPResource() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseUPIPResource() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
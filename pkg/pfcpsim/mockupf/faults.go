@@ -0,0 +1,227 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package mockupf
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wmnsk/go-pfcp/ie"
+	"github.com/wmnsk/go-pfcp/message"
+)
+
+// Fault describes how the mock UPF misbehaves when answering the requests it matches.
+type Fault struct {
+	// MessageType is the type of the requests the fault applies to. 0 matches every request.
+	MessageType uint8
+	// Nth applies the fault to the Nth matching request only, counting from 1.
+	// 0 applies it to every matching request. Retransmissions are counted as new requests.
+	Nth int
+
+	// Drop discards the request without answering it.
+	Drop bool
+	// Delay postpones the response. Jitter adds a random delay uniformly distributed in [0, Jitter).
+	Delay  time.Duration
+	Jitter time.Duration
+	// Cause rejects the request with the given cause, leaving the state of the mock UPF untouched.
+	// It is ignored for requests whose response carries no Cause, e.g. Heartbeat Requests.
+	Cause uint8
+	// WrongType answers with a response of another message type, keeping sequence number and SEID.
+	WrongType bool
+	// Duplicate sends the response twice.
+	Duplicate bool
+}
+
+// faultState tracks the number of requests matched by a fault.
+type faultState struct {
+	Fault
+	matched int
+}
+
+// delay returns the time the response has to be postponed.
+func (f *Fault) delay() time.Duration {
+	d := f.Delay
+	if f.Jitter > 0 {
+		d += rand.N(f.Jitter)
+	}
+
+	return d
+}
+
+// faultMessageTypes maps the message names accepted by ParseFault to their message type.
+var faultMessageTypes = map[string]uint8{
+	"heartbeat":             message.MsgTypeHeartbeatRequest,
+	"association-setup":     message.MsgTypeAssociationSetupRequest,
+	"association-release":   message.MsgTypeAssociationReleaseRequest,
+	"session-establishment": message.MsgTypeSessionEstablishmentRequest,
+	"session-modification":  message.MsgTypeSessionModificationRequest,
+	"session-deletion":      message.MsgTypeSessionDeletionRequest,
+}
+
+// ParseFault parses a fault from a comma-separated list of settings, e.g.
+// "msg=session-establishment,nth=2,drop" or "delay=100ms,jitter=50ms,cause=73".
+// Accepted settings are msg, nth, delay, jitter, cause, drop, wrong-type and duplicate.
+func ParseFault(spec string) (Fault, error) {
+	var f Fault
+
+	for _, setting := range strings.Split(spec, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(setting), "=")
+
+		var err error
+
+		switch key {
+		case "msg":
+			msgType, ok := faultMessageTypes[value]
+			if !ok {
+				return Fault{}, fmt.Errorf("unknown message %q", value)
+			}
+
+			f.MessageType = msgType
+		case "nth":
+			f.Nth, err = strconv.Atoi(value)
+		case "delay":
+			f.Delay, err = time.ParseDuration(value)
+		case "jitter":
+			f.Jitter, err = time.ParseDuration(value)
+		case "cause":
+			var cause uint64
+
+			cause, err = strconv.ParseUint(value, 10, 8)
+			f.Cause = uint8(cause)
+		case "drop":
+			f.Drop = true
+		case "wrong-type":
+			f.WrongType = true
+		case "duplicate":
+			f.Duplicate = true
+		default:
+			return Fault{}, fmt.Errorf("unknown fault setting %q", setting)
+		}
+
+		if err != nil {
+			return Fault{}, fmt.Errorf("invalid fault setting %q: %w", setting, err)
+		}
+	}
+
+	if f.Nth < 0 || f.Delay < 0 || f.Jitter < 0 {
+		return Fault{}, fmt.Errorf("invalid fault %q: negative values are not allowed", spec)
+	}
+
+	return f, nil
+}
+
+// InjectFault adds a fault. When several faults apply to the same request, the first injected wins.
+func (u *MockUPF) InjectFault(f Fault) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	u.faults = append(u.faults, &faultState{Fault: f})
+}
+
+// ClearFaults removes every injected fault.
+func (u *MockUPF) ClearFaults() {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	u.faults = nil
+}
+
+// matchFault counts msg against the injected faults and returns the one to apply, if any.
+func (u *MockUPF) matchFault(msg message.Message) *Fault {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	var selected *Fault
+
+	for _, f := range u.faults {
+		if f.MessageType != 0 && f.MessageType != msg.MessageType() {
+			continue
+		}
+
+		f.matched++
+
+		if selected == nil && (f.Nth == 0 || f.Nth == f.matched) {
+			selected = &f.Fault
+		}
+	}
+
+	return selected
+}
+
+// newRejection returns a response to msg carrying cause, or nil if the response has no Cause.
+func (u *MockUPF) newRejection(msg message.Message, cause uint8) message.Message {
+	switch msg := msg.(type) {
+	case *message.AssociationSetupRequest:
+		return message.NewAssociationSetupResponse(msg.Sequence(),
+			ie.NewNodeIDHeuristic(u.nodeID),
+			ie.NewCause(cause),
+			ie.NewRecoveryTimeStamp(u.RecoveryTimeStamp()),
+		)
+	case *message.AssociationReleaseRequest:
+		return message.NewAssociationReleaseResponse(msg.Sequence(),
+			ie.NewNodeIDHeuristic(u.nodeID),
+			ie.NewCause(cause),
+		)
+	case *message.SessionEstablishmentRequest:
+		return message.NewSessionEstablishmentResponse(0, 0, cpSEID(msg), msg.Sequence(), 0,
+			ie.NewNodeIDHeuristic(u.nodeID),
+			ie.NewCause(cause),
+		)
+	case *message.SessionModificationRequest:
+		return message.NewSessionModificationResponse(0, 0, u.peerSEID(msg.SEID()), msg.Sequence(), 0,
+			ie.NewCause(cause),
+		)
+	case *message.SessionDeletionRequest:
+		return message.NewSessionDeletionResponse(0, 0, u.peerSEID(msg.SEID()), msg.Sequence(), 0,
+			ie.NewCause(cause),
+		)
+	default:
+		return nil
+	}
+}
+
+// newWrongTypeResponse returns a response with the sequence number and SEID of rsp but a different message type.
+// Session related responses are replaced by other session related responses, so that the
+// client still correlates them to the request.
+func (u *MockUPF) newWrongTypeResponse(rsp message.Message) message.Message {
+	switch rsp.(type) {
+	case *message.HeartbeatResponse:
+		return message.NewAssociationSetupResponse(rsp.Sequence(),
+			ie.NewNodeIDHeuristic(u.nodeID),
+			ie.NewCause(ie.CauseRequestAccepted),
+		)
+	case *message.SessionDeletionResponse:
+		return message.NewSessionModificationResponse(0, 0, rsp.SEID(), rsp.Sequence(), 0,
+			ie.NewCause(ie.CauseRequestAccepted),
+		)
+	case *message.SessionEstablishmentResponse, *message.SessionModificationResponse:
+		return message.NewSessionDeletionResponse(0, 0, rsp.SEID(), rsp.Sequence(), 0,
+			ie.NewCause(ie.CauseRequestAccepted),
+		)
+	default:
+		return message.NewHeartbeatResponse(rsp.Sequence(), ie.NewRecoveryTimeStamp(u.RecoveryTimeStamp()))
+	}
+}
+
+// Restart emulates a restart of the UPF: the association and the sessions are lost and a new
+// Recovery Time Stamp is advertised. The N4 socket and the injected faults are kept.
+func (u *MockUPF) Restart() {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	// the Recovery Time Stamp has a resolution of one second
+	rts := time.Now()
+	if rts.Sub(u.recoveryTimeStamp) < time.Second {
+		rts = u.recoveryTimeStamp.Add(time.Second)
+	}
+
+	u.recoveryTimeStamp = rts
+	u.associated = false
+	u.peer = nil
+	u.lastSEID = 0
+	u.sessions = make(map[uint64]*Session)
+}
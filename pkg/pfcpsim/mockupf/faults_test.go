@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package mockupf
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wmnsk/go-pfcp/message"
+)
+
+func TestParseFault(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    Fault
+		wantErr bool
+	}{
+		{
+			name: "drop nth",
+			spec: "msg=session-establishment,nth=2,drop",
+			want: Fault{MessageType: message.MsgTypeSessionEstablishmentRequest, Nth: 2, Drop: true},
+		},
+		{
+			name: "delay with jitter",
+			spec: "delay=100ms, jitter=50ms",
+			want: Fault{Delay: 100 * time.Millisecond, Jitter: 50 * time.Millisecond},
+		},
+		{
+			name: "cause, wrong type and duplicate",
+			spec: "msg=heartbeat,cause=73,wrong-type,duplicate",
+			want: Fault{MessageType: message.MsgTypeHeartbeatRequest, Cause: 73, WrongType: true, Duplicate: true},
+		},
+		{
+			name:    "unknown message",
+			spec:    "msg=session-report,drop",
+			wantErr: true,
+		},
+		{
+			name:    "unknown setting",
+			spec:    "drop,reorder",
+			wantErr: true,
+		},
+		{
+			name:    "cause out of range",
+			spec:    "cause=300",
+			wantErr: true,
+		},
+		{
+			name:    "negative nth",
+			spec:    "nth=-1,drop",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFault(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFault() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("ParseFault() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,410 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package mockupf
+
+import (
+	"encoding/binary"
+	"net"
+	"slices"
+
+	"github.com/omec-project/pfcpsim/logger"
+	"github.com/omec-project/pfcpsim/pkg/pfcpsim/session"
+	"github.com/wmnsk/go-pfcp/ie"
+	"github.com/wmnsk/go-pfcp/message"
+)
+
+// handleAssociationSetupRequest accepts every association. As mandated by TS 29.244, a new association
+// from the CP function replaces the previous one and removes its sessions.
+func (u *MockUPF) handleAssociationSetupRequest(msg *message.AssociationSetupRequest, raddr net.Addr) message.Message {
+	u.lock.Lock()
+	u.associated = true
+	u.peer = raddr
+	u.sessions = make(map[uint64]*Session)
+	u.cpFeatures = nil
+	rts := u.recoveryTimeStamp
+	upFeatures := u.upFeatures
+	u.lock.Unlock()
+
+	if msg.CPFunctionFeatures != nil {
+		u.recordCPFunctionFeatures(msg.CPFunctionFeatures)
+	}
+
+	logger.PfcpsimLog.Infof("mock UPF associated with %v", raddr)
+
+	ies := []*ie.IE{
+		ie.NewNodeIDHeuristic(u.nodeID),
+		ie.NewCause(ie.CauseRequestAccepted),
+		ie.NewRecoveryTimeStamp(rts),
+	}
+
+	if len(upFeatures) != 0 {
+		ies = append(ies, ie.NewUPFunctionFeatures(upFeatures...))
+	}
+
+	return message.NewAssociationSetupResponse(msg.Sequence(), ies...)
+}
+
+// handleAssociationSetupResponse sets up the association requested with SetupAssociation, if the CP function
+// accepted it.
+func (u *MockUPF) handleAssociationSetupResponse(msg *message.AssociationSetupResponse, raddr net.Addr) {
+	if msg.Cause == nil {
+		logger.PfcpsimLog.Warnf("mock UPF received Association Setup Response without cause from %v", raddr)
+		return
+	}
+
+	if cause, err := msg.Cause.Cause(); err != nil || cause != ie.CauseRequestAccepted {
+		logger.PfcpsimLog.Warnf("mock UPF association rejected by %v", raddr)
+		return
+	}
+
+	u.lock.Lock()
+	u.associated = true
+	u.peer = raddr
+	u.sessions = make(map[uint64]*Session)
+	u.cpFeatures = nil
+	u.lock.Unlock()
+
+	if msg.CPFunctionFeatures != nil {
+		u.recordCPFunctionFeatures(msg.CPFunctionFeatures)
+	}
+
+	logger.PfcpsimLog.Infof("mock UPF associated with %v", raddr)
+}
+
+// handleAssociationUpdateRequest accepts the updates of an established association, recording the
+// CP Function Features.
+func (u *MockUPF) handleAssociationUpdateRequest(msg *message.AssociationUpdateRequest) message.Message {
+	cause := uint8(ie.CauseRequestAccepted)
+
+	switch {
+	case !u.IsAssociated():
+		cause = ie.CauseNoEstablishedPFCPAssociation
+	case msg.NodeID == nil:
+		cause = ie.CauseMandatoryIEMissing
+	case msg.CPFunctionFeatures != nil:
+		u.recordCPFunctionFeatures(msg.CPFunctionFeatures)
+	}
+
+	return message.NewAssociationUpdateResponse(msg.Sequence(),
+		ie.NewNodeIDHeuristic(u.nodeID),
+		ie.NewCause(cause),
+	)
+}
+
+func (u *MockUPF) recordCPFunctionFeatures(features *ie.IE) {
+	octets, err := features.CPFunctionFeatures()
+	if err != nil {
+		logger.PfcpsimLog.Warnf("mock UPF ignoring invalid CP Function Features: %v", err)
+		return
+	}
+
+	u.lock.Lock()
+	u.cpFeatures = slices.Clone(octets)
+	u.lock.Unlock()
+}
+
+func (u *MockUPF) handleAssociationReleaseRequest(msg *message.AssociationReleaseRequest) message.Message {
+	u.lock.Lock()
+	u.associated = false
+	u.sessions = make(map[uint64]*Session)
+	u.lock.Unlock()
+
+	return message.NewAssociationReleaseResponse(msg.Sequence(),
+		ie.NewNodeIDHeuristic(u.nodeID),
+		ie.NewCause(ie.CauseRequestAccepted),
+	)
+}
+
+func (u *MockUPF) handleSessionEstablishmentRequest(msg *message.SessionEstablishmentRequest) message.Message {
+	cpSEID := cpSEID(msg)
+
+	reject := func(cause uint8) message.Message {
+		return message.NewSessionEstablishmentResponse(0, 0, cpSEID, msg.Sequence(), 0,
+			ie.NewNodeIDHeuristic(u.nodeID),
+			ie.NewCause(cause),
+		)
+	}
+
+	if cpSEID == 0 {
+		return reject(ie.CauseMandatoryIEMissing)
+	}
+
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	if !u.associated {
+		return reject(ie.CauseNoEstablishedPFCPAssociation)
+	}
+
+	sess := newSession(u.lastSEID+1, cpSEID)
+
+	rules := slices.Concat(msg.CreatePDR, msg.CreateFAR, msg.CreateQER, msg.CreateURR)
+	if err := sess.apply(rules...); err != nil {
+		logger.PfcpsimLog.Warnf("mock UPF rejecting Session Establishment Request: %v", err)
+		return reject(ie.CauseRuleCreationModificationFailure)
+	}
+
+	u.lastSEID++
+	u.sessions[sess.SEID] = sess
+
+	ies := []*ie.IE{
+		ie.NewNodeIDHeuristic(u.nodeID),
+		ie.NewCause(ie.CauseRequestAccepted),
+		u.newFSEID(sess.SEID),
+	}
+
+	return message.NewSessionEstablishmentResponse(0, 0, cpSEID, msg.Sequence(), 0,
+		append(ies, u.createdPDRs(sess, msg.CreatePDR)...)...,
+	)
+}
+
+func (u *MockUPF) handleSessionModificationRequest(msg *message.SessionModificationRequest) message.Message {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	sess, ok := u.sessions[msg.SEID()]
+	if !ok {
+		return message.NewSessionModificationResponse(0, 0, 0, msg.Sequence(), 0,
+			ie.NewCause(ie.CauseSessionContextNotFound),
+		)
+	}
+
+	// rules are applied to a copy, so that a failing request leaves the session untouched
+	modified := sess.clone()
+
+	rules := slices.Concat(
+		msg.RemovePDR, msg.RemoveFAR, msg.RemoveQER, msg.RemoveURR,
+		msg.CreatePDR, msg.CreateFAR, msg.CreateQER, msg.CreateURR,
+		msg.UpdatePDR, msg.UpdateFAR, msg.UpdateQER, msg.UpdateURR,
+	)
+	if err := modified.apply(rules...); err != nil {
+		logger.PfcpsimLog.Warnf("mock UPF rejecting Session Modification Request: %v", err)
+
+		return message.NewSessionModificationResponse(0, 0, sess.CPSEID, msg.Sequence(), 0,
+			ie.NewCause(ie.CauseRuleCreationModificationFailure),
+		)
+	}
+
+	reports, err := modified.queryURRs(msg.QueryURR)
+	if err != nil {
+		logger.PfcpsimLog.Warnf("mock UPF rejecting Session Modification Request: %v", err)
+
+		return message.NewSessionModificationResponse(0, 0, sess.CPSEID, msg.Sequence(), 0,
+			ie.NewCause(ie.CauseRuleCreationModificationFailure),
+		)
+	}
+
+	u.sessions[sess.SEID] = modified
+	u.scheduleDownlinkDataReport(sess.SEID, bufferingPDRs(modified, msg.UpdateFAR))
+
+	ies := []*ie.IE{ie.NewCause(ie.CauseRequestAccepted)}
+	ies = append(ies, u.createdPDRs(modified, msg.CreatePDR)...)
+
+	return message.NewSessionModificationResponse(0, 0, sess.CPSEID, msg.Sequence(), 0,
+		append(ies, reports...)...,
+	)
+}
+
+func (u *MockUPF) handleSessionDeletionRequest(msg *message.SessionDeletionRequest) message.Message {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	sess, ok := u.sessions[msg.SEID()]
+	if !ok {
+		return message.NewSessionDeletionResponse(0, 0, 0, msg.Sequence(), 0,
+			ie.NewCause(ie.CauseSessionContextNotFound),
+		)
+	}
+
+	delete(u.sessions, sess.SEID)
+
+	return message.NewSessionDeletionResponse(0, 0, sess.CPSEID, msg.Sequence(), 0,
+		ie.NewCause(ie.CauseRequestAccepted),
+	)
+}
+
+// cpSEID returns the SEID allocated by the CP function to the session established by msg, or 0 if missing.
+func cpSEID(msg *message.SessionEstablishmentRequest) uint64 {
+	if msg.CPFSEID == nil {
+		return 0
+	}
+
+	fseid, err := msg.CPFSEID.FSEID()
+	if err != nil {
+		return 0
+	}
+
+	return fseid.SEID
+}
+
+// peerSEID returns the CP SEID of the session identified by seid, or 0 if the session does not exist.
+func (u *MockUPF) peerSEID(seid uint64) uint64 {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	if sess, ok := u.sessions[seid]; ok {
+		return sess.CPSEID
+	}
+
+	return 0
+}
+
+// localIP returns the IP address of the mock UPF: the Node ID if it is an address, the N4 address otherwise.
+func (u *MockUPF) localIP() net.IP {
+	ip := net.ParseIP(u.nodeID)
+	if ip == nil || ip.IsUnspecified() {
+		if addr, ok := u.conn.LocalAddr().(*net.UDPAddr); ok {
+			ip = addr.IP
+		}
+	}
+
+	return ip
+}
+
+// newFSEID returns the F-SEID of a session, using the Node ID or the N4 address as IP address.
+func (u *MockUPF) newFSEID(seid uint64) *ie.IE {
+	ip := u.localIP()
+
+	if v4 := ip.To4(); v4 != nil {
+		return ie.NewFSEID(seid, v4, nil)
+	}
+
+	return ie.NewFSEID(seid, nil, ip)
+}
+
+// createdPDRs allocates the F-TEIDs and the UE addresses the UPF is asked to choose for the Create PDRs pdrs
+// of sess, and returns the Created PDR IEs carrying them. The lock must be held.
+func (u *MockUPF) createdPDRs(sess *Session, pdrs []*ie.IE) []*ie.IE {
+	var created []*ie.IE
+
+	chosen := make(map[uint8]uint32)
+
+	for _, pdr := range pdrs {
+		id, err := pdr.PDRID()
+		if err != nil {
+			continue
+		}
+
+		ies := []*ie.IE{ie.NewPDRID(id)}
+
+		if fteid := chooseFTEID(pdr); fteid != nil {
+			ies = append(ies, u.allocateFTEID(sess, id, fteid, chosen))
+		}
+
+		if ueIP := chooseUEIPAddress(pdr); ueIP != nil {
+			ies = append(ies, u.allocateUEIPAddress(sess, ueIP))
+		}
+
+		if len(ies) > 1 {
+			created = append(created, ie.NewCreatedPDR(ies...))
+		}
+	}
+
+	return created
+}
+
+// allocateFTEID allocates a TEID to the PDR id of sess and returns the F-TEID IE carrying it.
+// PDRs with the same Choose ID in a request, recorded in chosen, get the same TEID. The lock must be held.
+func (u *MockUPF) allocateFTEID(sess *Session, id uint16, fteid *ie.FTEIDFields, chosen map[uint8]uint32) *ie.IE {
+	teid, ok := chosen[fteid.ChooseID]
+	if !ok || !fteid.HasChID() {
+		u.lastTEID++
+		teid = u.lastTEID
+
+		if fteid.HasChID() {
+			chosen[fteid.ChooseID] = teid
+		}
+	}
+
+	sess.TEIDs[id] = teid
+
+	ip := u.localIP()
+	if v4 := ip.To4(); v4 != nil {
+		return ie.NewFTEID(0x01, teid, v4, nil, 0)
+	}
+
+	return ie.NewFTEID(0x02, teid, nil, ip, 0)
+}
+
+// allocateUEIPAddress allocates the UE IPv4 address and/or IPv6 prefix requested by ueIP, and returns the
+// UE IP Address IE carrying them. A session gets a single address of each version, shared by its PDRs.
+// The lock must be held.
+func (u *MockUPF) allocateUEIPAddress(sess *Session, ueIP *ie.UEIPAddressFields) *ie.IE {
+	var (
+		flags  uint8
+		v4, v6 string
+	)
+
+	if ueIP.Flags&session.UEIPAddressCHV4 != 0 {
+		if sess.UEAddress == nil {
+			u.lastUEAddress++
+			sess.UEAddress = nthUEAddress(u.uePool, u.lastUEAddress)
+		}
+
+		flags |= session.UEIPAddressV4
+		v4 = sess.UEAddress.String()
+	}
+
+	if ueIP.Flags&session.UEIPAddressCHV6 != 0 {
+		if sess.UEIPv6Prefix == nil {
+			u.lastUEIPv6Prefix++
+			sess.UEIPv6Prefix = &net.IPNet{
+				IP:   nthUEIPv6Prefix(u.uePoolV6, u.lastUEIPv6Prefix),
+				Mask: net.CIDRMask(64, 128),
+			}
+		}
+
+		flags |= session.UEIPAddressV6
+		v6 = sess.UEIPv6Prefix.IP.String()
+	}
+
+	return ie.NewUEIPAddress(flags, v4, v6, 0, 0)
+}
+
+// nthUEAddress returns the nth address of the IPv4 network.
+func nthUEAddress(network *net.IPNet, n uint32) net.IP {
+	ip := slices.Clone(network.IP.To4())
+	binary.BigEndian.PutUint32(ip, binary.BigEndian.Uint32(ip)+n)
+
+	return ip
+}
+
+// nthUEIPv6Prefix returns the nth /64 prefix of the IPv6 network.
+func nthUEIPv6Prefix(network *net.IPNet, n uint64) net.IP {
+	ip := slices.Clone(network.IP.To16())
+	binary.BigEndian.PutUint64(ip, binary.BigEndian.Uint64(ip)+n)
+
+	return ip
+}
+
+// chooseUEIPAddress returns the UE IP Address of the Create PDR pdr if it asks the UPF to allocate
+// the UE IPv4 address or IPv6 prefix (CHV4 or CHV6 flag), nil otherwise.
+func chooseUEIPAddress(pdr *ie.IE) *ie.UEIPAddressFields {
+	ueIP, err := pdr.UEIPAddress()
+	if err != nil || ueIP.Flags&(session.UEIPAddressCHV4|session.UEIPAddressCHV6) == 0 {
+		return nil
+	}
+
+	return ueIP
+}
+
+// chooseFTEID returns the F-TEID of the Create PDR pdr if it asks the UPF to allocate it (CH flag), nil otherwise.
+func chooseFTEID(pdr *ie.IE) *ie.FTEIDFields {
+	pdi, err := pdr.PDI()
+	if err != nil {
+		return nil
+	}
+
+	for _, i := range pdi {
+		if i.Type != ie.FTEID {
+			continue
+		}
+
+		if fteid, err := i.FTEID(); err == nil && fteid.HasCh() {
+			return fteid
+		}
+	}
+
+	return nil
+}
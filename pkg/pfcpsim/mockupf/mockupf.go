@@ -0,0 +1,510 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+// Package mockupf implements a minimal UPF answering PFCP requests on N4. It keeps the
+// association state and the rules of each session, so that PFCP clients can be tested
+// without a real UPF.
+package mockupf
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/omec-project/pfcpsim/logger"
+	"github.com/wmnsk/go-pfcp/ie"
+	"github.com/wmnsk/go-pfcp/message"
+)
+
+var (
+	errNotAssociated = errors.New("no CP function is associated")
+	errNoRemotePeers = errors.New("no remote GTP-U peer")
+)
+
+// DefaultAddress is the address the mock UPF listens on if none is provided.
+const DefaultAddress = "127.0.0.1:8805"
+
+// maxPFCPMessageSize is larger than any message sent by pfcpsim.
+const maxPFCPMessageSize = 3000
+
+// UE address pools the mock UPF allocates from when the CP function sets the CHV4 or CHV6 flag.
+// Addresses are not reused.
+const (
+	DefaultUEPool   = "10.250.0.0/16"
+	DefaultUEPoolV6 = "2001:db8:250::/48"
+)
+
+// UP Function Features advertised by default: FTUP (octet 5, F-TEID allocation) and UEIP (octet 7, UE IP
+// address allocation), as the mock UPF allocates both. Refer to section 8.2.25 in PFCP specs Release 16
+var defaultUPFunctionFeatures = []uint8{0x10, 0x00, 0x04}
+
+// Node Report Type and Remote GTP-U Peer IE flags. Refer to sections 8.2.69 and 8.2.70 in PFCP specs Release 16
+const (
+	nodeReportTypeUPFR uint8 = 0x01
+	nodeReportTypeUPRR uint8 = 0x02
+	remoteGTPUPeerV6   uint8 = 0x01
+	remoteGTPUPeerV4   uint8 = 0x02
+)
+
+// MockUPF answers Heartbeat, Association Setup/Update/Release and Session Establishment/Modification/Deletion
+// Requests, allocating SEIDs, F-TEIDs and UE addresses and storing the rules of each session.
+type MockUPF struct {
+	addr   string
+	nodeID string
+
+	conn *net.UDPConn
+	done chan struct{}
+	wg   sync.WaitGroup
+
+	lock              sync.Mutex
+	recoveryTimeStamp time.Time
+	// peer is the address of the last CP function that set up an association
+	peer       net.Addr
+	associated bool
+	lastSEID   uint64
+	lastTEID   uint32
+	sessions   map[uint64]*Session
+	// uePool and uePoolV6 are the networks UE addresses are allocated from
+	uePool           *net.IPNet
+	uePoolV6         *net.IPNet
+	lastUEAddress    uint32
+	lastUEIPv6Prefix uint64
+	// received counts the messages received by message type
+	received map[uint8]int
+	faults   []*faultState
+	// dlDataDelay is the delay of the Downlink Data Reports sent for buffering FARs, 0 if disabled
+	dlDataDelay time.Duration
+	// lastSequence is the sequence number of the last request sent by the mock UPF
+	lastSequence uint32
+	// upFeatures are advertised to the CP function, cpFeatures are the last advertised by the CP function
+	upFeatures []uint8
+	cpFeatures []uint8
+}
+
+// NewMockUPF returns a mock UPF listening on addr (host:port). Port 0 picks a free port.
+// The Node ID advertised to the CP function is the host part of addr.
+func NewMockUPF(addr string) *MockUPF {
+	nodeID := addr
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		nodeID = host
+	}
+
+	_, uePool, _ := net.ParseCIDR(DefaultUEPool)
+	_, uePoolV6, _ := net.ParseCIDR(DefaultUEPoolV6)
+
+	return &MockUPF{
+		addr:       addr,
+		nodeID:     strings.Trim(nodeID, "[]"),
+		sessions:   make(map[uint64]*Session),
+		uePool:     uePool,
+		uePoolV6:   uePoolV6,
+		received:   make(map[uint8]int),
+		upFeatures: slices.Clone(defaultUPFunctionFeatures),
+	}
+}
+
+// SetNodeID overrides the Node ID advertised to the CP function.
+func (u *MockUPF) SetNodeID(nodeID string) {
+	u.nodeID = nodeID
+}
+
+// SetUPFunctionFeatures sets the octets of the UP Function Features IE sent in Association Setup Responses,
+// FTUP and UEIP by default. The IE is not sent if features is empty.
+func (u *MockUPF) SetUPFunctionFeatures(features ...uint8) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	u.upFeatures = slices.Clone(features)
+}
+
+// CPFunctionFeatures returns the octets of the last CP Function Features IE received from the CP function.
+func (u *MockUPF) CPFunctionFeatures() []uint8 {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	return slices.Clone(u.cpFeatures)
+}
+
+// Start opens the N4 socket and starts serving requests.
+func (u *MockUPF) Start() error {
+	laddr, err := net.ResolveUDPAddr("udp", u.addr)
+	if err != nil {
+		return err
+	}
+
+	conn, err := net.ListenUDP("udp", laddr)
+	if err != nil {
+		return err
+	}
+
+	u.lock.Lock()
+	u.recoveryTimeStamp = time.Now()
+	u.lock.Unlock()
+
+	u.conn = conn
+	u.done = make(chan struct{})
+
+	u.wg.Add(1)
+
+	go u.serve()
+
+	logger.PfcpsimLog.Infof("mock UPF listening on %v", conn.LocalAddr())
+
+	return nil
+}
+
+// Stop closes the N4 socket and waits for the pending requests to be served. Delayed responses are discarded.
+func (u *MockUPF) Stop() {
+	if u.conn == nil {
+		return
+	}
+
+	close(u.done)
+
+	if err := u.conn.Close(); err != nil {
+		logger.PfcpsimLog.Warnln(err)
+	}
+
+	u.wg.Wait()
+	u.conn = nil
+}
+
+// Addr returns the address the mock UPF is listening on, useful when started on port 0.
+func (u *MockUPF) Addr() string {
+	if u.conn == nil {
+		return u.addr
+	}
+
+	return u.conn.LocalAddr().String()
+}
+
+// IsAssociated reports whether a CP function set up an association.
+func (u *MockUPF) IsAssociated() bool {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	return u.associated
+}
+
+// RecoveryTimeStamp returns the Recovery Time Stamp advertised by the mock UPF.
+func (u *MockUPF) RecoveryTimeStamp() time.Time {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	return u.recoveryTimeStamp
+}
+
+// SessionsNum returns the number of installed sessions.
+func (u *MockUPF) SessionsNum() int {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	return len(u.sessions)
+}
+
+// Session returns a copy of the session identified by seid, the SEID allocated by the mock UPF.
+func (u *MockUPF) Session(seid uint64) (*Session, bool) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	sess, ok := u.sessions[seid]
+	if !ok {
+		return nil, false
+	}
+
+	return sess.clone(), true
+}
+
+// Sessions returns a copy of the installed sessions ordered by SEID.
+func (u *MockUPF) Sessions() []*Session {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	sessions := make([]*Session, 0, len(u.sessions))
+	for _, sess := range u.sessions {
+		sessions = append(sessions, sess.clone())
+	}
+
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].SEID < sessions[j].SEID })
+
+	return sessions
+}
+
+// Received returns the number of messages of type msgType received so far.
+func (u *MockUPF) Received(msgType uint8) int {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	return u.received[msgType]
+}
+
+// SetupAssociation sends an Association Setup Request to the CP function at cpAddr (host:port), as UPFs
+// initiating the association do. The association is set up once the CP function accepted the request.
+func (u *MockUPF) SetupAssociation(cpAddr string) error {
+	raddr, err := net.ResolveUDPAddr("udp", cpAddr)
+	if err != nil {
+		return err
+	}
+
+	u.lock.Lock()
+	u.lastSequence++
+	ies := []*ie.IE{
+		ie.NewNodeIDHeuristic(u.nodeID),
+		ie.NewRecoveryTimeStamp(u.recoveryTimeStamp),
+	}
+
+	if len(u.upFeatures) != 0 {
+		ies = append(ies, ie.NewUPFunctionFeatures(u.upFeatures...))
+	}
+
+	req := message.NewAssociationSetupRequest(u.lastSequence, ies...)
+	u.lock.Unlock()
+
+	return u.send(req, raddr)
+}
+
+// SendHeartbeat sends a Heartbeat Request to the CP function at cpAddr (host:port), whether associated or not.
+// The response is not awaited.
+func (u *MockUPF) SendHeartbeat(cpAddr string) error {
+	raddr, err := net.ResolveUDPAddr("udp", cpAddr)
+	if err != nil {
+		return err
+	}
+
+	u.lock.Lock()
+	u.lastSequence++
+	req := message.NewHeartbeatRequest(u.lastSequence, ie.NewRecoveryTimeStamp(u.recoveryTimeStamp), nil)
+	u.lock.Unlock()
+
+	return u.send(req, raddr)
+}
+
+// UpdateAssociation sends an Association Update Request carrying ies to the associated CP function, e.g. new
+// UP Function Features, or a PFCP Association Release Request with a Graceful Release Period. New UP Function
+// Features are advertised in the following Association Setup Responses too. The response is not awaited.
+func (u *MockUPF) UpdateAssociation(ies ...*ie.IE) error {
+	return u.sendRequest(func(seq uint32) message.Message {
+		for _, i := range ies {
+			if features, err := i.UPFunctionFeatures(); err == nil {
+				u.upFeatures = slices.Clone(features)
+			}
+		}
+
+		return message.NewAssociationUpdateRequest(seq, append([]*ie.IE{ie.NewNodeIDHeuristic(u.nodeID)}, ies...)...)
+	})
+}
+
+// ReleaseAssociation sends an Association Release Request to the associated CP function. The association and
+// the sessions are released without waiting for the response.
+func (u *MockUPF) ReleaseAssociation() error {
+	return u.sendRequest(func(seq uint32) message.Message {
+		u.associated = false
+		u.sessions = make(map[uint64]*Session)
+
+		return message.NewAssociationReleaseRequest(seq, ie.NewNodeIDHeuristic(u.nodeID))
+	})
+}
+
+// ReportUserPlanePath sends a Node Report Request to the associated CP function, reporting a failure of the user
+// plane path towards the remote GTP-U peers peers, or its recovery if recovered is set.
+func (u *MockUPF) ReportUserPlanePath(recovered bool, peers ...string) error {
+	if len(peers) == 0 {
+		return errNoRemotePeers
+	}
+
+	remotePeers := make([]*ie.IE, 0, len(peers))
+
+	for _, peer := range peers {
+		ip := net.ParseIP(peer)
+		if ip == nil {
+			return fmt.Errorf("invalid remote GTP-U peer %q", peer)
+		}
+
+		if ip.To4() != nil {
+			remotePeers = append(remotePeers, ie.NewRemoteGTPUPeer(remoteGTPUPeerV4, peer, "", 0, ""))
+		} else {
+			remotePeers = append(remotePeers, ie.NewRemoteGTPUPeer(remoteGTPUPeerV6, "", peer, 0, ""))
+		}
+	}
+
+	report := ie.NewUserPlanePathFailureReport(remotePeers[0])
+	reportType := nodeReportTypeUPFR
+
+	if recovered {
+		report = ie.NewUserPlanePathRecoveryReport(remotePeers[0])
+		reportType = nodeReportTypeUPRR
+	}
+
+	for _, peer := range remotePeers[1:] {
+		report.Add(peer)
+	}
+
+	return u.sendRequest(func(seq uint32) message.Message {
+		return message.NewNodeReportRequest(seq,
+			ie.NewNodeIDHeuristic(u.nodeID),
+			ie.NewNodeReportType(reportType),
+			report,
+		)
+	})
+}
+
+// sendRequest sends the request returned by build, called with the lock held, to the associated CP function.
+// The response is not awaited.
+func (u *MockUPF) sendRequest(build func(seq uint32) message.Message) error {
+	u.lock.Lock()
+
+	if !u.associated || u.peer == nil {
+		u.lock.Unlock()
+		return errNotAssociated
+	}
+
+	u.lastSequence++
+	peer := u.peer
+	req := build(u.lastSequence)
+	u.lock.Unlock()
+
+	return u.send(req, peer)
+}
+
+func (u *MockUPF) serve() {
+	defer u.wg.Done()
+
+	buf := make([]byte, maxPFCPMessageSize)
+
+	for {
+		n, raddr, err := u.conn.ReadFrom(buf)
+		if err != nil {
+			select {
+			case <-u.done:
+				return
+			default:
+			}
+
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+
+			continue
+		}
+
+		msg, err := message.Parse(buf[:n])
+		if err != nil {
+			logger.PfcpsimLog.Warnf("mock UPF could not parse message from %v: %v", raddr, err)
+			continue
+		}
+
+		u.lock.Lock()
+		u.received[msg.MessageType()]++
+		u.lock.Unlock()
+
+		u.respond(msg, raddr)
+	}
+}
+
+// respond answers msg, applying the first injected fault matching it.
+func (u *MockUPF) respond(msg message.Message, raddr net.Addr) {
+	fault := u.matchFault(msg)
+	if fault == nil {
+		fault = &Fault{}
+	}
+
+	if fault.Drop {
+		logger.PfcpsimLog.Debugf("mock UPF dropping %v with sequence number %v", msg.MessageTypeName(), msg.Sequence())
+		return
+	}
+
+	var rsp message.Message
+	if fault.Cause != 0 {
+		rsp = u.newRejection(msg, fault.Cause)
+	}
+
+	if rsp == nil {
+		rsp = u.handle(msg, raddr)
+	}
+
+	if rsp == nil {
+		return
+	}
+
+	if fault.WrongType {
+		rsp = u.newWrongTypeResponse(rsp)
+	}
+
+	copies := 1
+	if fault.Duplicate {
+		copies = 2
+	}
+
+	delay := fault.delay()
+	if delay == 0 {
+		u.sendCopies(rsp, raddr, copies)
+		return
+	}
+
+	// delayed responses do not hold back the following requests
+	u.wg.Add(1)
+
+	go func() {
+		defer u.wg.Done()
+
+		select {
+		case <-time.After(delay):
+			u.sendCopies(rsp, raddr, copies)
+		case <-u.done:
+		}
+	}()
+}
+
+func (u *MockUPF) sendCopies(rsp message.Message, raddr net.Addr, copies int) {
+	for range copies {
+		if err := u.send(rsp, raddr); err != nil {
+			logger.PfcpsimLog.Warnf("mock UPF could not send %v: %v", rsp.MessageTypeName(), err)
+		}
+	}
+}
+
+func (u *MockUPF) send(msg message.Message, raddr net.Addr) error {
+	b := make([]byte, msg.MarshalLen())
+	if err := msg.MarshalTo(b); err != nil {
+		return err
+	}
+
+	_, err := u.conn.WriteTo(b, raddr)
+
+	return err
+}
+
+// handle returns the response to msg, or nil if msg is not answered.
+func (u *MockUPF) handle(msg message.Message, raddr net.Addr) message.Message {
+	switch msg := msg.(type) {
+	case *message.HeartbeatRequest:
+		return message.NewHeartbeatResponse(msg.Sequence(), ie.NewRecoveryTimeStamp(u.RecoveryTimeStamp()))
+	case *message.AssociationSetupRequest:
+		return u.handleAssociationSetupRequest(msg, raddr)
+	case *message.AssociationReleaseRequest:
+		return u.handleAssociationReleaseRequest(msg)
+	case *message.AssociationUpdateRequest:
+		return u.handleAssociationUpdateRequest(msg)
+	case *message.AssociationSetupResponse:
+		u.handleAssociationSetupResponse(msg, raddr)
+		return nil
+	case *message.SessionEstablishmentRequest:
+		return u.handleSessionEstablishmentRequest(msg)
+	case *message.SessionModificationRequest:
+		return u.handleSessionModificationRequest(msg)
+	case *message.SessionDeletionRequest:
+		return u.handleSessionDeletionRequest(msg)
+	case *message.HeartbeatResponse, *message.SessionReportResponse, *message.AssociationUpdateResponse,
+		*message.AssociationReleaseResponse, *message.NodeReportResponse:
+		// answers to the requests sent by the mock UPF, nothing to do
+		return nil
+	default:
+		logger.PfcpsimLog.Debugf("mock UPF ignoring %v", msg.MessageTypeName())
+		return nil
+	}
+}
@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package mockupf
+
+import (
+	"slices"
+	"time"
+
+	"github.com/omec-project/pfcpsim/logger"
+	"github.com/wmnsk/go-pfcp/ie"
+	"github.com/wmnsk/go-pfcp/message"
+)
+
+// SetDownlinkDataReportDelay makes the mock UPF emulate downlink data arriving for idle UEs: delay after
+// a Session Modification setting a FAR to buffer and notify the CP function, a Session Report Request
+// carrying a Downlink Data Report is sent for the PDRs using the FAR. A zero delay disables the reports.
+func (u *MockUPF) SetDownlinkDataReportDelay(delay time.Duration) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	u.dlDataDelay = delay
+}
+
+// bufferingPDRs returns the IDs of the PDRs of sess using one of the FARs set to buffer and notify by updates.
+func bufferingPDRs(sess *Session, updates []*ie.IE) []uint16 {
+	var farIDs []uint32
+
+	for _, far := range updates {
+		if !far.HasBUFF() || !far.HasNOCP() {
+			continue
+		}
+
+		if id, err := far.FARID(); err == nil {
+			farIDs = append(farIDs, id)
+		}
+	}
+
+	var pdrIDs []uint16
+
+	for id, pdr := range sess.PDRs {
+		farID, err := pdr.FARID()
+		if err == nil && slices.Contains(farIDs, farID) {
+			pdrIDs = append(pdrIDs, id)
+		}
+	}
+
+	slices.Sort(pdrIDs)
+
+	return pdrIDs
+}
+
+// scheduleDownlinkDataReport sends, after the configured delay, a Downlink Data Report for the PDRs
+// pdrIDs of the session identified by seid. The report is not sent if the session was deleted meanwhile.
+// It must be called with the lock held.
+func (u *MockUPF) scheduleDownlinkDataReport(seid uint64, pdrIDs []uint16) {
+	if u.dlDataDelay == 0 || len(pdrIDs) == 0 || u.peer == nil {
+		return
+	}
+
+	delay := u.dlDataDelay
+	peer := u.peer
+
+	u.wg.Add(1)
+
+	go func() {
+		defer u.wg.Done()
+
+		select {
+		case <-time.After(delay):
+		case <-u.done:
+			return
+		}
+
+		req := u.newDownlinkDataReport(seid, pdrIDs)
+		if req == nil {
+			return
+		}
+
+		if err := u.send(req, peer); err != nil {
+			logger.PfcpsimLog.Warnf("mock UPF could not send %v: %v", req.MessageTypeName(), err)
+		}
+	}()
+}
+
+// newDownlinkDataReport returns the Session Report Request carrying a Downlink Data Report for the PDRs
+// pdrIDs of the session identified by seid, or nil if the session does not exist anymore.
+func (u *MockUPF) newDownlinkDataReport(seid uint64, pdrIDs []uint16) message.Message {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	sess, ok := u.sessions[seid]
+	if !ok {
+		return nil
+	}
+
+	u.lastSequence++
+
+	pdrs := make([]*ie.IE, 0, len(pdrIDs))
+	for _, id := range pdrIDs {
+		pdrs = append(pdrs, ie.NewPDRID(id))
+	}
+
+	return message.NewSessionReportRequest(0, 0, sess.CPSEID, u.lastSequence, 0,
+		ie.NewReportType(0, 0, 0, 1),
+		ie.NewDownlinkDataReport(pdrs...),
+	)
+}
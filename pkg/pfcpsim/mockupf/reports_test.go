@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package mockupf
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/omec-project/pfcpsim/pkg/pfcpsim/session"
+	"github.com/wmnsk/go-pfcp/ie"
+)
+
+func TestBufferingPDRs(t *testing.T) {
+	sess := newSession(1, 1)
+
+	for id, farID := range map[uint16]uint32{1: 1, 2: 2, 3: 2} {
+		sess.PDRs[id] = session.NewPDRBuilder().
+			WithID(id).
+			WithMethod(session.Create).
+			WithFARID(farID).
+			AddQERID(1).
+			WithUEAddress("17.0.0.1").
+			MarkAsDownlink().
+			BuildPDR()
+	}
+
+	newUpdateFAR := func(id uint32, action uint8) *ie.IE {
+		return session.NewFARBuilder().
+			WithID(id).
+			WithMethod(session.Update).
+			WithAction(action).
+			WithDstInterface(ie.DstInterfaceAccess).
+			BuildFAR()
+	}
+
+	tests := []struct {
+		name    string
+		updates []*ie.IE
+		want    []uint16
+	}{
+		{
+			name:    "buffer and notify",
+			updates: []*ie.IE{newUpdateFAR(2, session.ActionBuffer|session.ActionNotify)},
+			want:    []uint16{2, 3},
+		},
+		{
+			name:    "buffer without notify",
+			updates: []*ie.IE{newUpdateFAR(2, session.ActionBuffer)},
+		},
+		{
+			name:    "forward",
+			updates: []*ie.IE{newUpdateFAR(1, session.ActionForward)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bufferingPDRs(sess, tt.updates); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("bufferingPDRs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package mockupf
+
+import (
+	"fmt"
+	"maps"
+	"net"
+
+	"github.com/omec-project/pfcpsim/pkg/pfcpsim/session"
+	"github.com/wmnsk/go-pfcp/ie"
+)
+
+// Session is a PFCP session installed in the mock UPF.
+type Session struct {
+	// SEID is the SEID allocated by the mock UPF
+	SEID uint64
+	// CPSEID is the SEID allocated by the CP function
+	CPSEID uint64
+
+	// PDRs, FARs, QERs and URRs are indexed by rule ID. Updates are merged into the stored Create IE.
+	PDRs map[uint16]*ie.IE
+	FARs map[uint32]*ie.IE
+	QERs map[uint32]*ie.IE
+	URRs map[uint32]*ie.IE
+
+	// TEIDs are the TEIDs allocated by the mock UPF to the PDRs created with F-TEID CHOOSE, indexed by PDR ID
+	TEIDs map[uint16]uint32
+	// UEAddress and UEIPv6Prefix are allocated by the mock UPF for the PDRs created with the CHV4 and CHV6 flags
+	UEAddress    net.IP
+	UEIPv6Prefix *net.IPNet
+
+	// urSEQN is the sequence number of the last usage report sent for the session
+	urSEQN uint32
+}
+
+func newSession(seid, cpSEID uint64) *Session {
+	return &Session{
+		SEID:   seid,
+		CPSEID: cpSEID,
+		PDRs:   make(map[uint16]*ie.IE),
+		FARs:   make(map[uint32]*ie.IE),
+		QERs:   make(map[uint32]*ie.IE),
+		URRs:   make(map[uint32]*ie.IE),
+		TEIDs:  make(map[uint16]uint32),
+	}
+}
+
+// clone returns a copy of s that can be handed out without holding the MockUPF lock.
+func (s *Session) clone() *Session {
+	return &Session{
+		SEID:   s.SEID,
+		CPSEID: s.CPSEID,
+		PDRs:   maps.Clone(s.PDRs),
+		FARs:   maps.Clone(s.FARs),
+		QERs:   maps.Clone(s.QERs),
+		URRs:   maps.Clone(s.URRs),
+		TEIDs:  maps.Clone(s.TEIDs),
+
+		UEAddress:    s.UEAddress,
+		UEIPv6Prefix: s.UEIPv6Prefix,
+
+		urSEQN: s.urSEQN,
+	}
+}
+
+// ruleError is returned when a rule cannot be created, updated or removed.
+type ruleError struct {
+	ieType uint16
+	id     uint32
+	reason string
+}
+
+func (e *ruleError) Error() string {
+	return fmt.Sprintf("rule %v of IE type %v: %v", e.id, e.ieType, e.reason)
+}
+
+// apply creates, updates or removes the rules carried by ies, depending on their IE type.
+// It stops at the first rule that cannot be applied.
+func (s *Session) apply(ies ...*ie.IE) error {
+	for _, i := range ies {
+		var err error
+
+		switch i.Type {
+		case ie.CreatePDR, ie.UpdatePDR, ie.RemovePDR:
+			err = applyRule(s.PDRs, i, ie.CreatePDR, ie.UpdatePDR, (*ie.IE).PDRID)
+		case ie.CreateFAR, ie.UpdateFAR, ie.RemoveFAR:
+			err = applyRule(s.FARs, i, ie.CreateFAR, ie.UpdateFAR, (*ie.IE).FARID)
+		case ie.CreateQER, ie.UpdateQER, ie.RemoveQER:
+			err = applyRule(s.QERs, i, ie.CreateQER, ie.UpdateQER, (*ie.IE).QERID)
+		case ie.CreateURR, ie.UpdateURR, ie.RemoveURR:
+			err = applyRule(s.URRs, i, ie.CreateURR, ie.UpdateURR, (*ie.IE).URRID)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// usageReportTriggerIMMER is the Usage Report Trigger bit of the reports answering a Query URR.
+const usageReportTriggerIMMER = 0x80
+
+// queryURRs returns the usage reports answering the Query URR IEs queries. As the mock UPF forwards
+// no traffic, the reported volumes are zero. Querying a URR not installed in the session is an error.
+func (s *Session) queryURRs(queries []*ie.IE) ([]*ie.IE, error) {
+	reports := make([]*ie.IE, 0, len(queries))
+
+	for _, query := range queries {
+		id, err := query.URRID()
+		if err != nil {
+			return nil, &ruleError{ieType: query.Type, reason: err.Error()}
+		}
+
+		if _, ok := s.URRs[id]; !ok {
+			return nil, &ruleError{ieType: query.Type, id: id, reason: "not found"}
+		}
+
+		s.urSEQN++
+
+		reports = append(reports, ie.NewUsageReportWithinSessionModificationResponse(
+			ie.NewURRID(id),
+			ie.NewURSEQN(s.urSEQN),
+			ie.NewUsageReportTrigger(usageReportTriggerIMMER, 0, 0),
+			ie.NewVolumeMeasurement(0x07, 0, 0, 0, 0, 0, 0),
+		))
+	}
+
+	return reports, nil
+}
+
+func applyRule[K uint16 | uint32](table map[K]*ie.IE, rule *ie.IE, createType, updateType uint16,
+	getID func(*ie.IE) (K, error),
+) error {
+	id, err := ruleID(rule, getID)
+	if err != nil {
+		return &ruleError{ieType: rule.Type, reason: err.Error()}
+	}
+
+	stored, exists := table[id]
+
+	switch rule.Type {
+	case createType:
+		if exists {
+			return &ruleError{ieType: rule.Type, id: uint32(id), reason: "already exists"}
+		}
+
+		table[id] = rule
+	case updateType:
+		if !exists {
+			return &ruleError{ieType: rule.Type, id: uint32(id), reason: "not found"}
+		}
+
+		merged, err := session.MergeRule(stored, rule)
+		if err != nil {
+			return &ruleError{ieType: rule.Type, id: uint32(id), reason: err.Error()}
+		}
+
+		table[id] = merged
+	default:
+		if !exists {
+			return &ruleError{ieType: rule.Type, id: uint32(id), reason: "not found"}
+		}
+
+		delete(table, id)
+	}
+
+	return nil
+}
+
+// ruleID reads the rule ID of rule. Remove IEs may carry either the rule ID or the whole Create IE.
+func ruleID[K uint16 | uint32](rule *ie.IE, getID func(*ie.IE) (K, error)) (K, error) {
+	id, err := getID(rule)
+	if err == nil {
+		return id, nil
+	}
+
+	children, parseErr := ie.ParseMultiIEs(rule.Payload)
+	if parseErr != nil || len(children) == 0 {
+		return 0, err
+	}
+
+	return getID(children[0])
+}
@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package mockupf
+
+import (
+	"testing"
+
+	"github.com/omec-project/pfcpsim/pkg/pfcpsim/session"
+	"github.com/wmnsk/go-pfcp/ie"
+)
+
+func newFAR(id uint32, method session.IEMethod) *ie.IE {
+	return session.NewFARBuilder().
+		WithID(id).
+		WithMethod(method).
+		WithAction(session.ActionForward).
+		WithDstInterface(ie.DstInterfaceAccess).
+		WithTEID(100 + id).
+		WithDownlinkIP("198.18.0.10").
+		BuildFAR()
+}
+
+func TestSessionApply(t *testing.T) {
+	tests := []struct {
+		name     string
+		rules    []*ie.IE
+		wantFARs int
+		wantErr  bool
+	}{
+		{
+			name:     "create",
+			rules:    []*ie.IE{newFAR(1, session.Create), newFAR(2, session.Create)},
+			wantFARs: 2,
+		},
+		{
+			name:    "create existing rule",
+			rules:   []*ie.IE{newFAR(1, session.Create), newFAR(1, session.Create)},
+			wantErr: true,
+		},
+		{
+			name:     "update",
+			rules:    []*ie.IE{newFAR(1, session.Create), newFAR(1, session.Update)},
+			wantFARs: 1,
+		},
+		{
+			name:    "update unknown rule",
+			rules:   []*ie.IE{newFAR(1, session.Update)},
+			wantErr: true,
+		},
+		{
+			name:     "remove",
+			rules:    []*ie.IE{newFAR(1, session.Create), newFAR(1, session.Delete)},
+			wantFARs: 0,
+		},
+		{
+			name:    "remove unknown rule",
+			rules:   []*ie.IE{newFAR(1, session.Delete)},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sess := newSession(1, 1)
+
+			err := sess.apply(tt.rules...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("apply() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && len(sess.FARs) != tt.wantFARs {
+				t.Errorf("FARs mismatch. got = %v, want = %v", len(sess.FARs), tt.wantFARs)
+			}
+		})
+	}
+}
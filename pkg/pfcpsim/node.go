@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"fmt"
+	"net"
+	"slices"
+
+	"github.com/omec-project/pfcpsim/logger"
+	ieLib "github.com/wmnsk/go-pfcp/ie"
+	"github.com/wmnsk/go-pfcp/message"
+)
+
+// handleAssociationReleaseRequest answers an Association Release Request sent by the peer from raddr. Once released,
+// the association is down and every active session is marked as released, as the peer deleted them.
+func (c *PFCPClient) handleAssociationReleaseRequest(msg *message.AssociationReleaseRequest, raddr net.Addr) {
+	cause := uint8(ieLib.CauseRequestAccepted)
+
+	switch {
+	case !c.IsAssociationAlive():
+		cause = ieLib.CauseNoEstablishedPFCPAssociation
+	case msg.NodeID == nil:
+		cause = ieLib.CauseMandatoryIEMissing
+	}
+
+	res := message.NewAssociationReleaseResponse(msg.Sequence(),
+		newNodeID(c.localAddr),
+		ieLib.NewCause(cause),
+	)
+
+	if err := c.sendMsgTo(res, raddr); err != nil {
+		logger.PfcpsimLog.Errorln("Error sending Association Release Response:", err)
+	}
+
+	if cause != ieLib.CauseRequestAccepted {
+		logger.PfcpsimLog.Warnf("rejected Association Release Request with sequence number %v, cause %v",
+			msg.Sequence(), cause)
+
+		return
+	}
+
+	c.peerReleased.Store(true)
+
+	sessions := getSessions()
+	for _, sess := range sessions {
+		sess.setReleased()
+	}
+
+	details := fmt.Sprintf("association released by the peer, %v sessions released", len(sessions))
+	logger.PfcpsimLog.Warnln(details)
+	c.setAssociationStatus(false, details)
+}
+
+// handleNodeReportRequest answers a Node Report Request sent by the peer from raddr. The sessions whose gNB is a
+// remote GTP-U peer of a User Plane Path Failure Report are marked, until a User Plane Path Recovery Report for the
+// peer is received.
+func (c *PFCPClient) handleNodeReportRequest(msg *message.NodeReportRequest, raddr net.Addr) {
+	cause := uint8(ieLib.CauseRequestAccepted)
+	if msg.NodeID == nil || msg.NodeReportType == nil {
+		cause = ieLib.CauseMandatoryIEMissing
+	}
+
+	res := message.NewNodeReportResponse(msg.Sequence(),
+		newNodeID(c.localAddr),
+		ieLib.NewCause(cause),
+		nil,
+	)
+
+	if err := c.sendMsgTo(res, raddr); err != nil {
+		logger.PfcpsimLog.Errorln("Error sending Node Report Response:", err)
+	}
+
+	if cause != ieLib.CauseRequestAccepted {
+		logger.PfcpsimLog.Warnf("rejected Node Report Request with sequence number %v, cause %v", msg.Sequence(), cause)
+		return
+	}
+
+	failed := remoteGTPUPeers(msg.UserPlanePathFailureReport)
+	recovered := remoteGTPUPeers(msg.UserPlanePathRecoveryReport)
+
+	if len(failed) == 0 && len(recovered) == 0 {
+		details := fmt.Sprintf("Node Report Request with sequence number %v received", msg.Sequence())
+		logger.PfcpsimLog.Infoln(details)
+		c.emitEvent(EventNodeReport, msg, details)
+
+		return
+	}
+
+	if len(failed) != 0 {
+		affected := setUserPlanePathFailure(failed, true)
+		details := fmt.Sprintf("user plane path failure towards remote GTP-U peers %v, %v sessions affected",
+			failed, affected)
+		logger.PfcpsimLog.Warnln(details)
+		c.emitPeersEvent(EventUserPlanePathFailure, msg, details, failed)
+	}
+
+	if len(recovered) != 0 {
+		affected := setUserPlanePathFailure(recovered, false)
+		details := fmt.Sprintf("user plane path recovered towards remote GTP-U peers %v, %v sessions affected",
+			recovered, affected)
+		logger.PfcpsimLog.Infoln(details)
+		c.emitPeersEvent(EventUserPlanePathRecovery, msg, details, recovered)
+	}
+}
+
+// setUserPlanePathFailure marks or unmarks the user plane path failure of the active sessions whose gNB is one
+// of peers. Returns the number of sessions affected.
+func setUserPlanePathFailure(peers []string, failed bool) int {
+	affected := 0
+
+	for _, sess := range getSessions() {
+		if address, _ := sess.GNBTunnel(); slices.Contains(peers, address) {
+			sess.setUserPlanePathFailure(failed)
+			affected++
+		}
+	}
+
+	return affected
+}
+
+// remoteGTPUPeers returns the addresses of the Remote GTP-U Peers of a User Plane Path Failure or Recovery
+// Report. Returns nil if report is nil or malformed.
+func remoteGTPUPeers(report *ieLib.IE) []string {
+	if report == nil {
+		return nil
+	}
+
+	var (
+		ies []*ieLib.IE
+		err error
+	)
+
+	if report.Type == ieLib.UserPlanePathRecoveryReport {
+		ies, err = report.UserPlanePathRecoveryReport()
+	} else {
+		ies, err = report.UserPlanePathFailureReport()
+	}
+
+	if err != nil {
+		logger.PfcpsimLog.Warnf("could not parse user plane path report: %v", err)
+		return nil
+	}
+
+	var peers []string
+
+	for _, ie := range ies {
+		if ie.Type != ieLib.RemoteGTPUPeer {
+			continue
+		}
+
+		peer, err := ie.RemoteGTPUPeer()
+		if err != nil {
+			logger.PfcpsimLog.Warnf("could not parse Remote GTP-U Peer: %v", err)
+			continue
+		}
+
+		if peer.IPv4Address != nil {
+			peers = append(peers, peer.IPv4Address.String())
+		}
+
+		if peer.IPv6Address != nil {
+			peers = append(peers, peer.IPv6Address.String())
+		}
+	}
+
+	return peers
+}
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/omec-project/pfcpsim/logger"
+	"github.com/omec-project/pfcpsim/pkg/pfcpsim/session"
+	ieLib "github.com/wmnsk/go-pfcp/ie"
+)
+
+// maxPagings is the number of pagings kept for each session. Older pagings are discarded.
+const maxPagings = 256
+
+var errNoGNBTunnel = errors.New("no gNB tunnel known for the session")
+
+// Paging is the emulation of the paging of an idle UE, triggered by a Downlink Data Report.
+type Paging struct {
+	// ReportedAt is the time the Session Report Request carrying the Downlink Data Report was received.
+	ReportedAt time.Time
+	// CompletedAt is the time the downlink FARs were switched back to forward.
+	CompletedAt time.Time
+	// Latency is the end-to-end paging latency, from ReportedAt to CompletedAt. It includes the paging delay.
+	Latency time.Duration
+}
+
+// EnablePagingEmulation turns on or off the emulation of idle UEs. When enabled, a Downlink Data Report
+// received for a session is followed, after delay, by a Session Modification switching the downlink FARs
+// back to forward towards the gNB tunnel they were last set to, as the SMF does once the UE answered
+// the paging with a Service Request.
+func (c *PFCPClient) EnablePagingEmulation(enable bool, delay time.Duration) {
+	c.pagingDelay.Store(int64(delay))
+	c.pagingEmulation.Store(enable)
+}
+
+func (c *PFCPClient) IsPagingEmulationEnabled() bool {
+	return c.pagingEmulation.Load()
+}
+
+// PagingDelay returns the time waited between a Downlink Data Report and the Session Modification.
+func (c *PFCPClient) PagingDelay() time.Duration {
+	return time.Duration(c.pagingDelay.Load())
+}
+
+// page emulates the paging of the UE of sess, whose Downlink Data Report was received at reportedAt.
+// A Downlink Data Report received while the session is already being paged is ignored.
+func (c *PFCPClient) page(sess *PFCPSession, reportedAt time.Time) {
+	if !sess.startPaging() {
+		logger.PfcpsimLog.Debugf("session with local SEID %v is already being paged", sess.localSEID)
+		return
+	}
+
+	defer sess.endPaging()
+
+	select {
+	case <-time.After(c.PagingDelay()):
+	case <-c.ctx.Done():
+		return
+	}
+
+	if err := c.restoreDownlinkForwarding(sess); err != nil {
+		details := fmt.Sprintf("could not page session with local SEID %v: %v", sess.localSEID, err)
+		logger.PfcpsimLog.Errorln(details)
+		c.emitEvent(EventPagingFailed, nil, details)
+
+		return
+	}
+
+	paging := Paging{ReportedAt: reportedAt, CompletedAt: time.Now()}
+	paging.Latency = paging.CompletedAt.Sub(reportedAt)
+	sess.addPaging(paging)
+
+	details := fmt.Sprintf("session with local SEID %v paged in %v", sess.localSEID, paging.Latency)
+	logger.PfcpsimLog.Infoln(details)
+	c.emitEvent(EventPagingCompleted, nil, details)
+}
+
+// restoreDownlinkForwarding modifies sess so that its downlink FARs forward to the gNB tunnel they were last set to.
+func (c *PFCPClient) restoreDownlinkForwarding(sess *PFCPSession) error {
+	address, teid := sess.GNBTunnel()
+	if address == "" {
+		return errNoGNBTunnel
+	}
+
+	farIDs, _ := sess.DownlinkRules()
+	fars := make([]*ieLib.IE, 0, len(farIDs))
+
+	for _, farID := range farIDs {
+		fars = append(fars, session.NewFARBuilder().
+			WithID(farID).
+			WithMethod(session.Update).
+			WithAction(session.ActionForward).
+			WithDstInterface(ieLib.DstInterfaceAccess).
+			WithTEID(teid).
+			WithDownlinkIP(address).
+			BuildFAR(),
+		)
+	}
+
+	return c.ModifySession(sess, nil, fars, nil, nil)
+}
@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/omec-project/pfcpsim/logger"
+	ieLib "github.com/wmnsk/go-pfcp/ie"
+	"github.com/wmnsk/go-pfcp/message"
+)
+
+// EnablePassiveAssociation turns on or off the passive mode. When enabled, the client does not initiate the
+// Association Setup: it waits for an Association Setup Request sent by the peer and accepts it if the Node ID
+// of the peer is peerNodeID, or any Node ID if peerNodeID is empty. No Heartbeat Request is sent in passive
+// mode, the ones of the peer are answered instead, hence auto recovery has no effect.
+func (c *PFCPClient) EnablePassiveAssociation(enable bool, peerNodeID string) {
+	c.associationLock.Lock()
+	c.expectedPeerNodeID = peerNodeID
+	c.associationLock.Unlock()
+
+	c.passive.Store(enable)
+}
+
+func (c *PFCPClient) IsPassiveAssociationEnabled() bool {
+	return c.passive.Load()
+}
+
+// AcceptAssociation waits until an association is set up by the peer, or ctx is done.
+// Returns nil at once if an association is already established.
+func (c *PFCPClient) AcceptAssociation(ctx context.Context) error {
+	for !c.IsAssociationAlive() {
+		select {
+		case <-ctx.Done():
+			return NewTimeoutExpiredError(ctx.Err())
+		case <-c.associationAccepted:
+		}
+	}
+
+	return nil
+}
+
+// handleAssociationSetupRequest answers an Association Setup Request sent by the peer from raddr. Outside of
+// passive mode the request is reported as unsolicited.
+func (c *PFCPClient) handleAssociationSetupRequest(msg *message.AssociationSetupRequest, raddr net.Addr) {
+	if !c.IsPassiveAssociationEnabled() {
+		details := fmt.Sprintf("unsolicited %v with sequence number %v received", msg.MessageTypeName(), msg.Sequence())
+		logger.PfcpsimLog.Warnln(details)
+		c.emitEvent(EventUnsolicitedMessage, msg, details)
+
+		return
+	}
+
+	cause := uint8(ieLib.CauseRequestAccepted)
+
+	c.associationLock.Lock()
+	expected := c.expectedPeerNodeID
+	c.associationLock.Unlock()
+
+	switch {
+	case msg.NodeID == nil:
+		cause = ieLib.CauseMandatoryIEMissing
+	case !matchNodeID(msg.NodeID, expected):
+		cause = ieLib.CauseRequestRejected
+	}
+
+	res := message.NewAssociationSetupResponse(msg.Sequence(),
+		newNodeID(c.localAddr),
+		ieLib.NewCause(cause),
+		ieLib.NewRecoveryTimeStamp(c.recoveryTimeStamp),
+	)
+
+	if features := c.cpFunctionFeaturesIE(); features != nil && cause == ieLib.CauseRequestAccepted {
+		res.IEs = append(res.IEs, features)
+	}
+
+	// the response goes back to the source of the request, which may differ from the configured peer address
+	if err := c.sendMsgTo(res, raddr); err != nil {
+		logger.PfcpsimLog.Errorln("Error sending Association Setup Response:", err)
+		return
+	}
+
+	if cause != ieLib.CauseRequestAccepted {
+		logger.PfcpsimLog.Warnf("rejected Association Setup Request with sequence number %v, cause %v",
+			msg.Sequence(), cause)
+
+		return
+	}
+
+	c.updatePeerRecoveryTimeStamp(msg.RecoveryTimeStamp)
+	c.peerReleased.Store(false)
+	c.resetAssociationState(append(append([]*ieLib.IE{msg.NodeID, msg.UPFunctionFeatures},
+		msg.UserPlaneIPResourceInformation...), msg.AlternativeSMFIPAddress...)...)
+	c.setAssociationStatus(true, "association set up by the peer")
+
+	select {
+	case c.associationAccepted <- struct{}{}:
+	default:
+	}
+}
+
+// matchNodeID reports whether the Node ID IE nodeID is expected. IP addresses are compared by value,
+// FQDNs are case-insensitive. An empty expected Node ID matches any.
+func matchNodeID(nodeID *ieLib.IE, expected string) bool {
+	if expected == "" {
+		return true
+	}
+
+	received, err := nodeID.NodeID()
+	if err != nil {
+		return false
+	}
+
+	if receivedIP, expectedIP := net.ParseIP(received), net.ParseIP(expected); receivedIP != nil && expectedIP != nil {
+		return receivedIP.Equal(expectedIP)
+	}
+
+	return strings.EqualFold(received, expected)
+}
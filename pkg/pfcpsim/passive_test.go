@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"testing"
+
+	ieLib "github.com/wmnsk/go-pfcp/ie"
+)
+
+func TestMatchNodeID(t *testing.T) {
+	tests := []struct {
+		name     string
+		nodeID   string
+		expected string
+		want     bool
+	}{
+		{
+			name:     "any Node ID",
+			nodeID:   "10.0.0.2",
+			expected: "",
+			want:     true,
+		},
+		{
+			name:     "same IPv4 address",
+			nodeID:   "10.0.0.2",
+			expected: "10.0.0.2",
+			want:     true,
+		},
+		{
+			name:     "other IPv4 address",
+			nodeID:   "10.0.0.3",
+			expected: "10.0.0.2",
+		},
+		{
+			name:     "IPv6 address in another notation",
+			nodeID:   "2001:db8::2",
+			expected: "2001:db8:0:0::2",
+			want:     true,
+		},
+		{
+			name:     "FQDN, case-insensitive",
+			nodeID:   "upf.example.org",
+			expected: "UPF.example.org",
+			want:     true,
+		},
+		{
+			name:     "other FQDN",
+			nodeID:   "upf2.example.org",
+			expected: "upf.example.org",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchNodeID(ieLib.NewNodeIDHeuristic(tt.nodeID), tt.expected); got != tt.want {
+				t.Errorf("matchNodeID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -8,10 +8,13 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/omec-project/pfcpsim/logger"
+	"github.com/omec-project/pfcpsim/pkg/pfcpsim/session"
 	ieLib "github.com/wmnsk/go-pfcp/ie"
 	"github.com/wmnsk/go-pfcp/message"
 )
@@ -20,6 +23,17 @@ const (
 	PFCPStandardPort       = 8805
 	DefaultHeartbeatPeriod = 5
 	DefaultResponseTimeout = 5 * time.Second
+	// DefaultMaxRetransmissions is the default N1, the number of times a request is retransmitted
+	// before giving up (3GPP TS 29.244 section 6.4). Requests are not retransmitted by default, so that a request
+	// fails after DefaultResponseTimeout.
+	DefaultMaxRetransmissions = 0
+	// DefaultMaxInFlight is the default number of session transactions that can wait for a response at once.
+	DefaultMaxInFlight = 1
+
+	// maxSequenceNumber is the highest value of the 3 octets PFCP sequence number.
+	maxSequenceNumber = 0xffffff
+	// sharedChanSize is the buffer size of channels shared by low-level Send* and Peek* functions.
+	sharedChanSize = 64
 )
 
 var (
@@ -27,6 +41,8 @@ var (
 	lockActiveSessions = new(sync.Mutex)
 	errWrongRspType    = errors.New("unexpected response type")
 	errAssocFailed     = errors.New("association failed")
+	errMissingCause    = errors.New("missing Cause IE")
+	errMissingUPFSEID  = errors.New("missing UP F-SEID IE")
 )
 
 func GetActiveSessionNum() int {
@@ -65,6 +81,54 @@ func GetSessionByLocalSEID(seid uint64) (*PFCPSession, bool) {
 	return nil, false
 }
 
+// getSessionByPeerSEID returns the active session whose SEID allocated by the peer is seid.
+func getSessionByPeerSEID(seid uint64) (*PFCPSession, bool) {
+	lockActiveSessions.Lock()
+	defer lockActiveSessions.Unlock()
+
+	for _, session := range activeSessions {
+		if session.PeerSEID() == seid {
+			return session, true
+		}
+	}
+
+	return nil, false
+}
+
+// GetSessionIndexes returns the indexes of the active sessions in ascending order.
+func GetSessionIndexes() []int {
+	lockActiveSessions.Lock()
+	defer lockActiveSessions.Unlock()
+
+	return sessionIndexes()
+}
+
+func sessionIndexes() []int {
+	indexes := make([]int, 0, len(activeSessions))
+	for index := range activeSessions {
+		indexes = append(indexes, index)
+	}
+
+	sort.Ints(indexes)
+
+	return indexes
+}
+
+// getSessions returns the active sessions ordered by index.
+func getSessions() []*PFCPSession {
+	lockActiveSessions.Lock()
+	defer lockActiveSessions.Unlock()
+
+	indexes := sessionIndexes()
+
+	sessions := make([]*PFCPSession, 0, len(indexes))
+	for _, index := range indexes {
+		sessions = append(sessions, activeSessions[index])
+	}
+
+	return sessions
+}
+
 func RemoveSession(index int) {
 	lockActiveSessions.Lock()
 	defer lockActiveSessions.Unlock()
@@ -77,19 +141,55 @@ func RemoveSession(index int) {
 //   - 1st mode enables high-level PFCP operations (e.g., SetupAssociation())
 //   - 2nd mode gives a user more control over PFCP sequence flow
 //     and enables send and receive of individual messages (e.g., SendAssociationSetupRequest(), PeekNextResponse())
+//
+// High-level operations are safe for concurrent use. Session operations are pipelined
+// up to the window configured with SetMaxInFlight().
 type PFCPClient struct {
 	// keeps the current number of active PFCP sessions
 	// it is also used as F-SEID
-	lastFSEID uint64
+	lastFSEID atomic.Uint64
 
 	aliveLock           sync.Mutex
 	isAssociationActive bool
 
+	associationLock sync.Mutex
+	// association is the state negotiated with the peer
+	association AssociationState
+	// expectedPeerNodeID is the Node ID accepted in passive mode, any if empty
+	expectedPeerNodeID string
+	// cpFeatures are the octets of the CP Function Features IE advertised to the peer
+	cpFeatures []uint8
+
+	// passive waits for the peer to set up the association, associationAccepted is signalled once it did
+	passive             atomic.Bool
+	associationAccepted chan struct{}
+
+	// recoveryTimeStamp is the time this client started, advertised to the peer
+	recoveryTimeStamp time.Time
+
+	peerRecoveryLock sync.Mutex
+	// peerRecoveryTimeStamp is the last Recovery Time Stamp received from the peer
+	peerRecoveryTimeStamp time.Time
+
+	// autoRecovery enables re-association and sessions replay when the peer restarts
+	autoRecovery atomic.Bool
+	// peerRestarted is set when a change of the peer Recovery Time Stamp is detected
+	peerRestarted atomic.Bool
+	// peerReleased is set when the peer released the association, until a new association is set up
+	peerReleased atomic.Bool
+
+	// pagingEmulation answers Downlink Data Reports as if the UE was paged, after pagingDelay
+	pagingEmulation atomic.Bool
+	pagingDelay     atomic.Int64
+
 	ctx    context.Context
 	cancel context.CancelFunc
 
-	heartbeatsChan chan *message.HeartbeatResponse
+	heartbeatsChan chan message.Message
 	recvChan       chan message.Message
+	eventsChan     chan Event
+
+	transactions *transactionTable
 
 	sequenceNumber uint32
 	seqNumLock     sync.Mutex
@@ -98,22 +198,39 @@ type PFCPClient struct {
 	remoteAddr string
 	conn       *net.UDPConn
 
-	// responseTimeout timeout to wait for PFCP response (default: 5 seconds)
+	// responseTimeout timeout to wait for PFCP response (default: 5 seconds).
+	// It is the T1 timer after which a request is retransmitted.
 	responseTimeout time.Duration
+	// maxRetransmissions is the N1 counter (default: DefaultMaxRetransmissions)
+	maxRetransmissions int
+
+	retransmissions atomic.Uint64
+	timeouts        atomic.Uint64
+
+	// window limits the session transactions waiting for a response (default: DefaultMaxInFlight)
+	window *inFlightWindow
 }
 
+// NewPFCPClient returns a PFCPClient bound to localAddr. localAddr may contain a port
+// (e.g. 127.0.0.1:0 to pick a free one), otherwise PFCPStandardPort is used.
 func NewPFCPClient(localAddr string) *PFCPClient {
 	client := &PFCPClient{
-		sequenceNumber:  0,
-		localAddr:       localAddr,
-		responseTimeout: DefaultResponseTimeout,
+		sequenceNumber:     0,
+		localAddr:          localAddr,
+		recoveryTimeStamp:  time.Now(),
+		responseTimeout:    DefaultResponseTimeout,
+		maxRetransmissions: DefaultMaxRetransmissions,
+		window:             newInFlightWindow(DefaultMaxInFlight),
 	}
 
 	ctx, cancelFunc := context.WithCancel(context.Background())
 	client.ctx = ctx
 	client.cancel = cancelFunc
-	client.heartbeatsChan = make(chan *message.HeartbeatResponse)
-	client.recvChan = make(chan message.Message)
+	client.heartbeatsChan = make(chan message.Message, sharedChanSize)
+	client.recvChan = make(chan message.Message, sharedChanSize)
+	client.eventsChan = make(chan Event, eventsBufferSize)
+	client.transactions = newTransactionTable()
+	client.associationAccepted = make(chan struct{}, 1)
 
 	return client
 }
@@ -123,13 +240,15 @@ func (c *PFCPClient) getNextSequenceNumber() uint32 {
 	defer c.seqNumLock.Unlock()
 
 	c.sequenceNumber++
+	if c.sequenceNumber > maxSequenceNumber {
+		c.sequenceNumber = 1
+	}
 
 	return c.sequenceNumber
 }
 
 func (c *PFCPClient) getNextFSEID() uint64 {
-	c.lastFSEID++
-	return c.lastFSEID
+	return c.lastFSEID.Add(1)
 }
 
 func (c *PFCPClient) resetSequenceNumber() {
@@ -137,23 +256,42 @@ func (c *PFCPClient) resetSequenceNumber() {
 	defer c.seqNumLock.Unlock()
 
 	c.sequenceNumber = 0
+
+	c.transactions.reset()
 }
 
-func (c *PFCPClient) setAssociationStatus(status bool) {
+// setAssociationStatus updates the association status. On change, EventAssociationUp or
+// EventAssociationDown is raised with reason as details.
+func (c *PFCPClient) setAssociationStatus(status bool, reason string) {
 	c.aliveLock.Lock()
-	defer c.aliveLock.Unlock()
-
+	changed := c.isAssociationActive != status
 	c.isAssociationActive = status
+	c.aliveLock.Unlock()
+
+	if !changed {
+		return
+	}
+
+	if status {
+		c.emitEvent(EventAssociationUp, nil, reason)
+	} else {
+		c.emitEvent(EventAssociationDown, nil, reason)
+	}
 }
 
 func (c *PFCPClient) sendMsg(msg message.Message) error {
-	b := make([]byte, msg.MarshalLen())
-	if err := msg.MarshalTo(b); err != nil {
+	raddr, err := net.ResolveUDPAddr("udp", c.remoteAddr)
+	if err != nil {
 		return err
 	}
 
-	raddr, err := net.ResolveUDPAddr("udp", c.remoteAddr)
-	if err != nil {
+	return c.sendMsgTo(msg, raddr)
+}
+
+// sendMsgTo sends msg to raddr, e.g. a response to the source address of a request.
+func (c *PFCPClient) sendMsgTo(msg message.Message, raddr net.Addr) error {
+	b := make([]byte, msg.MarshalLen())
+	if err := msg.MarshalTo(b); err != nil {
 		return err
 	}
 
@@ -164,15 +302,108 @@ func (c *PFCPClient) sendMsg(msg message.Message) error {
 	return nil
 }
 
-func (c *PFCPClient) receiveFromN4() {
+// sendRequest registers a transaction for req and sends it to the peer.
+// The response is delivered to rspChan or, if nil, to a channel owned by the transaction.
+// seid is the SEID expected in the response header (0 for node related messages).
+func (c *PFCPClient) sendRequest(req message.Message, seid uint64, rspChan chan message.Message) (*transaction, error) {
+	tx := newTransaction(req, seid, rspChan)
+	c.transactions.add(tx)
+
+	if err := c.sendMsg(req); err != nil {
+		c.transactions.remove(tx.seq)
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// waitResponse blocks until the response of tx is received. The request is retransmitted
+// with the same sequence number every time c.responseTimeout (T1) expires, up to c.maxRetransmissions (N1) times.
+// Then the transaction is abandoned, so that a late response is not mistaken for another one.
+func (c *PFCPClient) waitResponse(tx *transaction) (message.Message, error) {
+	t1 := time.NewTimer(c.responseTimeout)
+	defer t1.Stop()
+
+	for attempt := 0; ; attempt++ {
+		select {
+		case rsp := <-tx.rspChan:
+			return rsp, nil
+		case <-t1.C:
+			if attempt >= c.maxRetransmissions {
+				c.transactions.remove(tx.seq)
+				c.timeouts.Add(1)
+
+				return nil, NewTimeoutExpiredError()
+			}
+
+			logger.PfcpsimLog.Debugf("no response to %v with sequence number %v, retransmitting (%v/%v)",
+				tx.req.MessageTypeName(), tx.seq, attempt+1, c.maxRetransmissions)
+
+			if err := c.sendMsg(tx.req); err != nil {
+				c.transactions.remove(tx.seq)
+				return nil, err
+			}
+
+			c.retransmissions.Add(1)
+			t1.Reset(c.responseTimeout)
+		}
+	}
+}
+
+// transact sends req and waits for the response correlated to it.
+func (c *PFCPClient) transact(req message.Message, seid uint64) (message.Message, error) {
+	tx, err := c.sendRequest(req, seid, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.waitResponse(tx)
+}
+
+// transactSession is like transact, but waits for a free slot in the in-flight window before sending req.
+func (c *PFCPClient) transactSession(req message.Message, seid uint64) (message.Message, error) {
+	c.window.acquire()
+	defer c.window.release()
+
+	return c.transact(req, seid)
+}
+
+// dispatchResponse delivers msg to the transaction it belongs to.
+// Responses that cannot be correlated are published as events.
+func (c *PFCPClient) dispatchResponse(msg message.Message) {
+	tx, result := c.transactions.resolve(msg)
+
+	switch result {
+	case txMatched:
+		select {
+		case tx.rspChan <- msg:
+		default:
+			logger.PfcpsimLog.Warnf("no receiver for %v with sequence number %v, dropping it",
+				msg.MessageTypeName(), msg.Sequence())
+		}
+	case txDuplicate:
+		details := fmt.Sprintf("duplicate %v with sequence number %v", msg.MessageTypeName(), msg.Sequence())
+		logger.PfcpsimLog.Warnln(details)
+		c.emitEvent(EventDuplicateResponse, msg, details)
+	default:
+		details := fmt.Sprintf("%v with sequence number %v and SEID %v does not match any outstanding request",
+			msg.MessageTypeName(), msg.Sequence(), msg.SEID())
+		logger.PfcpsimLog.Warnln(details)
+		c.emitEvent(EventUnmatchedResponse, msg, details)
+	}
+}
+
+// receiveFromN4 reads the messages received on conn until ctx is cancelled.
+// Both are passed by ConnectN4, as a new connection replaces them.
+func (c *PFCPClient) receiveFromN4(ctx context.Context, conn *net.UDPConn) {
 	buf := make([]byte, 3000)
 
 	for {
 		select {
-		case <-c.ctx.Done():
+		case <-ctx.Done():
 			return
 		default:
-			n, _, err := c.conn.ReadFrom(buf)
+			n, raddr, err := conn.ReadFrom(buf)
 			if err != nil {
 				continue
 			}
@@ -183,49 +414,79 @@ func (c *PFCPClient) receiveFromN4() {
 			}
 
 			switch msg := msg.(type) {
-			case *message.HeartbeatResponse:
-				c.heartbeatsChan <- msg
 			case *message.HeartbeatRequest:
-				// ignore HeartbeatRequest
-				continue
+				c.handleHeartbeatRequest(msg, raddr)
+			case *message.HeartbeatResponse:
+				c.updatePeerRecoveryTimeStamp(msg.RecoveryTimeStamp)
+				c.dispatchResponse(msg)
+			case *message.AssociationSetupResponse:
+				c.updatePeerRecoveryTimeStamp(msg.RecoveryTimeStamp)
+				c.dispatchResponse(msg)
+			case *message.AssociationSetupRequest:
+				c.handleAssociationSetupRequest(msg, raddr)
+			case *message.AssociationUpdateRequest:
+				c.handleAssociationUpdateRequest(msg, raddr)
 			case *message.SessionReportRequest:
-				if c.handleSessionReportRequest(msg) {
+				if c.handleSessionReportRequest(msg, raddr) {
 					continue
 				}
+			case *message.NodeReportRequest:
+				c.handleNodeReportRequest(msg, raddr)
+			case *message.AssociationReleaseRequest:
+				c.handleAssociationReleaseRequest(msg, raddr)
 			default:
-				c.recvChan <- msg
+				if isRequest(msg) {
+					details := fmt.Sprintf("unsolicited %v with sequence number %v received", msg.MessageTypeName(), msg.Sequence())
+					logger.PfcpsimLog.Warnln(details)
+					c.emitEvent(EventUnsolicitedMessage, msg, details)
+
+					continue
+				}
+
+				c.dispatchResponse(msg)
 			}
 		}
 	}
 }
 
+// ConnectN4 opens the N4 socket towards remoteAddr. Both IPv4 and IPv6 addresses are supported.
+// If remoteAddr contains also a port (e.g. 10.0.0.1:8888 or [2001:db8::1]:8888),
+// the provided port is used instead of PFCPStandardPort.
 func (c *PFCPClient) ConnectN4(remoteAddr string) error {
-	addr := fmt.Sprintf("%s:%d", remoteAddr, PFCPStandardPort)
-
-	if host, port, err := net.SplitHostPort(remoteAddr); err == nil {
-		// remoteAddr contains also a port. Use provided port instead of PFCPStandardPort
-		addr = fmt.Sprintf("%s:%s", host, port)
-	}
+	c.remoteAddr = withDefaultPort(remoteAddr, PFCPStandardPort)
 
-	c.remoteAddr = addr
-
-	laddr, err := net.ResolveUDPAddr("udp", fmt.Sprintf("%s:%d", c.localAddr, PFCPStandardPort))
+	laddr, err := net.ResolveUDPAddr("udp", withDefaultPort(c.localAddr, PFCPStandardPort))
 	if err != nil {
 		return err
 	}
 
-	rxconn, err := net.ListenUDP("udp4", laddr)
+	rxconn, err := net.ListenUDP("udp", laddr)
 	if err != nil {
 		return err
 	}
 
+	// the context of a previous connection is cancelled on teardown
+	if c.cancel == nil {
+		c.ctx, c.cancel = context.WithCancel(context.Background())
+	}
+
 	c.conn = rxconn
 
-	go c.receiveFromN4()
+	go c.receiveFromN4(c.ctx, rxconn)
 
 	return nil
 }
 
+// LocalAddr returns the address the N4 socket is bound to, e.g. for the peer to initiate the association.
+// Returns an empty string if not connected.
+func (c *PFCPClient) LocalAddr() string {
+	if c.conn == nil {
+		return ""
+	}
+
+	return c.conn.LocalAddr().String()
+}
+
 func (c *PFCPClient) DisconnectN4() {
 	if c.cancel != nil {
 		c.cancel()
@@ -238,10 +499,16 @@ func (c *PFCPClient) DisconnectN4() {
 	}
 }
 
+// PeekNextHeartbeatResponse waits for the response to a Heartbeat Request sent with SendHeartbeatRequest().
 func (c *PFCPClient) PeekNextHeartbeatResponse() (*message.HeartbeatResponse, error) {
 	select {
 	case msg := <-c.heartbeatsChan:
-		return msg, nil
+		hbResp, ok := msg.(*message.HeartbeatResponse)
+		if !ok {
+			return nil, NewInvalidResponseError(errWrongRspType)
+		}
+
+		return hbResp, nil
 	case <-time.After(c.responseTimeout):
 		return nil, NewTimeoutExpiredError()
 	}
@@ -251,8 +518,20 @@ func (c *PFCPClient) SetPFCPResponseTimeout(timeout time.Duration) {
 	c.responseTimeout = timeout
 }
 
-// PeekNextResponse can be used to wait for a next PFCP message from a peer.
-// It's a blocking operation, which is timed out after c.responseTimeout period (5 seconds by default).
+// SetMaxRetransmissions sets N1, the number of times a request is retransmitted when no response
+// is received within the response timeout (T1). 0 disables retransmissions.
+func (c *PFCPClient) SetMaxRetransmissions(n1 int) {
+	c.maxRetransmissions = max(n1, 0)
+}
+
+// SetMaxInFlight sets how many session transactions (establishment, modification, deletion)
+// can wait for a response at the same time. Values lower than 1 are treated as 1.
+func (c *PFCPClient) SetMaxInFlight(maxInFlight int) {
+	c.window.setSize(maxInFlight)
+}
+
+// PeekNextResponse can be used to wait for the response to a request sent with one of the Send* functions.
+// Responses are delivered in order of arrival. It's a blocking operation, which is timed out after c.responseTimeout period (5 seconds by default).
 // Use SetPFCPResponseTimeout() to configure a custom timeout.
 func (c *PFCPClient) PeekNextResponse() (message.Message, error) {
 	var resMsg message.Message
@@ -273,112 +552,202 @@ func (c *PFCPClient) PeekNextResponse() (message.Message, error) {
 	}
 }
 
+// handleHeartbeatRequest answers to a Heartbeat Request sent by the peer from raddr with our Recovery Time Stamp.
+func (c *PFCPClient) handleHeartbeatRequest(msg *message.HeartbeatRequest, raddr net.Addr) {
+	c.updatePeerRecoveryTimeStamp(msg.RecoveryTimeStamp)
+
+	res := message.NewHeartbeatResponse(msg.Sequence(), ieLib.NewRecoveryTimeStamp(c.recoveryTimeStamp))
+
+	if err := c.sendMsgTo(res, raddr); err != nil {
+		logger.PfcpsimLog.Errorln("Error sending Heartbeat Response:", err)
+	}
+}
+
+// updatePeerRecoveryTimeStamp records the Recovery Time Stamp of the peer.
+// A change of a previously known value means that the peer restarted and EventPeerRestarted is raised.
+func (c *PFCPClient) updatePeerRecoveryTimeStamp(rts *ieLib.IE) {
+	if rts == nil {
+		return
+	}
+
+	ts, err := rts.RecoveryTimeStamp()
+	if err != nil {
+		logger.PfcpsimLog.Warnln("Could not parse peer Recovery Time Stamp:", err)
+		return
+	}
+
+	c.peerRecoveryLock.Lock()
+	previous := c.peerRecoveryTimeStamp
+	c.peerRecoveryTimeStamp = ts
+	c.peerRecoveryLock.Unlock()
+
+	if !previous.IsZero() && !previous.Equal(ts) {
+		details := fmt.Sprintf("peer Recovery Time Stamp changed from %v to %v", previous, ts)
+		logger.PfcpsimLog.Warnln(details)
+		c.peerRestarted.Store(true)
+		c.emitEvent(EventPeerRestarted, nil, details)
+	}
+}
+
+// PeerRecoveryTimeStamp returns the last Recovery Time Stamp received from the peer.
+// The zero time is returned if none was received yet.
+func (c *PFCPClient) PeerRecoveryTimeStamp() time.Time {
+	c.peerRecoveryLock.Lock()
+	defer c.peerRecoveryLock.Unlock()
+
+	return c.peerRecoveryTimeStamp
+}
+
+// RecoveryTimeStamp returns the Recovery Time Stamp advertised to the peer.
+func (c *PFCPClient) RecoveryTimeStamp() time.Time {
+	return c.recoveryTimeStamp
+}
+
 // MsgTypeSessionReportRequest: sent by the UP function to the CP function to
 // report information related to an PFCP session
 // MsgTypeSessionReportResponse: sent by the CP function to the UP function as
-// a reply to the Session Report Request.
-func (c *PFCPClient) handleSessionReportRequest(msg *message.SessionReportRequest) bool {
+// a reply to the Session Report Request, sent to the source address raddr of the request.
+func (c *PFCPClient) handleSessionReportRequest(msg *message.SessionReportRequest, raddr net.Addr) bool {
 	if msg.MessageType() == message.MsgTypeSessionReportRequest {
 		logger.PfcpsimLog.Infoln("Session Report Request received")
 
+		c.emitEvent(EventSessionReport, msg, describeSessionReport(msg))
+
+		reportedAt := time.Now()
+
+		sess, ok := GetSessionByLocalSEID(msg.SEID())
+		if ok {
+			storeUsageReports(sess, msg.UsageReport)
+		} else if len(msg.UsageReport) > 0 {
+			logger.PfcpsimLog.Warnf("dropping usage reports for unknown session with local SEID %v", msg.SEID())
+		}
+
 		err := c.sendSessionReportResponse(msg.Sequence(),
-			msg.Header.SEID)
+			msg.Header.SEID, raddr)
 		if err != nil {
 			logger.PfcpsimLog.Errorln("Error sending Session Report Response")
 		}
 
+		// the modification is awaited in another goroutine, not to block the reception of its response
+		if ok && c.IsPagingEmulationEnabled() && msg.ReportType != nil && msg.ReportType.HasDLDR() {
+			go c.page(sess, reportedAt)
+		}
+
 		return true
 	}
 
 	return false
 }
 
-func (c *PFCPClient) sendSessionReportResponse(seq uint32, seid uint64) error {
+func (c *PFCPClient) sendSessionReportResponse(seq uint32, seid uint64, raddr net.Addr) error {
 	var rseid uint64
 
 	sess, ok := GetSessionByLocalSEID(seid)
 	if !ok {
 		rseid = 0
 	} else {
-		rseid = sess.peerSEID
+		rseid = sess.PeerSEID()
 	}
 
 	res := message.NewSessionReportResponse(0, 0, rseid, seq, 0,
 		ieLib.NewCause(ieLib.CauseRequestAccepted))
 
-	return c.sendMsg(res)
+	return c.sendMsgTo(res, raddr)
 }
 
-func (c *PFCPClient) SendAssociationSetupRequest(ie ...*ieLib.IE) error {
+// newAssociationSetupRequest restarts the sequence numbers, as a new association is about to begin.
+func (c *PFCPClient) newAssociationSetupRequest(ie ...*ieLib.IE) *message.AssociationSetupRequest {
 	c.resetSequenceNumber()
 
 	assocReq := message.NewAssociationSetupRequest(
 		c.getNextSequenceNumber(),
-		ieLib.NewRecoveryTimeStamp(time.Now()),
-		ieLib.NewNodeID(c.localAddr, "", ""),
+		ieLib.NewRecoveryTimeStamp(c.recoveryTimeStamp),
+		newNodeID(c.localAddr),
 	)
 
+	if features := c.cpFunctionFeaturesIE(); features != nil {
+		assocReq.IEs = append(assocReq.IEs, features)
+	}
+
 	assocReq.IEs = append(assocReq.IEs, ie...)
 
-	return c.sendMsg(assocReq)
+	return assocReq
 }
 
-// SendAssociationTeardownRequest sends PFCP Teardown Request towards a peer.
-// A caller should make sure that the PFCP connection is established before
-// invoking this function.
-func (c *PFCPClient) SendAssociationTeardownRequest(ie ...*ieLib.IE) error {
-	raddr, err := net.ResolveUDPAddr("udp", c.remoteAddr)
-	if err != nil {
-		return err
-	}
+func (c *PFCPClient) SendAssociationSetupRequest(ie ...*ieLib.IE) error {
+	_, err := c.sendRequest(c.newAssociationSetupRequest(ie...), 0, c.recvChan)
+	return err
+}
 
-	teardownReq := message.NewAssociationReleaseRequest(0,
-		ieLib.NewNodeID(raddr.String(), "", ""),
+// newAssociationTeardownRequest returns an Association Release Request carrying the Node ID of the sender.
+func (c *PFCPClient) newAssociationTeardownRequest(ie ...*ieLib.IE) *message.AssociationReleaseRequest {
+	teardownReq := message.NewAssociationReleaseRequest(c.getNextSequenceNumber(),
+		newNodeID(c.localAddr),
 	)
 
 	teardownReq.IEs = append(teardownReq.IEs, ie...)
 
-	return c.sendMsg(teardownReq)
+	return teardownReq
 }
 
-func (c *PFCPClient) SendHeartbeatRequest() error {
-	hbReq := message.NewHeartbeatRequest(
+// SendAssociationTeardownRequest sends PFCP Teardown Request towards a peer.
+// A caller should make sure that the PFCP connection is established before
+// invoking this function.
+func (c *PFCPClient) SendAssociationTeardownRequest(ie ...*ieLib.IE) error {
+	_, err := c.sendRequest(c.newAssociationTeardownRequest(ie...), 0, c.recvChan)
+	return err
+}
+
+func (c *PFCPClient) newHeartbeatRequest() *message.HeartbeatRequest {
+	return message.NewHeartbeatRequest(
 		c.getNextSequenceNumber(),
-		ieLib.NewRecoveryTimeStamp(time.Now()),
-		ieLib.NewSourceIPAddress(net.ParseIP(c.localAddr), nil, 0),
+		ieLib.NewRecoveryTimeStamp(c.recoveryTimeStamp),
+		newSourceIPAddress(c.localAddr),
 	)
+}
 
-	return c.sendMsg(hbReq)
+// SendHeartbeatRequest sends a Heartbeat Request. Use PeekNextHeartbeatResponse() to retrieve the response.
+func (c *PFCPClient) SendHeartbeatRequest() error {
+	_, err := c.sendRequest(c.newHeartbeatRequest(), 0, c.heartbeatsChan)
+	return err
 }
 
-func (c *PFCPClient) SendSessionEstablishmentRequest(pdrs []*ieLib.IE, fars []*ieLib.IE,
-	qers []*ieLib.IE, urrs []*ieLib.IE,
-) error {
+func (c *PFCPClient) newSessionEstablishmentRequest(localSEID uint64, pdrs []*ieLib.IE, fars []*ieLib.IE,
+	qers []*ieLib.IE, urrs []*ieLib.IE, bar *ieLib.IE,
+) *message.SessionEstablishmentRequest {
 	estReq := message.NewSessionEstablishmentRequest(
 		0,
 		0,
 		0,
 		c.getNextSequenceNumber(),
 		0,
-		ieLib.NewNodeID(c.localAddr, "", ""),
-		ieLib.NewFSEID(c.getNextFSEID(), net.ParseIP(c.localAddr), nil),
-		ieLib.NewPDNType(ieLib.PDNTypeIPv4),
+		newNodeID(c.localAddr),
+		newFSEID(localSEID, c.localAddr),
+		ieLib.NewPDNType(pdnTypeFromPDRs(pdrs)),
 	)
 	estReq.CreatePDR = append(estReq.CreatePDR, pdrs...)
 	estReq.CreateFAR = append(estReq.CreateFAR, fars...)
 	estReq.CreateQER = append(estReq.CreateQER, qers...)
 	estReq.CreateURR = append(estReq.CreateURR, urrs...)
+	estReq.CreateBAR = bar
 
-	return c.sendMsg(estReq)
+	return estReq
 }
 
-func (c *PFCPClient) SendSessionModificationRequest(
-	PeerSEID uint64,
-	pdrs []*ieLib.IE,
-	qers []*ieLib.IE,
-	fars []*ieLib.IE,
-	urrs []*ieLib.IE,
+func (c *PFCPClient) SendSessionEstablishmentRequest(pdrs []*ieLib.IE, fars []*ieLib.IE,
+	qers []*ieLib.IE, urrs []*ieLib.IE,
 ) error {
+	localSEID := c.getNextFSEID()
+
+	_, err := c.sendRequest(c.newSessionEstablishmentRequest(localSEID, pdrs, fars, qers, urrs, nil), localSEID, c.recvChan)
+
+	return err
+}
+
+// newSessionModificationRequest returns a Session Modification Request carrying ies. Each IE is placed
+// in the field matching its type, so that Create, Update and Remove IEs of any rule, and Query URR IEs,
+// can be mixed.
+func (c *PFCPClient) newSessionModificationRequest(PeerSEID uint64, ies ...[]*ieLib.IE) *message.SessionModificationRequest {
 	modifyReq := message.NewSessionModificationRequest(
 		0,
 		0,
@@ -387,56 +756,133 @@ func (c *PFCPClient) SendSessionModificationRequest(
 		0,
 	)
 
-	modifyReq.UpdatePDR = append(modifyReq.UpdatePDR, pdrs...)
-	modifyReq.UpdateFAR = append(modifyReq.UpdateFAR, fars...)
-	modifyReq.UpdateQER = append(modifyReq.UpdateQER, qers...)
-	modifyReq.UpdateURR = append(modifyReq.UpdateURR, urrs...)
+	fields := map[uint16]*[]*ieLib.IE{
+		ieLib.CreatePDR: &modifyReq.CreatePDR,
+		ieLib.CreateFAR: &modifyReq.CreateFAR,
+		ieLib.CreateQER: &modifyReq.CreateQER,
+		ieLib.CreateURR: &modifyReq.CreateURR,
+		ieLib.UpdatePDR: &modifyReq.UpdatePDR,
+		ieLib.UpdateFAR: &modifyReq.UpdateFAR,
+		ieLib.UpdateQER: &modifyReq.UpdateQER,
+		ieLib.UpdateURR: &modifyReq.UpdateURR,
+		ieLib.RemovePDR: &modifyReq.RemovePDR,
+		ieLib.RemoveFAR: &modifyReq.RemoveFAR,
+		ieLib.RemoveQER: &modifyReq.RemoveQER,
+		ieLib.RemoveURR: &modifyReq.RemoveURR,
+		ieLib.QueryURR:  &modifyReq.QueryURR,
+	}
+
+	for _, list := range ies {
+		for _, i := range list {
+			if field, ok := fields[i.Type]; ok {
+				*field = append(*field, i)
+			} else {
+				modifyReq.IEs = append(modifyReq.IEs, i)
+			}
+		}
+	}
 
-	return c.sendMsg(modifyReq)
+	return modifyReq
 }
 
-func (c *PFCPClient) SendSessionDeletionRequest(localSEID uint64, remoteSEID uint64) error {
-	delReq := message.NewSessionDeletionRequest(
+// SendSessionModificationRequest sends a Session Modification Request to the peer for the session with
+// peer SEID PeerSEID, without waiting for the response. The response is correlated with the local SEID of
+// the session if it is an active one.
+func (c *PFCPClient) SendSessionModificationRequest(
+	PeerSEID uint64,
+	pdrs []*ieLib.IE,
+	qers []*ieLib.IE,
+	fars []*ieLib.IE,
+	urrs []*ieLib.IE,
+) error {
+	var localSEID uint64
+	if sess, ok := getSessionByPeerSEID(PeerSEID); ok {
+		localSEID = sess.localSEID
+	}
+
+	_, err := c.sendRequest(c.newSessionModificationRequest(PeerSEID, pdrs, qers, fars, urrs), localSEID, c.recvChan)
+	return err
+}
+
+func (c *PFCPClient) newSessionDeletionRequest(localSEID uint64, remoteSEID uint64) *message.SessionDeletionRequest {
+	return message.NewSessionDeletionRequest(
 		0,
 		0,
 		remoteSEID,
 		c.getNextSequenceNumber(),
 		0,
-		ieLib.NewFSEID(localSEID, net.ParseIP(c.localAddr), nil),
+		newFSEID(localSEID, c.localAddr),
 	)
+}
 
-	return c.sendMsg(delReq)
+func (c *PFCPClient) SendSessionDeletionRequest(localSEID uint64, remoteSEID uint64) error {
+	_, err := c.sendRequest(c.newSessionDeletionRequest(localSEID, remoteSEID), localSEID, c.recvChan)
+	return err
 }
 
+// StartHeartbeats sends a Heartbeat Request every DefaultHeartbeatPeriod seconds.
+// It stops at the first failure, unless auto recovery is enabled: in that case it keeps
+// probing the peer and recovers the association once the peer is back or restarted.
 func (c *PFCPClient) StartHeartbeats() {
+	c.sendHeartbeats(c.ctx)
+}
+
+// sendHeartbeats is the loop of StartHeartbeats, running until ctx is cancelled.
+func (c *PFCPClient) sendHeartbeats(ctx context.Context) {
 	ticker := time.NewTicker(DefaultHeartbeatPeriod * time.Second)
+	defer ticker.Stop()
+
+	heartbeatLost := false
 
 	for {
 		select {
-		case <-c.ctx.Done():
+		case <-ctx.Done():
 			return
 		case <-ticker.C:
 			err := c.SendAndRecvHeartbeat()
+
+			if !c.IsAutoRecoveryEnabled() {
+				if err != nil {
+					return
+				}
+
+				continue
+			}
+
 			if err != nil {
-				return
+				heartbeatLost = true
+				continue
+			}
+
+			if c.peerRestarted.Swap(false) || heartbeatLost {
+				heartbeatLost = false
+
+				c.recoverAssociation()
 			}
 		}
 	}
 }
 
 func (c *PFCPClient) SendAndRecvHeartbeat() error {
-	err := c.SendHeartbeatRequest()
+	resp, err := c.transact(c.newHeartbeatRequest(), 0)
 	if err != nil {
+		c.emitEvent(EventHeartbeatFailure, nil, fmt.Sprintf("no Heartbeat Response received: %v", err))
+		c.setAssociationStatus(false, "heartbeat failure")
+
 		return err
 	}
 
-	_, err = c.PeekNextHeartbeatResponse()
-	if err != nil {
-		c.setAssociationStatus(false)
-		return err
+	if _, ok := resp.(*message.HeartbeatResponse); !ok {
+		c.emitEvent(EventHeartbeatFailure, resp, "unexpected response to Heartbeat Request")
+		c.setAssociationStatus(false, "heartbeat failure")
+
+		return NewInvalidResponseError(errWrongRspType)
 	}
 
-	c.setAssociationStatus(true)
+	// heartbeats are answered by a peer that released the association too
+	if !c.peerReleased.Load() {
+		c.setAssociationStatus(true, "heartbeat received")
+	}
 
 	return nil
 }
@@ -444,12 +890,22 @@ func (c *PFCPClient) SendAndRecvHeartbeat() error {
 // SetupAssociation sends PFCP Association Setup Request and waits for PFCP Association Setup Response.
 // Returns error if the process fails at any stage.
 func (c *PFCPClient) SetupAssociation() error {
-	err := c.SendAssociationSetupRequest()
-	if err != nil {
+	// heartbeats are still sent after the peer released the association
+	released := c.peerReleased.Load()
+
+	if err := c.setupAssociation(); err != nil {
 		return err
 	}
 
-	resp, err := c.PeekNextResponse()
+	if !released {
+		go c.sendHeartbeats(c.ctx)
+	}
+
+	return nil
+}
+
+func (c *PFCPClient) setupAssociation() error {
+	resp, err := c.transact(c.newAssociationSetupRequest(), 0)
 	if err != nil {
 		return err
 	}
@@ -468,9 +924,10 @@ func (c *PFCPClient) SetupAssociation() error {
 		return NewInvalidResponseError(errAssocFailed)
 	}
 
-	c.setAssociationStatus(true)
-
-	go c.StartHeartbeats()
+	c.peerReleased.Store(false)
+	c.resetAssociationState(append(append([]*ieLib.IE{assocResp.NodeID, assocResp.UPFunctionFeatures},
+		assocResp.UserPlaneIPResourceInformation...), assocResp.AlternativeSMFIPAddress...)...)
+	c.setAssociationStatus(true, "association setup accepted")
 
 	return nil
 }
@@ -485,22 +942,22 @@ func (c *PFCPClient) IsAssociationAlive() bool {
 // TeardownAssociation tears down an already established association.
 // If called while no association is established, an error is returned
 func (c *PFCPClient) TeardownAssociation() error {
-	if !c.IsAssociationAlive() {
-		return NewAssociationInactiveError()
-	}
+	// an association released by the peer is only torn down locally
+	released := c.peerReleased.Swap(false)
 
-	err := c.SendAssociationTeardownRequest()
-	if err != nil {
-		return err
+	if !released && !c.IsAssociationAlive() {
+		return NewAssociationInactiveError()
 	}
 
-	resp, err := c.PeekNextResponse()
-	if err != nil {
-		return err
-	}
+	if !released {
+		resp, err := c.transact(c.newAssociationTeardownRequest(), 0)
+		if err != nil {
+			return err
+		}
 
-	if _, ok := resp.(*message.AssociationReleaseResponse); !ok {
-		return NewInvalidResponseError()
+		if _, ok := resp.(*message.AssociationReleaseResponse); !ok {
+			return NewInvalidResponseError()
+		}
 	}
 
 	if c.cancel != nil {
@@ -508,7 +965,7 @@ func (c *PFCPClient) TeardownAssociation() error {
 		c.cancel = nil
 	}
 
-	c.setAssociationStatus(false)
+	c.setAssociationStatus(false, "association released")
 
 	return nil
 }
@@ -518,92 +975,176 @@ func (c *PFCPClient) TeardownAssociation() error {
 func (c *PFCPClient) EstablishSession(pdrs []*ieLib.IE, fars []*ieLib.IE,
 	qers []*ieLib.IE, urrs []*ieLib.IE,
 ) (*PFCPSession, error) {
-	if !c.isAssociationActive {
+	return c.EstablishSessionWithBAR(pdrs, fars, qers, urrs, nil)
+}
+
+// EstablishSessionWithBAR is like EstablishSession, but also creates the Buffering Action Rule bar
+// referenced by the FARs. bar may be nil.
+func (c *PFCPClient) EstablishSessionWithBAR(pdrs []*ieLib.IE, fars []*ieLib.IE,
+	qers []*ieLib.IE, urrs []*ieLib.IE, bar *ieLib.IE,
+) (*PFCPSession, error) {
+	if !c.IsAssociationAlive() {
 		return nil, NewAssociationInactiveError()
 	}
 
-	err := c.SendSessionEstablishmentRequest(pdrs, fars, qers, urrs)
+	localSEID := c.getNextFSEID()
+
+	est, err := c.establish(localSEID, pdrs, fars, qers, urrs, bar)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.PeekNextResponse()
+	sess := newPFCPSession(localSEID, hostOnly(c.localAddr), est, pdrs, fars, qers, urrs)
+	sess.bar = bar
+
+	return sess, nil
+}
+
+// establishment is the result of a successful Session Establishment procedure.
+type establishment struct {
+	peerSEID    uint64
+	peerAddress string
+	createdPDRs []CreatedPDR
+}
+
+// establish performs the Session Establishment procedure and returns the F-SEID allocated by the peer
+// together with the Created PDRs.
+func (c *PFCPClient) establish(localSEID uint64, pdrs []*ieLib.IE, fars []*ieLib.IE,
+	qers []*ieLib.IE, urrs []*ieLib.IE, bar *ieLib.IE,
+) (*establishment, error) {
+	if err := c.checkUPFunctionFeatures(pdrs); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.transactSession(c.newSessionEstablishmentRequest(localSEID, pdrs, fars, qers, urrs, bar), localSEID)
 	if err != nil {
 		return nil, NewTimeoutExpiredError(err)
 	}
 
 	estResp, ok := resp.(*message.SessionEstablishmentResponse)
 	if !ok {
+		return nil, NewInvalidResponseError(errWrongRspType)
+	}
+
+	if err := checkCause(estResp.Cause); err != nil {
+		return nil, err
+	}
+
+	if estResp.UPFSEID == nil {
+		return nil, NewInvalidResponseError(errMissingUPFSEID)
+	}
+
+	remoteFSEID, err := estResp.UPFSEID.FSEID()
+	if err != nil {
 		return nil, NewInvalidResponseError(err)
 	}
 
-	if cause, errCause := estResp.Cause.Cause(); errCause != nil || cause != ieLib.CauseRequestAccepted {
-		return nil, NewInvalidCauseError(errCause)
+	est := &establishment{
+		peerSEID:    remoteFSEID.SEID,
+		createdPDRs: parseCreatedPDRs(estResp.CreatedPDR),
+	}
+
+	switch {
+	case remoteFSEID.IPv4Address != nil:
+		est.peerAddress = remoteFSEID.IPv4Address.String()
+	case remoteFSEID.IPv6Address != nil:
+		est.peerAddress = remoteFSEID.IPv6Address.String()
+	}
+
+	return est, nil
+}
+
+// checkCause returns an error if the Cause IE of a response is missing or is not Request accepted.
+func checkCause(cause *ieLib.IE) error {
+	if cause == nil {
+		return NewInvalidCauseError(errMissingCause)
 	}
 
-	remoteSEID, err := estResp.UPFSEID.FSEID()
+	value, err := cause.Cause()
 	if err != nil {
-		return nil, err
+		return NewInvalidCauseError(err)
 	}
 
-	sess := &PFCPSession{
-		localSEID: c.lastFSEID,
-		peerSEID:  remoteSEID.SEID,
+	if value != ieLib.CauseRequestAccepted {
+		return newRejectedError(value)
 	}
 
-	return sess, nil
+	return nil
 }
 
+// ModifySession sends a Session Modification Request for sess and awaits for the response. The IEs may be
+// Create, Update or Remove IEs of any rule type, or Query URR IEs: each is placed in the matching field of
+// the request, whatever the list it is passed in. The rules of sess are updated on success.
 func (c *PFCPClient) ModifySession(sess *PFCPSession, pdrs []*ieLib.IE, fars []*ieLib.IE,
 	qers []*ieLib.IE, urrs []*ieLib.IE,
 ) error {
-	if !c.isAssociationActive {
+	if !c.IsAssociationAlive() {
 		return NewAssociationInactiveError()
 	}
 
-	err := c.SendSessionModificationRequest(sess.peerSEID, pdrs, fars, qers, urrs)
-	if err != nil {
+	if err := c.checkUPFunctionFeatures(pdrs); err != nil {
 		return err
 	}
 
-	resp, err := c.PeekNextResponse()
+	resp, err := c.transactSession(c.newSessionModificationRequest(sess.PeerSEID(), pdrs, fars, qers, urrs), sess.localSEID)
 	if err != nil {
 		return NewTimeoutExpiredError(err)
 	}
 
 	modRes, ok := resp.(*message.SessionModificationResponse)
 	if !ok {
-		return NewInvalidResponseError(err)
+		return NewInvalidResponseError(errWrongRspType)
 	}
 
-	if cause, err := modRes.Cause.Cause(); err != nil || cause != ieLib.CauseRequestAccepted {
-		return NewInvalidCauseError(err)
+	if err := checkCause(modRes.Cause); err != nil {
+		return err
 	}
 
+	sess.applyModification(parseCreatedPDRs(modRes.CreatedPDR), pdrs, fars, qers, urrs)
+	storeUsageReports(sess, modRes.UsageReport)
+
 	return nil
 }
 
+// QueryURRs asks the peer for an immediate usage report of the URRs urrIDs of sess, every URR of sess if none is
+// given, by sending a Session Modification Request with Query URR IEs. Reports are stored in sess.
+func (c *PFCPClient) QueryURRs(sess *PFCPSession, urrIDs ...uint32) error {
+	if len(urrIDs) == 0 {
+		urrIDs = sess.URRIDs()
+	}
+
+	queries := make([]*ieLib.IE, 0, len(urrIDs))
+	for _, id := range urrIDs {
+		queries = append(queries, session.NewQueryURR(id))
+	}
+
+	return c.ModifySession(sess, nil, nil, nil, queries)
+}
+
 // DeleteSession sends Session Deletion Request for each session and awaits for PFCP Session Deletion Response.
 // Returns error if the process fails at any stage.
+// A session released by the peer is not installed in the peer anymore: it is deleted without any request.
 func (c *PFCPClient) DeleteSession(sess *PFCPSession) error {
-	err := c.SendSessionDeletionRequest(sess.localSEID, sess.peerSEID)
-	if err != nil {
-		return err
+	if sess.IsReleased() {
+		return nil
 	}
 
-	resp, err := c.PeekNextResponse()
+	resp, err := c.transactSession(c.newSessionDeletionRequest(sess.localSEID, sess.PeerSEID()), sess.localSEID)
 	if err != nil {
-		return err
+		return NewTimeoutExpiredError(err)
 	}
 
 	delResp, ok := resp.(*message.SessionDeletionResponse)
 	if !ok {
-		return NewInvalidResponseError()
+		return NewInvalidResponseError(errWrongRspType)
 	}
 
-	if cause, err := delResp.Cause.Cause(); err != nil || cause != ieLib.CauseRequestAccepted {
-		return NewInvalidCauseError(err)
+	if err := checkCause(delResp.Cause); err != nil {
+		return err
 	}
 
+	// final usage reports of the session
+	storeUsageReports(sess, delResp.UsageReport)
+
 	return nil
 }
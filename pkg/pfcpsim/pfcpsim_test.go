@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"testing"
+	"time"
+
+	ieLib "github.com/wmnsk/go-pfcp/ie"
+)
+
+func TestUpdatePeerRecoveryTimeStamp(t *testing.T) {
+	client := NewPFCPClient("127.0.0.1")
+
+	first := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	restart := first.Add(time.Hour)
+
+	client.updatePeerRecoveryTimeStamp(ieLib.NewRecoveryTimeStamp(first))
+	client.updatePeerRecoveryTimeStamp(ieLib.NewRecoveryTimeStamp(first))
+
+	if !client.PeerRecoveryTimeStamp().Equal(first) {
+		t.Fatalf("peer Recovery Time Stamp mismatch. got = %v, want = %v", client.PeerRecoveryTimeStamp(), first)
+	}
+
+	select {
+	case event := <-client.Events():
+		t.Fatalf("unexpected event %v: %v", event.Type, event.Details)
+	default:
+	}
+
+	client.updatePeerRecoveryTimeStamp(ieLib.NewRecoveryTimeStamp(restart))
+
+	select {
+	case event := <-client.Events():
+		if event.Type != EventPeerRestarted {
+			t.Errorf("event type mismatch. got = %v, want = %v", event.Type, EventPeerRestarted)
+		}
+	default:
+		t.Fatal("expected a peer restarted event")
+	}
+
+	if !client.PeerRecoveryTimeStamp().Equal(restart) {
+		t.Errorf("peer Recovery Time Stamp mismatch. got = %v, want = %v", client.PeerRecoveryTimeStamp(), restart)
+	}
+}
+
+func TestSetAssociationStatusEvents(t *testing.T) {
+	client := NewPFCPClient("127.0.0.1")
+
+	client.setAssociationStatus(true, "setup")
+	client.setAssociationStatus(true, "heartbeat received")
+	client.setAssociationStatus(false, "heartbeat failure")
+	client.setAssociationStatus(false, "heartbeat failure")
+
+	for _, expected := range []EventType{EventAssociationUp, EventAssociationDown} {
+		select {
+		case event := <-client.Events():
+			if event.Type != expected {
+				t.Errorf("event type mismatch. got = %v, want = %v", event.Type, expected)
+			}
+		default:
+			t.Fatalf("expected a %v event", expected)
+		}
+	}
+
+	select {
+	case event := <-client.Events():
+		t.Errorf("unexpected event %v: %v", event.Type, event.Details)
+	default:
+	}
+}
@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"fmt"
+
+	"github.com/omec-project/pfcpsim/logger"
+)
+
+// EnableAutoRecovery turns on or off the recovery mode. When enabled, the client detects
+// a restart of the peer (changed Recovery Time Stamp or heartbeat loss followed by recovery),
+// sets up the association again and replays the establishment of every active session
+// with the rules it was originally created with.
+func (c *PFCPClient) EnableAutoRecovery(enable bool) {
+	c.peerRestarted.Store(false)
+	c.autoRecovery.Store(enable)
+}
+
+func (c *PFCPClient) IsAutoRecoveryEnabled() bool {
+	return c.autoRecovery.Load()
+}
+
+// recoverAssociation re-runs Association Setup and re-establishes the active sessions.
+func (c *PFCPClient) recoverAssociation() {
+	logger.PfcpsimLog.Warnln("peer restart detected, recovering association")
+
+	c.setAssociationStatus(false, "peer restart detected")
+
+	if err := c.setupAssociation(); err != nil {
+		details := fmt.Sprintf("could not recover association: %v", err)
+		logger.PfcpsimLog.Errorln(details)
+		c.emitEvent(EventRecoveryFailed, nil, details)
+
+		return
+	}
+
+	// the Association Setup Response carries the Recovery Time Stamp we are recovering from
+	c.peerRestarted.Store(false)
+
+	sessions := getSessions()
+	recovered := 0
+
+	for _, sess := range sessions {
+		if err := c.reestablishSession(sess); err != nil {
+			logger.PfcpsimLog.Errorf("could not re-establish session with local SEID %v: %v", sess.localSEID, err)
+			continue
+		}
+
+		recovered++
+	}
+
+	details := fmt.Sprintf("association recovered, %v of %v sessions re-established", recovered, len(sessions))
+	logger.PfcpsimLog.Infoln(details)
+	c.emitEvent(EventAssociationRecovered, nil, details)
+}
+
+// reestablishSession replays the establishment of sess with its current rules and local SEID.
+func (c *PFCPClient) reestablishSession(sess *PFCPSession) error {
+	pdrs, fars, qers, urrs := sess.rules()
+
+	est, err := c.establish(sess.localSEID, pdrs, fars, qers, urrs, sess.bar)
+	if err != nil {
+		return err
+	}
+
+	sess.setEstablished(est)
+
+	return nil
+}
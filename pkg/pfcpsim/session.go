@@ -3,7 +3,940 @@
 
 package pfcpsim
 
+import (
+	"fmt"
+	"net"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/omec-project/pfcpsim/logger"
+	"github.com/omec-project/pfcpsim/pkg/pfcpsim/session"
+	ieLib "github.com/wmnsk/go-pfcp/ie"
+)
+
+// CreatedPDR is the content of a Created PDR IE, returned by the peer for a PDR
+// whose F-TEID or UE IP address is allocated by the UP function.
+type CreatedPDR struct {
+	PDRID       uint16
+	TEID        uint32
+	IPv4Address net.IP
+	IPv6Address net.IP
+	// UEAddress and UEIPv6Prefix (in CIDR notation) are set if allocated by the UP function
+	UEAddress    net.IP
+	UEIPv6Prefix string
+}
+
 type PFCPSession struct {
+	lock sync.Mutex
+
 	localSEID uint64
 	peerSEID  uint64
+	// localAddress and peerAddress are the IP addresses of the local and peer F-SEIDs
+	localAddress string
+	peerAddress  string
+
+	// rules installed in the session. They are replayed if the peer restarts.
+	pdrs []*ieLib.IE
+	fars []*ieLib.IE
+	qers []*ieLib.IE
+	urrs []*ieLib.IE
+	// bar is the Create BAR IE the session was established with, if any
+	bar *ieLib.IE
+	// ids allocates the IDs of the rules of the session
+	ids *session.IDAllocator
+
+	// createdPDRs returned by the peer, indexed by PDR ID
+	createdPDRs map[uint16]CreatedPDR
+
+	// gnbAddress and gnbTEID are the N3 address and TEID of the gNB set in downlink FARs, if any.
+	// The TEID is kept while the downlink FARs buffer, so that forwarding can be restored.
+	gnbAddress string
+	gnbTEID    uint32
+
+	createdAt  time.Time
+	modifiedAt time.Time
+
+	// usageReports received from the peer, oldest first
+	usageReports []UsageReport
+
+	// paging is set while a paging triggered by a Downlink Data Report is in progress
+	paging bool
+	// pagings completed for the session, oldest first
+	pagings []Paging
+
+	// released is set when the peer released the association, and the session with it
+	released bool
+	// userPlanePathFailure is set while the peer reports a failure of the user plane path towards the gNB
+	userPlanePathFailure bool
+}
+
+func newPFCPSession(localSEID uint64, localAddress string, est *establishment,
+	pdrs, fars, qers, urrs []*ieLib.IE,
+) *PFCPSession {
+	now := time.Now()
+
+	sess := &PFCPSession{
+		localSEID:    localSEID,
+		localAddress: localAddress,
+		pdrs:         pdrs,
+		fars:         fars,
+		qers:         qers,
+		urrs:         urrs,
+		createdAt:    now,
+		modifiedAt:   now,
+	}
+
+	sess.setEstablished(est)
+
+	for _, far := range fars {
+		sess.setGNBTunnel(far)
+	}
+
+	return sess
+}
+
+// LocalSEID returns the SEID allocated by pfcpsim for this session.
+func (s *PFCPSession) LocalSEID() uint64 {
+	return s.localSEID
+}
+
+// PeerSEID returns the SEID allocated by the peer for this session.
+func (s *PFCPSession) PeerSEID() uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.peerSEID
+}
+
+// LocalAddress returns the IP address of the F-SEID allocated by pfcpsim for this session.
+func (s *PFCPSession) LocalAddress() string {
+	return s.localAddress
+}
+
+// PeerAddress returns the IP address of the F-SEID allocated by the peer for this session.
+func (s *PFCPSession) PeerAddress() string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.peerAddress
+}
+
+// SetIDAllocator sets the allocator of the rule IDs of the session, usually the one its rules were built with.
+func (s *PFCPSession) SetIDAllocator(ids *session.IDAllocator) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.ids = ids
+}
+
+// IDAllocator returns the allocator of the rule IDs of the session. If none was set, one reserving
+// the IDs of the installed rules is created.
+func (s *PFCPSession) IDAllocator() *session.IDAllocator {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.ids == nil {
+		s.ids = session.NewIDAllocator()
+
+		for _, id := range ruleIDs(s.pdrs, pdrID) {
+			s.ids.Reserve(session.RulePDR, id)
+		}
+
+		for _, id := range ruleIDs(s.fars, (*ieLib.IE).FARID) {
+			s.ids.Reserve(session.RuleFAR, id)
+		}
+
+		for _, id := range ruleIDs(s.qers, (*ieLib.IE).QERID) {
+			s.ids.Reserve(session.RuleQER, id)
+		}
+
+		for _, id := range ruleIDs(s.urrs, (*ieLib.IE).URRID) {
+			s.ids.Reserve(session.RuleURR, id)
+		}
+
+		if s.bar != nil {
+			if id, err := s.bar.BARID(); err == nil {
+				s.ids.Reserve(session.RuleBAR, uint32(id))
+			}
+		}
+	}
+
+	return s.ids
+}
+
+// DownlinkRules returns the IDs of the FARs forwarding to the access interface and
+// the IDs of the URRs of the PDRs using those FARs.
+func (s *PFCPSession) DownlinkRules() ([]uint32, []uint32) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var farIDs, urrIDs []uint32
+
+	for _, far := range s.fars {
+		id, err := far.FARID()
+		if err != nil || !forwardsTo(far, ieLib.DstInterfaceAccess) {
+			continue
+		}
+
+		farIDs = append(farIDs, id)
+
+		for _, pdr := range s.pdrs {
+			if pdrFARID, err := pdr.FARID(); err != nil || pdrFARID != id {
+				continue
+			}
+
+			for _, urrID := range ruleIDs(childrenOfType(pdr, ieLib.URRID), (*ieLib.IE).URRID) {
+				if !slices.Contains(urrIDs, urrID) {
+					urrIDs = append(urrIDs, urrID)
+				}
+			}
+		}
+	}
+
+	return farIDs, urrIDs
+}
+
+// CreatedPDRs returns the Created PDRs returned by the peer, ordered by PDR ID.
+func (s *PFCPSession) CreatedPDRs() []CreatedPDR {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	created := make([]CreatedPDR, 0, len(s.createdPDRs))
+	for _, pdr := range s.createdPDRs {
+		created = append(created, pdr)
+	}
+
+	slices.SortFunc(created, func(a, b CreatedPDR) int {
+		return int(a.PDRID) - int(b.PDRID)
+	})
+
+	return created
+}
+
+// setEstablished stores the result of a Session Establishment, replacing the one of a previous establishment.
+func (s *PFCPSession) setEstablished(est *establishment) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.peerSEID = est.peerSEID
+	s.peerAddress = est.peerAddress
+	s.released = false
+	s.createdPDRs = make(map[uint16]CreatedPDR, len(est.createdPDRs))
+	s.addCreatedPDRs(est.createdPDRs)
+}
+
+// addCreatedPDRs stores created, replacing the Created PDRs with the same ID. The lock must be held.
+func (s *PFCPSession) addCreatedPDRs(created []CreatedPDR) {
+	for _, pdr := range created {
+		s.createdPDRs[pdr.PDRID] = pdr
+	}
+}
+
+// IsReleased reports whether the session was released by the peer along with the association.
+// A released session is not installed in the peer anymore.
+func (s *PFCPSession) IsReleased() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.released
+}
+
+func (s *PFCPSession) setReleased() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.released = true
+}
+
+// HasUserPlanePathFailure reports whether the peer reported a failure of the user plane path towards the gNB
+// of the session, not recovered yet.
+func (s *PFCPSession) HasUserPlanePathFailure() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.userPlanePathFailure
+}
+
+func (s *PFCPSession) setUserPlanePathFailure(failed bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.userPlanePathFailure = failed
+}
+
+// UsageReports returns the usage reports received for this session, oldest first.
+// At most the last maxUsageReports reports are kept.
+func (s *PFCPSession) UsageReports() []UsageReport {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return append([]UsageReport(nil), s.usageReports...)
+}
+
+func (s *PFCPSession) addUsageReports(reports []UsageReport) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.usageReports = append(s.usageReports, reports...)
+	if exceeding := len(s.usageReports) - maxUsageReports; exceeding > 0 {
+		s.usageReports = append([]UsageReport(nil), s.usageReports[exceeding:]...)
+	}
+}
+
+// Pagings returns the pagings completed for this session, oldest first.
+// At most the last maxPagings pagings are kept.
+func (s *PFCPSession) Pagings() []Paging {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return append([]Paging(nil), s.pagings...)
+}
+
+func (s *PFCPSession) addPaging(paging Paging) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.pagings = append(s.pagings, paging)
+	if exceeding := len(s.pagings) - maxPagings; exceeding > 0 {
+		s.pagings = append([]Paging(nil), s.pagings[exceeding:]...)
+	}
+}
+
+// startPaging marks the session as being paged. Returns false if it already is.
+func (s *PFCPSession) startPaging() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.paging {
+		return false
+	}
+
+	s.paging = true
+
+	return true
+}
+
+func (s *PFCPSession) endPaging() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.paging = false
+}
+
+// CreatedAt returns the time the session was established.
+func (s *PFCPSession) CreatedAt() time.Time {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.createdAt
+}
+
+// ModifiedAt returns the time the session was last modified, or established if never modified.
+func (s *PFCPSession) ModifiedAt() time.Time {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.modifiedAt
+}
+
+// GNBAddress returns the gNB address set in the downlink FARs of the session. Empty if not set yet.
+func (s *PFCPSession) GNBAddress() string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.gnbAddress
+}
+
+// GNBTunnel returns the gNB address and TEID last set in the downlink FARs of the session to forward packets.
+// The address is empty if not set yet.
+func (s *PFCPSession) GNBTunnel() (string, uint32) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.gnbAddress, s.gnbTEID
+}
+
+// UEAddresses returns the UE IPv4 address and the UE IPv6 prefix (in CIDR notation) found in the PDRs.
+func (s *PFCPSession) UEAddresses() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var addresses []string
+
+	for _, pdr := range s.pdrs {
+		ueIP, err := pdr.UEIPAddress()
+		if err != nil {
+			continue
+		}
+
+		// addresses allocated by the peer are only found in the Created PDRs
+		var created CreatedPDR
+		if id, err := pdr.PDRID(); err == nil {
+			created = s.createdPDRs[id]
+		}
+
+		switch {
+		case ueIP.Flags&session.UEIPAddressCHV4 != 0:
+			if created.UEAddress != nil {
+				addresses = appendUnique(addresses, created.UEAddress.String())
+			}
+		case ueIP.IPv4Address != nil:
+			addresses = appendUnique(addresses, ueIP.IPv4Address.String())
+		}
+
+		switch {
+		case ueIP.Flags&session.UEIPAddressCHV6 != 0:
+			if created.UEIPv6Prefix != "" {
+				addresses = appendUnique(addresses, created.UEIPv6Prefix)
+			}
+		case ueIP.IPv6Address != nil:
+			addresses = appendUnique(addresses, ueIPv6Prefix(ueIP))
+		}
+	}
+
+	return addresses
+}
+
+// ueIPv6Prefix returns the IPv6 prefix of ueIP in CIDR notation.
+func ueIPv6Prefix(ueIP *ieLib.UEIPAddressFields) string {
+	prefixLength := session.DefaultIPv6PrefixLength
+	if ueIP.Flags&session.UEIPAddressIP6PL != 0 {
+		prefixLength = int(ueIP.IPv6PrefixLength)
+	}
+
+	return fmt.Sprintf("%v/%v", ueIP.IPv6Address, prefixLength)
+}
+
+// UplinkTEID returns the TEID of the first PDR matching on a F-TEID. 0 if none is found.
+// If the F-TEID is allocated by the UPF, the TEID returned in the Created PDR IE is used.
+func (s *PFCPSession) UplinkTEID() uint32 {
+	teid, _ := s.UplinkFTEID()
+	return teid
+}
+
+// UplinkFTEID returns the TEID and the IP address of the first PDR matching on a F-TEID.
+// 0 and nil if none is found. If the F-TEID is allocated by the UPF, the Created PDR IE is used.
+func (s *PFCPSession) UplinkFTEID() (uint32, net.IP) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, pdr := range s.pdrs {
+		pdi, err := pdr.PDI()
+		if err != nil {
+			continue
+		}
+
+		for _, i := range pdi {
+			if i.Type != ieLib.FTEID {
+				continue
+			}
+
+			fteid, err := i.FTEID()
+			if err != nil {
+				continue
+			}
+
+			if !fteid.HasCh() {
+				return fteid.TEID, fteidAddress(fteid.IPv4Address, fteid.IPv6Address)
+			}
+
+			if id, err := pdr.PDRID(); err == nil {
+				created := s.createdPDRs[id]
+				return created.TEID, fteidAddress(created.IPv4Address, created.IPv6Address)
+			}
+		}
+	}
+
+	return 0, nil
+}
+
+// fteidAddress returns the IPv4 address of a F-TEID if set, the IPv6 one otherwise.
+func fteidAddress(ipv4, ipv6 net.IP) net.IP {
+	if ipv4 != nil {
+		return slices.Clone(ipv4)
+	}
+
+	return slices.Clone(ipv6)
+}
+
+// UplinkFARIDs returns the IDs of the FARs forwarding to the core interface.
+func (s *PFCPSession) UplinkFARIDs() []uint32 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var ids []uint32
+
+	for _, far := range s.fars {
+		if id, err := far.FARID(); err == nil && forwardsTo(far, ieLib.DstInterfaceCore) {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+// SharedQERIDs returns the IDs of the QERs referenced by every PDR of the session, such as a session QER.
+func (s *PFCPSession) SharedQERIDs() []uint32 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var shared []uint32
+
+	for i, pdr := range s.pdrs {
+		ids := ruleIDs(childrenOfType(pdr, ieLib.QERID), (*ieLib.IE).QERID)
+		if i == 0 {
+			shared = ids
+			continue
+		}
+
+		shared = slices.DeleteFunc(shared, func(id uint32) bool {
+			return !slices.Contains(ids, id)
+		})
+	}
+
+	return shared
+}
+
+// AppFilterPDRIDs returns the IDs of the PDRs matching on the SDF filter sdfFilter.
+// An empty sdfFilter returns the PDRs without SDF filter.
+func (s *PFCPSession) AppFilterPDRIDs(sdfFilter string) []uint16 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var ids []uint16
+
+	for _, pdr := range s.pdrs {
+		id, err := pdr.PDRID()
+		if err != nil || pdrSDFFilter(pdr) != sdfFilter {
+			continue
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+// pdrSDFFilter returns the flow description of the SDF filter of the Create PDR pdr. Empty if none.
+func pdrSDFFilter(pdr *ieLib.IE) string {
+	pdi, err := pdr.PDI()
+	if err != nil {
+		return ""
+	}
+
+	for _, i := range pdi {
+		if i.Type != ieLib.SDFFilter {
+			continue
+		}
+
+		if filter, err := i.SDFFilter(); err == nil {
+			return filter.FlowDescription
+		}
+	}
+
+	return ""
+}
+
+// RemoveIEs returns the IEs removing the PDRs pdrIDs from the session, along with the FARs, QERs and URRs
+// that only those PDRs reference. Rules shared with the other PDRs of the session are kept.
+func (s *PFCPSession) RemoveIEs(pdrIDs ...uint16) []*ieLib.IE {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var (
+		removed                      []*ieLib.IE
+		farIDs, qerIDs, urrIDs       []uint32
+		keptFARs, keptQERs, keptURRs []uint32
+	)
+
+	for _, pdr := range s.pdrs {
+		id, err := pdr.PDRID()
+		if err != nil {
+			continue
+		}
+
+		var farID []uint32
+		if far, err := pdr.FARID(); err == nil {
+			farID = []uint32{far}
+		}
+
+		qers := ruleIDs(childrenOfType(pdr, ieLib.QERID), (*ieLib.IE).QERID)
+		urrs := ruleIDs(childrenOfType(pdr, ieLib.URRID), (*ieLib.IE).URRID)
+
+		if !slices.Contains(pdrIDs, id) {
+			keptFARs = append(keptFARs, farID...)
+			keptQERs = append(keptQERs, qers...)
+			keptURRs = append(keptURRs, urrs...)
+
+			continue
+		}
+
+		removed = append(removed, ieLib.NewRemovePDR(ieLib.NewPDRID(id)))
+		farIDs = append(farIDs, farID...)
+		qerIDs = append(qerIDs, qers...)
+		urrIDs = append(urrIDs, urrs...)
+	}
+
+	for _, id := range unusedIDs(farIDs, keptFARs) {
+		removed = append(removed, ieLib.NewRemoveFAR(ieLib.NewFARID(id)))
+	}
+
+	for _, id := range unusedIDs(qerIDs, keptQERs) {
+		removed = append(removed, ieLib.NewRemoveQER(ieLib.NewQERID(id)))
+	}
+
+	for _, id := range unusedIDs(urrIDs, keptURRs) {
+		removed = append(removed, ieLib.NewRemoveURR(ieLib.NewURRID(id)))
+	}
+
+	return removed
+}
+
+// unusedIDs returns the IDs of ids not in used, without duplicates.
+func unusedIDs(ids, used []uint32) []uint32 {
+	var unused []uint32
+
+	for _, id := range ids {
+		if !slices.Contains(used, id) && !slices.Contains(unused, id) {
+			unused = append(unused, id)
+		}
+	}
+
+	return unused
+}
+
+// PDRIDs returns the IDs of the PDRs installed in the session.
+func (s *PFCPSession) PDRIDs() []uint16 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	ids := make([]uint16, 0, len(s.pdrs))
+
+	for _, pdr := range s.pdrs {
+		if id, err := pdr.PDRID(); err == nil {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+// FARIDs returns the IDs of the FARs installed in the session.
+func (s *PFCPSession) FARIDs() []uint32 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return ruleIDs(s.fars, (*ieLib.IE).FARID)
+}
+
+// QERIDs returns the IDs of the QERs installed in the session.
+func (s *PFCPSession) QERIDs() []uint32 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return ruleIDs(s.qers, (*ieLib.IE).QERID)
+}
+
+// URRIDs returns the IDs of the URRs installed in the session.
+func (s *PFCPSession) URRIDs() []uint32 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return ruleIDs(s.urrs, (*ieLib.IE).URRID)
+}
+
+// rules returns a copy of the rules installed in the session.
+func (s *PFCPSession) rules() ([]*ieLib.IE, []*ieLib.IE, []*ieLib.IE, []*ieLib.IE) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return slices.Clone(s.pdrs), slices.Clone(s.fars), slices.Clone(s.qers), slices.Clone(s.urrs)
+}
+
+// applyModification updates the rules of the session after a successful Session Modification.
+// Create IEs are added, Remove IEs delete the rule with the same ID and Update IEs are merged into it, so that
+// the session is re-established with its current rules on recovery. Update FARs may change the gNB address.
+// created are the Created PDRs returned by the peer.
+func (s *PFCPSession) applyModification(created []CreatedPDR, ies ...[]*ieLib.IE) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.addCreatedPDRs(created)
+
+	for _, list := range ies {
+		for _, i := range list {
+			switch i.Type {
+			case ieLib.CreatePDR:
+				s.pdrs = append(s.pdrs, i)
+				s.reserveID(session.RulePDR, i, pdrID)
+			case ieLib.CreateFAR:
+				s.fars = append(s.fars, i)
+				s.reserveID(session.RuleFAR, i, (*ieLib.IE).FARID)
+			case ieLib.CreateQER:
+				s.qers = append(s.qers, i)
+				s.reserveID(session.RuleQER, i, (*ieLib.IE).QERID)
+			case ieLib.CreateURR:
+				s.urrs = append(s.urrs, i)
+				s.reserveID(session.RuleURR, i, (*ieLib.IE).URRID)
+			case ieLib.RemovePDR:
+				s.pdrs = removeRule(s.pdrs, i, pdrID)
+
+				if id, err := ruleIDFromRemove(i, pdrID); err == nil {
+					delete(s.createdPDRs, uint16(id))
+					s.releaseID(session.RulePDR, id)
+				}
+			case ieLib.RemoveFAR:
+				s.fars = removeRule(s.fars, i, (*ieLib.IE).FARID)
+				s.releaseRemovedID(session.RuleFAR, i, (*ieLib.IE).FARID)
+			case ieLib.RemoveQER:
+				s.qers = removeRule(s.qers, i, (*ieLib.IE).QERID)
+				s.releaseRemovedID(session.RuleQER, i, (*ieLib.IE).QERID)
+			case ieLib.RemoveURR:
+				s.urrs = removeRule(s.urrs, i, (*ieLib.IE).URRID)
+				s.releaseRemovedID(session.RuleURR, i, (*ieLib.IE).URRID)
+			case ieLib.UpdatePDR:
+				s.pdrs = updateRule(s.pdrs, i, pdrID)
+			case ieLib.UpdateFAR:
+				s.fars = updateRule(s.fars, i, (*ieLib.IE).FARID)
+				s.setGNBTunnel(i)
+			case ieLib.UpdateQER:
+				s.qers = updateRule(s.qers, i, (*ieLib.IE).QERID)
+			case ieLib.UpdateURR:
+				s.urrs = updateRule(s.urrs, i, (*ieLib.IE).URRID)
+			}
+		}
+	}
+
+	s.modifiedAt = time.Now()
+}
+
+// reserveID marks the ID of the created rule as used in the ID allocator, if any. The lock must be held.
+func (s *PFCPSession) reserveID(t session.RuleType, rule *ieLib.IE, getID func(*ieLib.IE) (uint32, error)) {
+	if s.ids == nil {
+		return
+	}
+
+	if id, err := getID(rule); err == nil {
+		s.ids.Reserve(t, id)
+	}
+}
+
+// releaseRemovedID makes the ID of the removed rule available in the ID allocator, if any. The lock must be held.
+func (s *PFCPSession) releaseRemovedID(t session.RuleType, remove *ieLib.IE, getID func(*ieLib.IE) (uint32, error)) {
+	if id, err := ruleIDFromRemove(remove, getID); err == nil {
+		s.releaseID(t, id)
+	}
+}
+
+// releaseID makes id available in the ID allocator, if any. The lock must be held.
+func (s *PFCPSession) releaseID(t session.RuleType, id uint32) {
+	if s.ids != nil {
+		s.ids.Release(t, id)
+	}
+}
+
+// ruleIDs returns the IDs of rules read with getID.
+func ruleIDs(rules []*ieLib.IE, getID func(*ieLib.IE) (uint32, error)) []uint32 {
+	ids := make([]uint32, 0, len(rules))
+
+	for _, rule := range rules {
+		if id, err := getID(rule); err == nil {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+// ruleIDFromRemove returns the ID of the rule deleted by the Remove IE remove.
+// The ID is read either directly from remove or from the Create IE it wraps.
+func ruleIDFromRemove(remove *ieLib.IE, getID func(*ieLib.IE) (uint32, error)) (uint32, error) {
+	id, err := getID(remove)
+	if err == nil {
+		return id, nil
+	}
+
+	children, parseErr := ieLib.ParseMultiIEs(remove.Payload)
+	if parseErr != nil || len(children) == 0 {
+		return 0, err
+	}
+
+	return getID(children[0])
+}
+
+// removeRule deletes from rules the one having the same ID of the Remove IE remove.
+func removeRule(rules []*ieLib.IE, remove *ieLib.IE, getID func(*ieLib.IE) (uint32, error)) []*ieLib.IE {
+	id, err := ruleIDFromRemove(remove, getID)
+	if err != nil {
+		return rules
+	}
+
+	return slices.DeleteFunc(rules, func(rule *ieLib.IE) bool {
+		ruleID, err := getID(rule)
+		return err == nil && ruleID == id
+	})
+}
+
+// updateRule merges the Update IE update into the rule of rules having the same ID. The rules are copied,
+// not to modify the ones the session was created with.
+func updateRule(rules []*ieLib.IE, update *ieLib.IE, getID func(*ieLib.IE) (uint32, error)) []*ieLib.IE {
+	id, err := getID(update)
+	if err != nil {
+		return rules
+	}
+
+	for idx, rule := range rules {
+		if ruleID, err := getID(rule); err != nil || ruleID != id {
+			continue
+		}
+
+		merged, err := session.MergeRule(rule, update)
+		if err != nil {
+			logger.PfcpsimLog.Warnf("could not merge %v with ID %v into the session rules: %v", update.Type, id, err)
+			return rules
+		}
+
+		rules = slices.Clone(rules)
+		rules[idx] = merged
+
+		return rules
+	}
+
+	return rules
+}
+
+// setGNBTunnel records the gNB address and TEID set in the Create or Update FAR far, if any.
+// A TEID 0, used while buffering, does not replace the known one. The lock must be held.
+func (s *PFCPSession) setGNBTunnel(far *ieLib.IE) {
+	addr, teid := gnbTunnelFromFAR(far)
+	if addr == "" {
+		return
+	}
+
+	s.gnbAddress = addr
+
+	if teid != 0 {
+		s.gnbTEID = teid
+	}
+}
+
+// gnbTunnelFromFAR returns the address and the TEID set in the Outer Header Creation of a Create or Update FAR.
+func gnbTunnelFromFAR(far *ieLib.IE) (string, uint32) {
+	var (
+		params []*ieLib.IE
+		err    error
+	)
+
+	switch far.Type {
+	case ieLib.CreateFAR:
+		params, err = far.ForwardingParameters()
+	case ieLib.UpdateFAR:
+		params, err = far.UpdateForwardingParameters()
+	default:
+		return "", 0
+	}
+
+	if err != nil {
+		return "", 0
+	}
+
+	for _, i := range params {
+		if i.Type != ieLib.OuterHeaderCreation {
+			continue
+		}
+
+		ohc, err := i.OuterHeaderCreation()
+		if err != nil {
+			return "", 0
+		}
+
+		switch {
+		case ohc.IPv4Address != nil && !ohc.IPv4Address.IsUnspecified():
+			return ohc.IPv4Address.String(), ohc.TEID
+		case ohc.IPv6Address != nil && !ohc.IPv6Address.IsUnspecified():
+			return ohc.IPv6Address.String(), ohc.TEID
+		}
+	}
+
+	return "", 0
+}
+
+// parseCreatedPDRs returns the content of the Created PDR IEs ies. Malformed IEs are skipped.
+// Addresses are copied, as they share the buffer the response was received in.
+func parseCreatedPDRs(ies []*ieLib.IE) []CreatedPDR {
+	created := make([]CreatedPDR, 0, len(ies))
+
+	for _, i := range ies {
+		id, err := i.PDRID()
+		if err != nil {
+			continue
+		}
+
+		pdr := CreatedPDR{PDRID: id}
+
+		if fteid, err := i.FTEID(); err == nil {
+			pdr.TEID = fteid.TEID
+			pdr.IPv4Address = slices.Clone(fteid.IPv4Address)
+			pdr.IPv6Address = slices.Clone(fteid.IPv6Address)
+		}
+
+		if ueIP, err := i.UEIPAddress(); err == nil {
+			pdr.UEAddress = slices.Clone(ueIP.IPv4Address)
+
+			if ueIP.IPv6Address != nil {
+				pdr.UEIPv6Prefix = ueIPv6Prefix(ueIP)
+			}
+		}
+
+		created = append(created, pdr)
+	}
+
+	return created
+}
+
+// forwardsTo reports whether the Create FAR far has dstInterface as Destination Interface.
+func forwardsTo(far *ieLib.IE, dstInterface uint8) bool {
+	params, err := far.ForwardingParameters()
+	if err != nil {
+		return false
+	}
+
+	for _, i := range params {
+		if i.Type != ieLib.DestinationInterface {
+			continue
+		}
+
+		if value, err := i.DestinationInterface(); err == nil && value == dstInterface {
+			return true
+		}
+	}
+
+	return false
+}
+
+// childrenOfType returns the IEs of type ieType directly grouped in the grouped IE parent.
+func childrenOfType(parent *ieLib.IE, ieType uint16) []*ieLib.IE {
+	children, err := ieLib.ParseMultiIEs(parent.Payload)
+	if err != nil {
+		return nil
+	}
+
+	return slices.DeleteFunc(children, func(i *ieLib.IE) bool {
+		return i.Type != ieType
+	})
+}
+
+// pdrID adapts (*ieLib.IE).PDRID to the signature used for the other rules.
+func pdrID(pdr *ieLib.IE) (uint32, error) {
+	id, err := pdr.PDRID()
+	return uint32(id), err
+}
+
+func appendUnique(list []string, value string) []string {
+	if slices.Contains(list, value) {
+		return list
+	}
+
+	return append(list, value)
 }
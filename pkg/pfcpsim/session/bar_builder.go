@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package session
+
+import (
+	"time"
+
+	"github.com/omec-project/pfcpsim/logger"
+	"github.com/wmnsk/go-pfcp/ie"
+)
+
+type barBuilder struct {
+	method                 IEMethod
+	barID                  uint8
+	notificationDelay      time.Duration
+	suggestedPacketsCount  uint8
+	isSuggestedPacketsSet  bool
+	isNotificationDelaySet bool
+}
+
+// NewBARBuilder returns a barBuilder.
+func NewBARBuilder() *barBuilder {
+	return &barBuilder{}
+}
+
+func (b *barBuilder) WithID(id uint8) *barBuilder {
+	b.barID = id
+	return b
+}
+
+func (b *barBuilder) WithMethod(method IEMethod) *barBuilder {
+	b.method = method
+	return b
+}
+
+// WithDownlinkDataNotificationDelay sets the delay the UPF waits before notifying the CP function
+// of the first downlink packet buffered for the session.
+func (b *barBuilder) WithDownlinkDataNotificationDelay(delay time.Duration) *barBuilder {
+	b.isNotificationDelaySet = true
+	b.notificationDelay = delay
+
+	return b
+}
+
+// WithSuggestedBufferingPacketsCount sets the number of downlink packets the UPF should buffer.
+func (b *barBuilder) WithSuggestedBufferingPacketsCount(count uint8) *barBuilder {
+	b.isSuggestedPacketsSet = true
+	b.suggestedPacketsCount = count
+
+	return b
+}
+
+func (b *barBuilder) validate() {
+	if b.barID == 0 {
+		logger.PfcpsimLog.Panicln("tried building BAR without setting BAR ID")
+	}
+}
+
+// Build returns a Create BAR, an Update BAR (within Session Modification Request) or a Remove BAR IE,
+// depending on the method.
+func (b *barBuilder) Build() *ie.IE {
+	if doCheck {
+		b.validate()
+	}
+
+	if b.method == Delete {
+		return ie.NewRemoveBAR(ie.NewBARID(b.barID))
+	}
+
+	ies := []*ie.IE{ie.NewBARID(b.barID)}
+
+	if b.isNotificationDelaySet {
+		ies = append(ies, ie.NewDownlinkDataNotificationDelay(b.notificationDelay))
+	}
+
+	if b.isSuggestedPacketsSet {
+		ies = append(ies, ie.NewSuggestedBufferingPacketsCount(b.suggestedPacketsCount))
+	}
+
+	if b.method == Update {
+		return ie.NewUpdateBARWithinSessionModificationRequest(ies...)
+	}
+
+	return ie.NewCreateBAR(ies...)
+}
@@ -15,6 +15,7 @@ type farBuilder struct {
 	teid         uint32
 	downlinkIP   string
 	dstInterface uint8
+	barID        uint8
 
 	zeroBasedOuterHeader bool
 	isActionSet          bool
@@ -90,6 +91,11 @@ func (b *farBuilder) WithDownlinkIP(downlinkIP string) *farBuilder {
 	return b
 }
 
+func (b *farBuilder) WithBARID(barID uint8) *farBuilder {
+	b.barID = barID
+	return b
+}
+
 func (b *farBuilder) validate() {
 	if b.farID == 0 {
 		logger.PfcpsimLog.Panicln("tried building FAR without setting FAR ID")
@@ -140,6 +146,10 @@ func (b *farBuilder) BuildFAR() *ie.IE {
 		fwdParams,
 	)
 
+	if b.barID != 0 {
+		far.Add(ie.NewBARID(b.barID))
+	}
+
 	if b.method == Delete {
 		return ie.NewRemoveFAR(far)
 	}
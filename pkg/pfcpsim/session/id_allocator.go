@@ -0,0 +1,168 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package session
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/omec-project/pfcpsim/logger"
+)
+
+// RuleType identifies the kind of rule an ID is allocated for.
+type RuleType uint8
+
+const (
+	RulePDR RuleType = iota
+	RuleFAR
+	RuleQER
+	RuleURR
+	RuleBAR
+
+	numRuleTypes
+)
+
+func (t RuleType) String() string {
+	switch t {
+	case RulePDR:
+		return "PDR"
+	case RuleFAR:
+		return "FAR"
+	case RuleQER:
+		return "QER"
+	case RuleURR:
+		return "URR"
+	case RuleBAR:
+		return "BAR"
+	default:
+		return fmt.Sprintf("RuleType(%d)", uint8(t))
+	}
+}
+
+// maxRuleIDs are the highest IDs of each rule type, bounded by the size of their ID IE.
+var maxRuleIDs = [numRuleTypes]uint32{
+	RulePDR: math.MaxUint16,
+	RuleFAR: math.MaxUint32,
+	RuleQER: math.MaxUint32,
+	RuleURR: math.MaxUint32,
+	RuleBAR: math.MaxUint8,
+}
+
+// IDAllocator allocates the rule IDs of a session. Rule IDs are scoped to the session (TS 29.244),
+// so every allocator starts from 1. A rule can be given a name: the same name always returns
+// the same ID, so that a rule and the rules referencing it agree on its ID.
+type IDAllocator struct {
+	lock  sync.Mutex
+	named [numRuleTypes]map[string]uint32
+	used  [numRuleTypes]map[uint32]struct{}
+	last  [numRuleTypes]uint32
+}
+
+// NewIDAllocator returns an IDAllocator with no ID in use.
+func NewIDAllocator() *IDAllocator {
+	a := &IDAllocator{}
+
+	for t := range numRuleTypes {
+		a.named[t] = make(map[string]uint32)
+		a.used[t] = make(map[uint32]struct{})
+	}
+
+	return a
+}
+
+// ID returns the ID of the rule of type t named name, allocating it on first use.
+func (a *IDAllocator) ID(t RuleType, name string) uint32 {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if id, ok := a.named[t][name]; ok {
+		return id
+	}
+
+	id := a.allocate(t)
+	a.named[t][name] = id
+
+	return id
+}
+
+// New returns an unused ID for a rule of type t.
+func (a *IDAllocator) New(t RuleType) uint32 {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return a.allocate(t)
+}
+
+// Lookup returns the ID allocated to the rule of type t named name, if any.
+func (a *IDAllocator) Lookup(t RuleType, name string) (uint32, bool) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	id, ok := a.named[t][name]
+
+	return id, ok
+}
+
+// Reserve marks id as used by a rule of type t whose ID was not allocated by a.
+func (a *IDAllocator) Reserve(t RuleType, id uint32) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.used[t][id] = struct{}{}
+}
+
+// Release makes id available again, e.g. after the rule is removed. Its name, if any, is forgotten.
+func (a *IDAllocator) Release(t RuleType, id uint32) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	delete(a.used[t], id)
+
+	for name, namedID := range a.named[t] {
+		if namedID == id {
+			delete(a.named[t], name)
+		}
+	}
+}
+
+// allocate returns the lowest unused ID following the last allocated one, wrapping around
+// once the highest ID is reached. The lock must be held.
+func (a *IDAllocator) allocate(t RuleType) uint32 {
+	maxID := maxRuleIDs[t]
+
+	if uint64(len(a.used[t])) >= uint64(maxID) {
+		logger.PfcpsimLog.Panicf("no %v IDs left", t)
+	}
+
+	id := a.last[t]
+
+	for {
+		if id == maxID {
+			id = 0
+		}
+
+		id++
+
+		if _, ok := a.used[t][id]; !ok {
+			break
+		}
+	}
+
+	a.used[t][id] = struct{}{}
+	a.last[t] = id
+
+	return id
+}
+
+// ruleName returns the name of a rule given the parts of its key, e.g. ("uplink", 0) is "uplink-0".
+func ruleName(key ...any) string {
+	parts := make([]string, 0, len(key))
+	for _, k := range key {
+		parts = append(parts, fmt.Sprint(k))
+	}
+
+	return strings.Join(parts, "-")
+}
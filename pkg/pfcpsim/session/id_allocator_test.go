@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package session
+
+import (
+	"math"
+	"testing"
+)
+
+func TestIDAllocator(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(a *IDAllocator) []uint32
+		want []uint32
+	}{
+		{
+			name: "named IDs are allocated once",
+			run: func(a *IDAllocator) []uint32 {
+				return []uint32{
+					a.ID(RuleFAR, "uplink-0"),
+					a.ID(RuleFAR, "downlink-0"),
+					a.ID(RuleFAR, "uplink-0"),
+				}
+			},
+			want: []uint32{1, 2, 1},
+		},
+		{
+			name: "rule types have separate IDs",
+			run: func(a *IDAllocator) []uint32 {
+				return []uint32{a.New(RulePDR), a.New(RuleFAR), a.New(RulePDR)}
+			},
+			want: []uint32{1, 1, 2},
+		},
+		{
+			name: "reserved IDs are skipped",
+			run: func(a *IDAllocator) []uint32 {
+				a.Reserve(RuleQER, 1)
+				a.Reserve(RuleQER, 3)
+
+				return []uint32{a.New(RuleQER), a.New(RuleQER)}
+			},
+			want: []uint32{2, 4},
+		},
+		{
+			name: "released IDs are reused after wrapping around",
+			run: func(a *IDAllocator) []uint32 {
+				for range math.MaxUint8 {
+					a.New(RuleBAR)
+				}
+
+				a.Release(RuleBAR, 10)
+
+				return []uint32{a.New(RuleBAR)}
+			},
+			want: []uint32{10},
+		},
+		{
+			name: "released names are forgotten",
+			run: func(a *IDAllocator) []uint32 {
+				id := a.ID(RuleURR, "periodic")
+				a.Release(RuleURR, id)
+
+				return []uint32{id, a.ID(RuleURR, "periodic")}
+			},
+			want: []uint32{1, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.run(NewIDAllocator())
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("IDs mismatch. got = %v, want = %v", got, tt.want)
+			}
+
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("IDs mismatch. got = %v, want = %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestIDAllocatorExhausted(t *testing.T) {
+	a := NewIDAllocator()
+
+	for range math.MaxUint8 {
+		a.New(RuleBAR)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("allocating more BAR IDs than available should panic")
+		}
+	}()
+
+	a.New(RuleBAR)
+}
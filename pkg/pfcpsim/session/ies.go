@@ -25,3 +25,23 @@ const (
 
 	S_TAG = 0x100 // Refer to table 8.2.56-1 in PFCP specs Release 16
 )
+
+// UE IP Address IE flags. Refer to section 8.2.62 in PFCP specs Release 16
+const (
+	UEIPAddressV6    uint8 = 0x01
+	UEIPAddressV4    uint8 = 0x02
+	UEIPAddressCHV4  uint8 = 0x10
+	UEIPAddressCHV6  uint8 = 0x20
+	UEIPAddressIP6PL uint8 = 0x40
+
+	// DefaultIPv6PrefixLength is the prefix length assumed when the IP6PL flag is not set
+	DefaultIPv6PrefixLength = 64
+)
+
+// F-TEID IE flags. Refer to section 8.2.3 in PFCP specs Release 16
+const (
+	fteidV4   uint8 = 0x01
+	fteidV6   uint8 = 0x02
+	fteidCH   uint8 = 0x04
+	fteidCHID uint8 = 0x08
+)
@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package session
+
+import (
+	"github.com/wmnsk/go-pfcp/ie"
+)
+
+// MergeRule returns the Create IE stored with the IEs of the Update IE update replacing the ones of the same
+// type. Update Forwarding Parameters are merged into Forwarding Parameters the same way, without the PFCPSMReq-Flags
+// that only apply to the modification carrying them.
+func MergeRule(stored, update *ie.IE) (*ie.IE, error) {
+	storedIEs, err := ie.ParseMultiIEs(stored.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	updateIEs, err := ie.ParseMultiIEs(update.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, u := range updateIEs {
+		switch u.Type {
+		case ie.PFCPSMReqFlags:
+			continue
+		case ie.UpdateForwardingParameters:
+			children, err := ie.ParseMultiIEs(u.Payload)
+			if err != nil {
+				return nil, err
+			}
+
+			u = ie.NewGroupedIE(ie.ForwardingParameters, children...)
+		}
+
+		replaced := false
+
+		for idx, s := range storedIEs {
+			if s.Type != u.Type {
+				continue
+			}
+
+			if u.Type == ie.ForwardingParameters {
+				merged, err := MergeRule(s, u)
+				if err != nil {
+					return nil, err
+				}
+
+				u = merged
+			}
+
+			storedIEs[idx] = u
+			replaced = true
+
+			break
+		}
+
+		if !replaced {
+			storedIEs = append(storedIEs, u)
+		}
+	}
+
+	return ie.NewGroupedIE(stored.Type, storedIEs...), nil
+}
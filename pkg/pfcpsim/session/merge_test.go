@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package session
+
+import (
+	"testing"
+
+	"github.com/wmnsk/go-pfcp/ie"
+)
+
+func TestMergeRule(t *testing.T) {
+	stored := NewFARBuilder().
+		WithID(1).
+		WithMethod(Create).
+		WithAction(ActionForward).
+		WithDstInterface(ie.DstInterfaceAccess).
+		WithTEID(101).
+		WithDownlinkIP("198.18.0.10").
+		BuildFAR()
+	update := NewFARBuilder().
+		WithID(1).
+		WithMethod(Update).
+		WithAction(ActionForward).
+		WithDstInterface(ie.DstInterfaceAccess).
+		WithTEID(500).
+		WithDownlinkIP("198.18.0.20").
+		BuildFAR()
+
+	merged, err := MergeRule(stored, update)
+	if err != nil {
+		t.Fatalf("MergeRule() error = %v", err)
+	}
+
+	if merged.Type != ie.CreateFAR {
+		t.Errorf("merged IE type mismatch. got = %v, want = %v", merged.Type, ie.CreateFAR)
+	}
+
+	params, err := merged.ForwardingParameters()
+	if err != nil {
+		t.Fatalf("merged FAR has no Forwarding Parameters: %v", err)
+	}
+
+	forwarding := ie.NewGroupedIE(ie.ForwardingParameters, params...)
+
+	ohc, err := forwarding.OuterHeaderCreation()
+	if err != nil {
+		t.Fatalf("merged FAR has no Outer Header Creation: %v", err)
+	}
+
+	if ohc.TEID != 500 || ohc.IPv4Address.String() != "198.18.0.20" {
+		t.Errorf("Outer Header Creation mismatch. got TEID = %v, address = %v", ohc.TEID, ohc.IPv4Address)
+	}
+
+	if _, err := forwarding.PFCPSMReqFlags(); err == nil {
+		t.Error("merged FAR should not carry the PFCPSMReq-Flags of the update")
+	}
+}
@@ -18,9 +18,20 @@ type pdrBuilder struct {
 	teid       uint32
 	farID      uint32
 
+	// chooseTEID lets the UPF allocate the F-TEID, shared by the PDRs with the same chooseID if hasChooseID
+	chooseTEID  bool
+	hasChooseID bool
+	chooseID    uint8
+
 	qerIDs []*ie.IE
+	urrIDs []*ie.IE
+
+	ueAddress    string
+	ueIPv6Prefix string
+	// chooseUEAddress and chooseUEIPv6Prefix let the UPF allocate the UE IPv4 address and IPv6 prefix
+	chooseUEAddress    bool
+	chooseUEIPv6Prefix bool
 
-	ueAddress string
 	n3Address string
 	direction direction
 }
@@ -86,6 +97,23 @@ func (b *pdrBuilder) WithTEID(teid uint32) *pdrBuilder {
 	return b
 }
 
+// WithFTEIDChoose lets the UPF allocate the F-TEID of the uplink PDR (CH flag). The allocated F-TEID
+// is returned in the Created PDR IE of the response. The N3 address only selects the IP version.
+func (b *pdrBuilder) WithFTEIDChoose() *pdrBuilder {
+	b.chooseTEID = true
+	return b
+}
+
+// WithFTEIDChooseID is like WithFTEIDChoose, with a Choose ID (CHID flag): the UPF allocates the same
+// F-TEID to the PDRs created by a request with the same Choose ID.
+func (b *pdrBuilder) WithFTEIDChooseID(chooseID uint8) *pdrBuilder {
+	b.chooseTEID = true
+	b.hasChooseID = true
+	b.chooseID = chooseID
+
+	return b
+}
+
 func (b *pdrBuilder) WithMethod(method IEMethod) *pdrBuilder {
 	b.method = method
 	return b
@@ -101,11 +129,37 @@ func (b *pdrBuilder) WithUEAddress(ueAddress string) *pdrBuilder {
 	return b
 }
 
+// WithUEIPv6Prefix sets the IPv6 prefix delegated to the UE in CIDR notation (e.g. 2001:db8:0:1::/64).
+// It can be combined with WithUEAddress for dual-stack sessions.
+func (b *pdrBuilder) WithUEIPv6Prefix(prefix string) *pdrBuilder {
+	b.ueIPv6Prefix = prefix
+	return b
+}
+
+// WithUEAddressChoose lets the UPF allocate the UE IPv4 address of the downlink PDR (CHV4 flag).
+// The allocated address is returned in the Created PDR IE of the response.
+func (b *pdrBuilder) WithUEAddressChoose() *pdrBuilder {
+	b.chooseUEAddress = true
+	return b
+}
+
+// WithUEIPv6PrefixChoose lets the UPF allocate the UE IPv6 prefix of the downlink PDR (CHV6 flag).
+// It can be combined with WithUEAddressChoose for dual-stack sessions.
+func (b *pdrBuilder) WithUEIPv6PrefixChoose() *pdrBuilder {
+	b.chooseUEIPv6Prefix = true
+	return b
+}
+
 func (b *pdrBuilder) AddQERID(qerID uint32) *pdrBuilder {
 	b.qerIDs = append(b.qerIDs, ie.NewQERID(qerID))
 	return b
 }
 
+func (b *pdrBuilder) AddURRID(urrID uint32) *pdrBuilder {
+	b.urrIDs = append(b.urrIDs, ie.NewURRID(urrID))
+	return b
+}
+
 func (b *pdrBuilder) WithFARID(farID uint32) *pdrBuilder {
 	b.farID = farID
 	return b
@@ -135,12 +189,18 @@ func (b *pdrBuilder) validate() {
 	}
 
 	if b.direction == downlink {
-		if b.ueAddress == "" {
+		if b.ueAddress == "" && b.ueIPv6Prefix == "" && !b.chooseUEAddress && !b.chooseUEIPv6Prefix {
 			logger.PfcpsimLog.Panicln("tried building downlink PDR without setting the UE IP address")
 		}
+
+		if b.ueIPv6Prefix != "" && !b.chooseUEIPv6Prefix {
+			if _, _, err := net.ParseCIDR(b.ueIPv6Prefix); err != nil {
+				logger.PfcpsimLog.Panicln("tried building downlink PDR with an invalid UE IPv6 prefix")
+			}
+		}
 	}
 
-	if b.direction == uplink {
+	if b.direction == uplink && !b.chooseTEID {
 		if b.n3Address == "" {
 			logger.PfcpsimLog.Panicln("tried building uplink PDR without setting the N3Address")
 		}
@@ -151,6 +211,64 @@ func (b *pdrBuilder) validate() {
 	}
 }
 
+// newFTEID returns the F-TEID IE of the uplink PDR, either set or to be allocated by the UPF.
+// The V4 or V6 flag is set according to the version of the N3 address.
+func (b *pdrBuilder) newFTEID() *ie.IE {
+	ip := net.ParseIP(b.n3Address)
+	isIPv6 := ip != nil && ip.To4() == nil
+
+	if !b.chooseTEID {
+		if isIPv6 {
+			return ie.NewFTEID(fteidV6, b.teid, nil, ip, 0)
+		}
+
+		return ie.NewFTEID(fteidV4, b.teid, ip, nil, 0)
+	}
+
+	flags := fteidCH | fteidV4
+	if isIPv6 {
+		flags = fteidCH | fteidV6
+	}
+
+	if b.hasChooseID {
+		flags |= fteidCHID
+	}
+
+	return ie.NewFTEID(flags, 0, nil, nil, b.chooseID)
+}
+
+// newUEIPAddress returns the UE IP Address IE carrying the UE IPv4 address, the UE IPv6 prefix or both.
+// Addresses allocated by the UPF are left out, only their CHV4 or CHV6 flag is set.
+func (b *pdrBuilder) newUEIPAddress() *ie.IE {
+	var (
+		flags     uint8
+		v4, v6    string
+		prefixLen uint8
+	)
+
+	switch {
+	case b.chooseUEAddress:
+		flags |= UEIPAddressV4 | UEIPAddressCHV4
+	case b.ueAddress != "":
+		flags |= UEIPAddressV4
+		v4 = b.ueAddress
+	}
+
+	if b.chooseUEIPv6Prefix {
+		flags |= UEIPAddressV6 | UEIPAddressCHV6
+	} else if _, prefix, err := net.ParseCIDR(b.ueIPv6Prefix); err == nil {
+		flags |= UEIPAddressV6
+		v6 = prefix.IP.String()
+
+		if ones, _ := prefix.Mask.Size(); ones != DefaultIPv6PrefixLength {
+			flags |= UEIPAddressIP6PL
+			prefixLen = uint8(ones)
+		}
+	}
+
+	return ie.NewUEIPAddress(flags, v4, v6, 0, prefixLen)
+}
+
 func newRemovePDR(pdr *ie.IE) *ie.IE {
 	return ie.NewRemovePDR(pdr)
 }
@@ -170,7 +288,7 @@ func (b *pdrBuilder) BuildPDR() *ie.IE {
 	if b.direction == downlink {
 		pdi := ie.NewPDI(
 			ie.NewSourceInterface(ie.SrcInterfaceCore),
-			ie.NewUEIPAddress(0x2, b.ueAddress, "", 0, 0),
+			b.newUEIPAddress(),
 		)
 
 		if b.sdfFilter != "" {
@@ -185,6 +303,7 @@ func (b *pdrBuilder) BuildPDR() *ie.IE {
 
 		pdr.Add(pdi)
 		pdr.Add(b.qerIDs...)
+		pdr.Add(b.urrIDs...)
 
 		if b.method == Delete {
 			return newRemovePDR(pdr)
@@ -196,7 +315,7 @@ func (b *pdrBuilder) BuildPDR() *ie.IE {
 	// UplinkPDR
 	pdi := ie.NewPDI(
 		ie.NewSourceInterface(ie.SrcInterfaceAccess),
-		ie.NewFTEID(0x01, b.teid, net.ParseIP(b.n3Address), nil, 0),
+		b.newFTEID(),
 	)
 
 	if b.sdfFilter != "" {
@@ -212,6 +331,7 @@ func (b *pdrBuilder) BuildPDR() *ie.IE {
 
 	pdr.Add(pdi)
 	pdr.Add(b.qerIDs...)
+	pdr.Add(b.urrIDs...)
 
 	if b.method == Delete {
 		newRemovePDR(pdr)
@@ -133,6 +133,76 @@ func TestPDRBuilder(t *testing.T) {
 			),
 			description: "Valid Create Uplink PDR",
 		},
+		{
+			input: NewPDRBuilder().
+				WithID(1).
+				WithPrecedence(2).
+				WithTEID(100).
+				WithMethod(Create).
+				WithN3Address("2001:db8::1").
+				WithFARID(3).
+				AddQERID(4).
+				WithSDFFilter("permit ip any to assigned").
+				MarkAsUplink(),
+			expected: ie.NewCreatePDR(
+				ie.NewPDRID(1),
+				ie.NewPrecedence(2),
+				ie.NewOuterHeaderRemoval(0, 0),
+				ie.NewFARID(3),
+				ie.NewPDI(
+					ie.NewSourceInterface(ie.SrcInterfaceAccess),
+					ie.NewFTEID(0x02, 100, nil, net.ParseIP("2001:db8::1"), 0),
+					ie.NewSDFFilter("permit ip any to assigned", "", "", "", 1),
+				),
+				ie.NewQERID(4),
+			),
+			description: "Valid Create Uplink PDR with IPv6 N3 address",
+		},
+		{
+			input: NewPDRBuilder().
+				WithID(1).
+				WithPrecedence(2).
+				WithFTEIDChoose().
+				WithMethod(Create).
+				WithFARID(3).
+				AddQERID(4).
+				MarkAsUplink(),
+			expected: ie.NewCreatePDR(
+				ie.NewPDRID(1),
+				ie.NewPrecedence(2),
+				ie.NewOuterHeaderRemoval(0, 0),
+				ie.NewFARID(3),
+				ie.NewPDI(
+					ie.NewSourceInterface(ie.SrcInterfaceAccess),
+					ie.NewFTEID(0x05, 0, nil, nil, 0),
+				),
+				ie.NewQERID(4),
+			),
+			description: "Valid Create Uplink PDR with F-TEID allocated by the UPF",
+		},
+		{
+			input: NewPDRBuilder().
+				WithID(1).
+				WithPrecedence(2).
+				WithFTEIDChooseID(7).
+				WithN3Address("2001:db8::1").
+				WithMethod(Create).
+				WithFARID(3).
+				AddQERID(4).
+				MarkAsUplink(),
+			expected: ie.NewCreatePDR(
+				ie.NewPDRID(1),
+				ie.NewPrecedence(2),
+				ie.NewOuterHeaderRemoval(0, 0),
+				ie.NewFARID(3),
+				ie.NewPDI(
+					ie.NewSourceInterface(ie.SrcInterfaceAccess),
+					ie.NewFTEID(0x0e, 0, nil, nil, 7),
+				),
+				ie.NewQERID(4),
+			),
+			description: "Valid Create Uplink PDR with IPv6 F-TEID and Choose ID",
+		},
 		{
 			input: NewPDRBuilder().
 				WithID(1).
@@ -179,6 +249,71 @@ func TestPDRBuilder(t *testing.T) {
 			),
 			description: "Valid Update Downlink PDR no SDF",
 		},
+		{
+			input: NewPDRBuilder().
+				WithID(1).
+				WithPrecedence(2).
+				WithUEIPv6Prefix("2001:db8:0:1::/64").
+				WithMethod(Create).
+				WithFARID(3).
+				AddQERID(4).
+				MarkAsDownlink(),
+			expected: ie.NewCreatePDR(
+				ie.NewPDRID(1),
+				ie.NewPrecedence(2),
+				ie.NewFARID(3),
+				ie.NewPDI(
+					ie.NewSourceInterface(ie.SrcInterfaceCore),
+					ie.NewUEIPAddress(0x1, "", "2001:db8:0:1::", 0, 0),
+				),
+				ie.NewQERID(4),
+			),
+			description: "Valid Create Downlink PDR IPv6",
+		},
+		{
+			input: NewPDRBuilder().
+				WithID(1).
+				WithPrecedence(2).
+				WithUEAddress("172.16.0.1").
+				WithUEIPv6Prefix("2001:db8:0:1::/56").
+				WithMethod(Create).
+				WithFARID(3).
+				AddQERID(4).
+				MarkAsDownlink(),
+			expected: ie.NewCreatePDR(
+				ie.NewPDRID(1),
+				ie.NewPrecedence(2),
+				ie.NewFARID(3),
+				ie.NewPDI(
+					ie.NewSourceInterface(ie.SrcInterfaceCore),
+					ie.NewUEIPAddress(0x43, "172.16.0.1", "2001:db8::", 0, 56),
+				),
+				ie.NewQERID(4),
+			),
+			description: "Valid Create Downlink PDR dual-stack with prefix length",
+		},
+		{
+			input: NewPDRBuilder().
+				WithID(1).
+				WithPrecedence(2).
+				WithUEAddressChoose().
+				WithUEIPv6PrefixChoose().
+				WithMethod(Create).
+				WithFARID(3).
+				AddQERID(4).
+				MarkAsDownlink(),
+			expected: ie.NewCreatePDR(
+				ie.NewPDRID(1),
+				ie.NewPrecedence(2),
+				ie.NewFARID(3),
+				ie.NewPDI(
+					ie.NewSourceInterface(ie.SrcInterfaceCore),
+					ie.NewUEIPAddress(0x33, "", "", 0, 0),
+				),
+				ie.NewQERID(4),
+			),
+			description: "Valid Create Downlink PDR with UE addresses chosen by the UPF",
+		},
 		{
 			input: NewPDRBuilder().
 				WithID(1).
@@ -0,0 +1,656 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package session
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/wmnsk/go-pfcp/ie"
+	"go.yaml.in/yaml/v3"
+)
+
+// DefaultProfileName is the name of the built-in profile, used when no profile is selected.
+const DefaultProfileName = "default"
+
+//go:embed profiles/default.yaml
+var defaultProfile []byte
+
+// profileExtensions are the extensions of the files loaded by LoadProfiles.
+var profileExtensions = []string{".yaml", ".yml", ".json"}
+
+// Profile describes the rules of a session in YAML or JSON. The description is a text/template
+// rendered for every session with its Variables, so that addresses can be derived from them.
+// Rule IDs are allocated per session with the pdrID, farID, qerID, urrID and barID functions.
+// See profiles/default.yaml for an example.
+type Profile struct {
+	Name     string
+	template *template.Template
+}
+
+// Variables are the per-session values available in a profile template.
+type Variables struct {
+	// ID is the index of the session
+	ID   uint32
+	TEID uint32
+	// ChooseTEID is set when the UPF allocates the F-TEID of the uplink PDRs, TEID is 0 then
+	ChooseTEID   bool
+	UEAddress    string
+	UEIPv6Prefix string
+	// ChooseUEAddress and ChooseUEIPv6Prefix are set when the UPF allocates the UE IPv4 address
+	// and IPv6 prefix of the downlink PDRs, UEAddress and UEIPv6Prefix are empty then
+	ChooseUEAddress    bool
+	ChooseUEIPv6Prefix bool
+	GNBAddress         string
+	N3Address          string
+	QFI                uint8
+	AppFilters         []AppFilter
+}
+
+// AppFilter is an application filter, usually turned into a pair of uplink and downlink PDRs.
+type AppFilter struct {
+	SDFFilter string
+	// GateStatus is either "open" or "closed"
+	GateStatus string
+	Precedence uint32
+}
+
+// Rules are the IEs built from a profile.
+type Rules struct {
+	PDRs []*ie.IE
+	FARs []*ie.IE
+	QERs []*ie.IE
+	URRs []*ie.IE
+	// BAR is nil if the profile has no BAR. A session has at most one BAR.
+	BAR *ie.IE
+	// IDs holds the rule IDs in use, so that rules added later to the session get unused IDs
+	IDs *IDAllocator
+}
+
+type profileRules struct {
+	PDRs []pdrSpec `yaml:"pdrs"`
+	FARs []farSpec `yaml:"fars"`
+	QERs []qerSpec `yaml:"qers"`
+	URRs []urrSpec `yaml:"urrs"`
+	BAR  *barSpec  `yaml:"bar"`
+}
+
+type pdrSpec struct {
+	ID uint16 `yaml:"id"`
+	// Direction is either "uplink" or "downlink"
+	Direction  string `yaml:"direction"`
+	Precedence uint32 `yaml:"precedence"`
+	TEID       uint32 `yaml:"teid"`
+	N3Address  string `yaml:"n3Address"`
+	// ChooseTEID lets the UPF allocate the F-TEID of an uplink PDR. Uplink PDRs with the same ChooseID
+	// get the same F-TEID, setting ChooseID implies ChooseTEID
+	ChooseTEID   bool   `yaml:"chooseTEID"`
+	ChooseID     *uint8 `yaml:"chooseID"`
+	UEAddress    string `yaml:"ueAddress"`
+	UEIPv6Prefix string `yaml:"ueIPv6Prefix"`
+	// ChooseUEAddress and ChooseUEIPv6Prefix let the UPF allocate the UE IPv4 address and IPv6 prefix
+	// of a downlink PDR, instead of using UEAddress and UEIPv6Prefix
+	ChooseUEAddress    bool     `yaml:"chooseUEAddress"`
+	ChooseUEIPv6Prefix bool     `yaml:"chooseUEIPv6Prefix"`
+	SDFFilter          string   `yaml:"sdfFilter"`
+	FARID              uint32   `yaml:"farID"`
+	QERIDs             []uint32 `yaml:"qerIDs"`
+	URRIDs             []uint32 `yaml:"urrIDs"`
+}
+
+type farSpec struct {
+	ID uint32 `yaml:"id"`
+	// Actions are any of "forward", "drop", "buffer" and "notify"
+	Actions []string `yaml:"actions"`
+	// DstInterface is one of "access", "core", "sgi-lan" and "cp-function"
+	DstInterface                 string `yaml:"dstInterface"`
+	TEID                         uint32 `yaml:"teid"`
+	DownlinkIP                   string `yaml:"downlinkIP"`
+	ZeroBasedOuterHeaderCreation bool   `yaml:"zeroBasedOuterHeaderCreation"`
+	BARID                        uint8  `yaml:"barID"`
+}
+
+type qerSpec struct {
+	ID          uint32 `yaml:"id"`
+	QFI         uint8  `yaml:"qfi"`
+	UplinkMBR   uint64 `yaml:"uplinkMBR"`
+	DownlinkMBR uint64 `yaml:"downlinkMBR"`
+	UplinkGBR   uint64 `yaml:"uplinkGBR"`
+	DownlinkGBR uint64 `yaml:"downlinkGBR"`
+	// GateStatus is either "open" (default) or "closed"
+	GateStatus string `yaml:"gateStatus"`
+}
+
+type urrSpec struct {
+	ID uint32 `yaml:"id"`
+	// MeasurementMethod are any of "event", "volume" and "duration"
+	MeasurementMethod []string      `yaml:"measurementMethod"`
+	MeasurementPeriod time.Duration `yaml:"measurementPeriod"`
+	// ReportingTriggers are names of the Reporting Triggers flags, see reportingTriggers
+	ReportingTriggers []string    `yaml:"reportingTriggers"`
+	VolumeThreshold   *volumeSpec `yaml:"volumeThreshold"`
+	VolumeQuota       *volumeSpec `yaml:"volumeQuota"`
+}
+
+type volumeSpec struct {
+	Total    uint64 `yaml:"total"`
+	Uplink   uint64 `yaml:"uplink"`
+	Downlink uint64 `yaml:"downlink"`
+}
+
+type barSpec struct {
+	ID                             uint8          `yaml:"id"`
+	DownlinkDataNotificationDelay  *time.Duration `yaml:"downlinkDataNotificationDelay"`
+	SuggestedBufferingPacketsCount *uint8         `yaml:"suggestedBufferingPacketsCount"`
+}
+
+var applyActions = map[string]uint8{
+	"forward": ActionForward,
+	"drop":    ActionDrop,
+	"buffer":  ActionBuffer,
+	"notify":  ActionNotify,
+}
+
+var dstInterfaces = map[string]uint8{
+	"access":      ie.DstInterfaceAccess,
+	"core":        ie.DstInterfaceCore,
+	"sgi-lan":     ie.DstInterfaceSGiLANN6LAN,
+	"cp-function": ie.DstInterfaceCPFunction,
+}
+
+var gateStatuses = map[string]uint8{
+	"":       ie.GateStatusOpen,
+	"open":   ie.GateStatusOpen,
+	"closed": ie.GateStatusClosed,
+}
+
+var reportingTriggers = map[string]uint32{
+	"periodic":          RPT_TRIG_PERIO,
+	"volumeThreshold":   RPT_TRIG_VOLTH,
+	"timeThreshold":     RPT_TRIG_TIMTH,
+	"quotaHoldingTime":  RPT_TRIG_QUHTI,
+	"startOfTraffic":    RPT_TRIG_START,
+	"stopOfTraffic":     RPT_TRIG_STOPT,
+	"droppedDLTraffic":  RPT_TRIG_DROTH,
+	"linkedUsage":       RPT_TRIG_LIUSA,
+	"volumeQuota":       RPT_TRIG_VOLQU,
+	"timeQuota":         RPT_TRIG_TIMQU,
+	"envelopeClosure":   RPT_TRIG_ENVCL,
+	"macAddresses":      RPT_TRIG_MACAR,
+	"eventThreshold":    RPT_TRIG_EVETH,
+	"eventQuota":        RPT_TRIG_EVEQU,
+	"ipMulticastJoin":   RPT_TRIG_IPMJL,
+	"quotaValidityTime": RPT_TRIG_QUVTI,
+	"reportEndMarker":   RPT_TRIG_REEMR,
+	"userPlaneInactive": RPT_TRIG_UPINT,
+}
+
+// profileFuncs are the functions available in profile templates, in addition to the text/template ones.
+// Arithmetic functions accept any integer, e.g. {{add .ID (mul $i 2)}}.
+// The ID functions are bound to the allocator of the session when the profile is built, see idFuncs.
+var profileFuncs = template.FuncMap{
+	"add":   arithmetic(func(a, b int64) int64 { return a + b }),
+	"sub":   arithmetic(func(a, b int64) int64 { return a - b }),
+	"mul":   arithmetic(func(a, b int64) int64 { return a * b }),
+	"quote": strconv.Quote,
+	"pdrID": func(...any) uint32 { return 0 },
+	"farID": func(...any) uint32 { return 0 },
+	"qerID": func(...any) uint32 { return 0 },
+	"urrID": func(...any) uint32 { return 0 },
+	"barID": func(...any) uint32 { return 0 },
+}
+
+// idFuncs returns the template functions allocating rule IDs with ids. The arguments form the name
+// of the rule, e.g. {{farID "uplink" $i}}: the same name returns the same ID.
+func idFuncs(ids *IDAllocator) template.FuncMap {
+	idFunc := func(t RuleType) func(...any) uint32 {
+		return func(key ...any) uint32 {
+			return ids.ID(t, ruleName(key...))
+		}
+	}
+
+	return template.FuncMap{
+		"pdrID": idFunc(RulePDR),
+		"farID": idFunc(RuleFAR),
+		"qerID": idFunc(RuleQER),
+		"urrID": idFunc(RuleURR),
+		"barID": idFunc(RuleBAR),
+	}
+}
+
+func arithmetic(op func(a, b int64) int64) func(a, b any) (int64, error) {
+	return func(a, b any) (int64, error) {
+		x, err := toInt64(a)
+		if err != nil {
+			return 0, err
+		}
+
+		y, err := toInt64(b)
+		if err != nil {
+			return 0, err
+		}
+
+		return op(x, y), nil
+	}
+}
+
+func toInt64(v any) (int64, error) {
+	value := reflect.ValueOf(v)
+
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(value.Uint()), nil
+	default:
+		return 0, fmt.Errorf("%v is not an integer", v)
+	}
+}
+
+// sampleVariables are used to validate a profile when it is parsed.
+var sampleVariables = Variables{
+	ID:           1,
+	TEID:         1,
+	UEAddress:    "17.0.0.1",
+	UEIPv6Prefix: "2001:db8:17::/64",
+	GNBAddress:   "198.18.0.10",
+	N3Address:    "198.18.0.1",
+	AppFilters:   []AppFilter{{SDFFilter: "permit out ip from any to assigned", GateStatus: "open", Precedence: 100}},
+}
+
+// ParseProfile parses the profile description in data. The description is validated by building
+// the rules of a sample session.
+func ParseProfile(name string, data []byte) (*Profile, error) {
+	tmpl, err := template.New(name).Funcs(profileFuncs).Option("missingkey=error").Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("profile %v: %w", name, err)
+	}
+
+	profile := &Profile{Name: name, template: tmpl}
+
+	if _, err := profile.Build(sampleVariables); err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// DefaultProfile returns the built-in profile.
+var DefaultProfile = sync.OnceValue(func() *Profile {
+	profile, err := ParseProfile(DefaultProfileName, defaultProfile)
+	if err != nil {
+		panic(err)
+	}
+
+	return profile
+})
+
+// LoadProfiles parses every YAML or JSON file in dir. Profiles are named after the file, without extension.
+func LoadProfiles(dir string) (map[string]*Profile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := make(map[string]*Profile)
+
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || !isProfileExtension(ext) {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		name := strings.TrimSuffix(entry.Name(), ext)
+		if _, ok := profiles[name]; ok {
+			return nil, fmt.Errorf("profile %v defined more than once in %v", name, dir)
+		}
+
+		profile, err := ParseProfile(name, data)
+		if err != nil {
+			return nil, err
+		}
+
+		profiles[name] = profile
+	}
+
+	return profiles, nil
+}
+
+func isProfileExtension(ext string) bool {
+	for _, e := range profileExtensions {
+		if strings.EqualFold(ext, e) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Build renders the profile with vars and returns the Create IEs of the session.
+func (p *Profile) Build(vars Variables) (rules *Rules, err error) {
+	// builders and the ID allocator panic on invalid rules
+	defer func() {
+		if r := recover(); r != nil {
+			rules = nil
+			err = fmt.Errorf("profile %v: %v", p.Name, r)
+		}
+	}()
+
+	tmpl, err := p.template.Clone()
+	if err != nil {
+		return nil, fmt.Errorf("profile %v: %w", p.Name, err)
+	}
+
+	ids := NewIDAllocator()
+	tmpl.Funcs(idFuncs(ids))
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, vars); err != nil {
+		return nil, fmt.Errorf("profile %v: %w", p.Name, err)
+	}
+
+	var specs profileRules
+
+	decoder := yaml.NewDecoder(&buf)
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(&specs); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("profile %v: %w", p.Name, err)
+	}
+
+	rules, err = specs.build(ids)
+	if err != nil {
+		return nil, fmt.Errorf("profile %v: %w", p.Name, err)
+	}
+
+	return rules, nil
+}
+
+// build returns the IEs described by r. The IDs of the rules are reserved in ids, as they may be
+// set explicitly instead of being allocated.
+func (r *profileRules) build(ids *IDAllocator) (*Rules, error) {
+	rules := &Rules{IDs: ids}
+
+	seen := make(map[RuleType]map[uint32]bool, numRuleTypes)
+
+	checkID := func(t RuleType, id uint32) error {
+		if seen[t] == nil {
+			seen[t] = make(map[uint32]bool)
+		}
+
+		if seen[t][id] {
+			return fmt.Errorf("%v ID %v used more than once", t, id)
+		}
+
+		seen[t][id] = true
+		ids.Reserve(t, id)
+
+		return nil
+	}
+
+	for _, spec := range r.PDRs {
+		if err := checkID(RulePDR, uint32(spec.ID)); err != nil {
+			return nil, err
+		}
+
+		pdr, err := spec.build()
+		if err != nil {
+			return nil, fmt.Errorf("PDR %v: %w", spec.ID, err)
+		}
+
+		rules.PDRs = append(rules.PDRs, pdr)
+	}
+
+	for _, spec := range r.FARs {
+		if err := checkID(RuleFAR, spec.ID); err != nil {
+			return nil, err
+		}
+
+		far, err := spec.build()
+		if err != nil {
+			return nil, fmt.Errorf("FAR %v: %w", spec.ID, err)
+		}
+
+		rules.FARs = append(rules.FARs, far)
+	}
+
+	for _, spec := range r.QERs {
+		if err := checkID(RuleQER, spec.ID); err != nil {
+			return nil, err
+		}
+
+		qer, err := spec.build()
+		if err != nil {
+			return nil, fmt.Errorf("QER %v: %w", spec.ID, err)
+		}
+
+		rules.QERs = append(rules.QERs, qer)
+	}
+
+	for _, spec := range r.URRs {
+		if err := checkID(RuleURR, spec.ID); err != nil {
+			return nil, err
+		}
+
+		urr, err := spec.build()
+		if err != nil {
+			return nil, fmt.Errorf("URR %v: %w", spec.ID, err)
+		}
+
+		rules.URRs = append(rules.URRs, urr)
+	}
+
+	if r.BAR != nil {
+		if err := checkID(RuleBAR, uint32(r.BAR.ID)); err != nil {
+			return nil, err
+		}
+
+		rules.BAR = r.BAR.build()
+	}
+
+	return rules, nil
+}
+
+func (s *pdrSpec) build() (*ie.IE, error) {
+	b := NewPDRBuilder().
+		WithID(s.ID).
+		WithMethod(Create).
+		WithPrecedence(s.Precedence).
+		WithSDFFilter(s.SDFFilter).
+		WithFARID(s.FARID)
+
+	switch s.Direction {
+	case "uplink":
+		b.MarkAsUplink().
+			WithTEID(s.TEID).
+			WithN3Address(s.N3Address)
+
+		switch {
+		case s.ChooseID != nil:
+			b.WithFTEIDChooseID(*s.ChooseID)
+		case s.ChooseTEID:
+			b.WithFTEIDChoose()
+		}
+	case "downlink":
+		b.MarkAsDownlink().
+			WithUEAddress(s.UEAddress).
+			WithUEIPv6Prefix(s.UEIPv6Prefix)
+
+		if s.ChooseUEAddress {
+			b.WithUEAddressChoose()
+		}
+
+		if s.ChooseUEIPv6Prefix {
+			b.WithUEIPv6PrefixChoose()
+		}
+	default:
+		return nil, fmt.Errorf("unknown direction %q", s.Direction)
+	}
+
+	for _, id := range s.QERIDs {
+		b.AddQERID(id)
+	}
+
+	for _, id := range s.URRIDs {
+		b.AddURRID(id)
+	}
+
+	return b.BuildPDR(), nil
+}
+
+func (s *farSpec) build() (*ie.IE, error) {
+	var actions uint8
+
+	for _, name := range s.Actions {
+		action, ok := applyActions[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown action %q", name)
+		}
+
+		actions |= action
+	}
+
+	dstInterface, ok := dstInterfaces[s.DstInterface]
+	if !ok {
+		return nil, fmt.Errorf("unknown destination interface %q", s.DstInterface)
+	}
+
+	b := NewFARBuilder().
+		WithID(s.ID).
+		WithMethod(Create).
+		WithAction(actions).
+		WithDstInterface(dstInterface).
+		WithTEID(s.TEID).
+		WithDownlinkIP(s.DownlinkIP).
+		WithBARID(s.BARID)
+
+	if s.ZeroBasedOuterHeaderCreation {
+		b.WithZeroBasedOuterHeaderCreation()
+	}
+
+	return b.BuildFAR(), nil
+}
+
+func (s *qerSpec) build() (*ie.IE, error) {
+	gateStatus, ok := gateStatuses[s.GateStatus]
+	if !ok {
+		return nil, fmt.Errorf("unknown gate status %q", s.GateStatus)
+	}
+
+	b := NewQERBuilder().
+		WithID(s.ID).
+		WithMethod(Create).
+		WithQFI(s.QFI).
+		WithGateStatus(gateStatus)
+
+	if s.UplinkMBR != 0 || s.DownlinkMBR != 0 {
+		b.WithUplinkMBR(s.UplinkMBR).WithDownlinkMBR(s.DownlinkMBR)
+	}
+
+	if s.UplinkGBR != 0 || s.DownlinkGBR != 0 {
+		b.WithUplinkGBR(s.UplinkGBR).WithDownlinkGBR(s.DownlinkGBR)
+	}
+
+	return b.Build(), nil
+}
+
+func (s *urrSpec) build() (*ie.IE, error) {
+	b := NewURRBuilder().
+		WithID(s.ID).
+		WithMethod(Create).
+		WithMeasurementPeriod(s.MeasurementPeriod)
+
+	if len(s.MeasurementMethod) > 0 {
+		var event, volume, duration int
+
+		for _, method := range s.MeasurementMethod {
+			switch method {
+			case "event":
+				event = 1
+			case "volume":
+				volume = 1
+			case "duration":
+				duration = 1
+			default:
+				return nil, fmt.Errorf("unknown measurement method %q", method)
+			}
+		}
+
+		b.WithMeasurementMethod(event, volume, duration)
+	}
+
+	var triggers uint32
+
+	for _, name := range s.ReportingTriggers {
+		trigger, ok := reportingTriggers[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown reporting trigger %q", name)
+		}
+
+		triggers |= trigger
+	}
+
+	b.WithReportingTrigger(ReportingTrigger{Flags: triggers})
+
+	if v := s.VolumeThreshold; v != nil {
+		b.WithVolumeThreshold(v.flags(), v.Total, v.Uplink, v.Downlink)
+	}
+
+	if v := s.VolumeQuota; v != nil {
+		b.WithVolumeQuota(v.flags(), v.Total, v.Uplink, v.Downlink)
+	}
+
+	return b.Build(), nil
+}
+
+// flags returns the flags of the Volume Threshold or Volume Quota IE, set for the non-zero volumes.
+func (v *volumeSpec) flags() uint8 {
+	var flags uint8
+
+	if v.Total != 0 {
+		flags |= TOVOL
+	}
+
+	if v.Uplink != 0 {
+		flags |= ULVOL
+	}
+
+	if v.Downlink != 0 {
+		flags |= DLVOL
+	}
+
+	return flags
+}
+
+func (s *barSpec) build() *ie.IE {
+	b := NewBARBuilder().
+		WithID(s.ID).
+		WithMethod(Create)
+
+	if s.DownlinkDataNotificationDelay != nil {
+		b.WithDownlinkDataNotificationDelay(*s.DownlinkDataNotificationDelay)
+	}
+
+	if s.SuggestedBufferingPacketsCount != nil {
+		b.WithSuggestedBufferingPacketsCount(*s.SuggestedBufferingPacketsCount)
+	}
+
+	return b.Build()
+}
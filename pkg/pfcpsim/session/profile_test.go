@@ -0,0 +1,250 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package session
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/wmnsk/go-pfcp/ie"
+)
+
+// defaultRules returns the rules the default profile is expected to build. Rule IDs are allocated
+// per session from 1, in the order they appear in the profile.
+func defaultRules(vars Variables) *Rules {
+	const sessionQERID = 1
+
+	rules := &Rules{
+		QERs: []*ie.IE{
+			NewQERBuilder().WithID(sessionQERID).WithMethod(Create).WithUplinkMBR(60000).WithDownlinkMBR(60000).Build(),
+		},
+	}
+
+	for i, f := range vars.AppFilters {
+		gateStatus := gateStatuses[f.GateStatus]
+
+		uplinkID, downlinkID := uint32(2*i+1), uint32(2*i+2)
+		uplinkQERID, downlinkQERID := uint32(2*i+2), uint32(2*i+3)
+
+		rules.URRs = append(rules.URRs,
+			NewURRBuilder().WithID(uplinkID).WithMethod(Create).
+				WithMeasurementMethod(0, 1, 0).
+				WithMeasurementPeriod(1*time.Second).
+				WithReportingTrigger(ReportingTrigger{Flags: RPT_TRIG_PERIO}).
+				Build(),
+			NewURRBuilder().WithID(downlinkID).WithMethod(Create).
+				WithMeasurementMethod(0, 1, 0).
+				WithMeasurementPeriod(1*time.Second).
+				WithReportingTrigger(ReportingTrigger{Flags: RPT_TRIG_VOLTH | RPT_TRIG_VOLQU}).
+				WithVolumeThreshold(7, 10000, 20000, 30000).
+				WithVolumeQuota(7, 10000, 20000, 30000).
+				Build(),
+		)
+
+		uplinkPDR := NewPDRBuilder().WithID(uint16(uplinkID)).WithMethod(Create).
+			WithTEID(vars.TEID).WithFARID(uplinkID).AddQERID(sessionQERID).AddQERID(uplinkQERID).
+			AddURRID(uplinkID).
+			WithN3Address(vars.N3Address).WithSDFFilter(f.SDFFilter).WithPrecedence(f.Precedence).
+			MarkAsUplink()
+
+		if vars.ChooseTEID {
+			uplinkPDR.WithFTEIDChooseID(1)
+		}
+
+		downlinkPDR := NewPDRBuilder().WithID(uint16(downlinkID)).WithMethod(Create).
+			WithPrecedence(f.Precedence).WithUEAddress(vars.UEAddress).WithUEIPv6Prefix(vars.UEIPv6Prefix).
+			WithSDFFilter(f.SDFFilter).AddQERID(sessionQERID).AddQERID(downlinkQERID).
+			AddURRID(downlinkID).WithFARID(downlinkID).
+			MarkAsDownlink()
+
+		if vars.ChooseUEAddress {
+			downlinkPDR.WithUEAddressChoose()
+		}
+
+		if vars.ChooseUEIPv6Prefix {
+			downlinkPDR.WithUEIPv6PrefixChoose()
+		}
+
+		rules.PDRs = append(rules.PDRs, uplinkPDR.BuildPDR(), downlinkPDR.BuildPDR())
+
+		rules.FARs = append(rules.FARs,
+			NewFARBuilder().WithID(uplinkID).WithAction(ActionForward).
+				WithDstInterface(ie.DstInterfaceCore).WithMethod(Create).BuildFAR(),
+			NewFARBuilder().WithID(downlinkID).WithAction(ActionDrop).WithMethod(Create).
+				WithDstInterface(ie.DstInterfaceAccess).WithZeroBasedOuterHeaderCreation().BuildFAR(),
+		)
+
+		for _, qerID := range []uint32{uplinkQERID, downlinkQERID} {
+			rules.QERs = append(rules.QERs,
+				NewQERBuilder().WithID(qerID).WithMethod(Create).WithQFI(vars.QFI).
+					WithUplinkMBR(50000).WithDownlinkMBR(30000).WithGateStatus(gateStatus).Build(),
+			)
+		}
+	}
+
+	return rules
+}
+
+func TestDefaultProfile(t *testing.T) {
+	// the UPF allocates either nothing or both the F-TEID and the UE addresses
+	for _, choose := range []bool{false, true} {
+		t.Run(fmt.Sprintf("choose=%v", choose), func(t *testing.T) {
+			vars := Variables{
+				ID:           11,
+				TEID:         11,
+				UEAddress:    "17.0.0.2",
+				UEIPv6Prefix: "2001:db8:17:1::/64",
+				N3Address:    "198.18.0.1",
+				QFI:          9,
+				AppFilters: []AppFilter{
+					{SDFFilter: "permit out ip from any to assigned", GateStatus: "open", Precedence: 100},
+					{SDFFilter: "permit out udp from 10.0.0.0/8 80-88 to assigned", GateStatus: "closed", Precedence: 200},
+				},
+			}
+
+			if choose {
+				vars.TEID, vars.UEAddress, vars.UEIPv6Prefix = 0, "", ""
+				vars.ChooseTEID, vars.ChooseUEAddress, vars.ChooseUEIPv6Prefix = true, true, true
+			}
+
+			got, err := DefaultProfile().Build(vars)
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+
+			want := defaultRules(vars)
+			want.IDs = got.IDs
+
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("default profile mismatch.\ngot = %+v\nwant = %+v", got, want)
+			}
+
+			if id, ok := got.IDs.Lookup(RuleFAR, "downlink-1"); !ok || id != 4 {
+				t.Errorf("downlink FAR of the second filter mismatch. got = %v, %v, want = 4", id, ok)
+			}
+
+			// rules added later to the session do not reuse the IDs in use
+			if id := got.IDs.New(RuleQER); id != 6 {
+				t.Errorf("next QER ID mismatch. got = %v, want = 6", id)
+			}
+		})
+	}
+}
+
+func TestParseProfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{
+			name: "valid profile with BAR",
+			data: `
+pdrs:
+  - {id: {{.ID}}, direction: uplink, teid: {{.TEID}}, n3Address: {{.N3Address}}, farID: 1, qerIDs: [1], urrIDs: [1]}
+fars:
+  - {id: 1, actions: [buffer, notify], dstInterface: access, barID: 1}
+qers:
+  - {id: 1, qfi: 5, uplinkGBR: 1000, downlinkGBR: 1000}
+urrs:
+  - {id: 1, measurementMethod: [duration], reportingTriggers: [timeThreshold]}
+bar:
+  id: 1
+  downlinkDataNotificationDelay: 100ms
+  suggestedBufferingPacketsCount: 10
+`,
+		},
+		{
+			name: "JSON profile",
+			data: `{"qers": [{"id": {{.ID}}, "gateStatus": "closed"}]}`,
+		},
+		{
+			name:    "invalid template",
+			data:    `qers: [{id: {{.ID}]`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown variable",
+			data:    `qers: [{id: {{.SessionID}}}]`,
+			wantErr: true,
+		},
+		{
+			name:    "duplicate ID",
+			data:    `qers: [{id: {{qerID "a"}}}, {id: 1}]`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown field",
+			data:    `qers: [{id: 1, mbr: 10}]`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown action",
+			data:    `fars: [{id: 1, actions: [reorder], dstInterface: core}]`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid rule",
+			data:    `pdrs: [{id: 1, direction: uplink, farID: 1, qerIDs: [1]}]`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseProfile("test", []byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseProfile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProfileBuildWithBAR(t *testing.T) {
+	profile, err := ParseProfile("buffering", []byte(`
+bar: {id: 2, downlinkDataNotificationDelay: 100ms}
+`))
+	if err != nil {
+		t.Fatalf("ParseProfile() error = %v", err)
+	}
+
+	rules, err := profile.Build(sampleVariables)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	want := ie.NewCreateBAR(ie.NewBARID(2), ie.NewDownlinkDataNotificationDelay(100*time.Millisecond))
+	if !reflect.DeepEqual(rules.BAR, want) {
+		t.Errorf("BAR mismatch. got = %+v, want = %+v", rules.BAR, want)
+	}
+}
+
+func TestLoadProfiles(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"gbr.yaml":   `qers: [{id: {{.ID}}, uplinkGBR: 1000, downlinkGBR: 1000}]`,
+		"drop.json":  `{"fars": [{"id": {{.ID}}, "actions": ["drop"], "dstInterface": "core"}]}`,
+		"README.txt": `not a profile`,
+	}
+
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	profiles, err := LoadProfiles(dir)
+	if err != nil {
+		t.Fatalf("LoadProfiles() error = %v", err)
+	}
+
+	if len(profiles) != 2 || profiles["gbr"] == nil || profiles["drop"] == nil {
+		t.Errorf("loaded profiles mismatch. got = %v", profiles)
+	}
+}
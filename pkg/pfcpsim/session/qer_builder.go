@@ -11,6 +11,7 @@ import (
 type qerBuilder struct {
 	method     IEMethod
 	qerID      uint32
+	isQFISet   bool
 	qfi        uint8
 	isMbrSet   bool
 	ulMbr      uint64
@@ -18,8 +19,12 @@ type qerBuilder struct {
 	isGbrSet   bool
 	ulGbr      uint64
 	dlGbr      uint64
+	isGateSet  bool
 	gateStatus uint8
 
+	// changesOnly omits the QFI and the gate status from an Update QER if they are not set
+	changesOnly bool
+
 	isIDSet bool
 }
 
@@ -73,6 +78,7 @@ func (b *qerBuilder) WithID(id uint32) *qerBuilder {
 }
 
 func (b *qerBuilder) WithQFI(qfi uint8) *qerBuilder {
+	b.isQFISet = true
 	b.qfi = qfi
 	return b
 }
@@ -106,11 +112,19 @@ func (b *qerBuilder) WithDownlinkGBR(dlGbr uint64) *qerBuilder {
 }
 
 func (b *qerBuilder) WithGateStatus(status uint8) *qerBuilder {
+	b.isGateSet = true
 	b.gateStatus = status
 
 	return b
 }
 
+// WithChangesOnly makes an Update QER carry the QFI and the gate status only if set with WithQFI and
+// WithGateStatus, so that the QER is updated without resetting them. By default, they are always included.
+func (b *qerBuilder) WithChangesOnly() *qerBuilder {
+	b.changesOnly = true
+	return b
+}
+
 func (b *qerBuilder) validate() {
 	if !b.isIDSet {
 		logger.PfcpsimLog.Panicln("tried to build a QER without setting the QER ID")
@@ -127,21 +141,37 @@ func (b *qerBuilder) Build() *ie.IE {
 		b.validate()
 	}
 
-	createFunc := ie.NewCreateQER
-	if b.method == Update {
-		createFunc = ie.NewUpdateQER
-	}
-
 	gate := ie.NewGateStatus(ie.GateStatusOpen, ie.GateStatusOpen)
 	if b.gateStatus == ie.GateStatusClosed {
 		gate = ie.NewGateStatus(ie.GateStatusClosed, ie.GateStatusClosed)
 	}
 
-	qer := createFunc(
-		ie.NewQERID(b.qerID),
-		ie.NewQFI(b.qfi),
-		gate,
-	)
+	var qer *ie.IE
+
+	switch {
+	case b.method == Update && b.changesOnly:
+		qer = ie.NewUpdateQER(ie.NewQERID(b.qerID))
+
+		if b.isQFISet {
+			qer.Add(ie.NewQFI(b.qfi))
+		}
+
+		if b.isGateSet {
+			qer.Add(gate)
+		}
+	case b.method == Update:
+		qer = ie.NewUpdateQER(
+			ie.NewQERID(b.qerID),
+			ie.NewQFI(b.qfi),
+			gate,
+		)
+	default:
+		qer = ie.NewCreateQER(
+			ie.NewQERID(b.qerID),
+			ie.NewQFI(b.qfi),
+			gate,
+		)
+	}
 
 	if b.isMbrSet {
 		qer.Add(ie.NewMBR(b.ulMbr, b.dlMbr))
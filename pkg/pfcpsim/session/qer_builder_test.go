@@ -92,6 +92,19 @@ func TestQERBuilder(t *testing.T) {
 			),
 			description: "Valid Update QER",
 		},
+		{
+			input: NewQERBuilder().
+				WithID(1).
+				WithMethod(Update).
+				WithChangesOnly().
+				WithUplinkMBR(1000).
+				WithDownlinkMBR(2000),
+			expected: ie.NewUpdateQER(
+				ie.NewQERID(1),
+				ie.NewMBR(1000, 2000),
+			),
+			description: "Valid Update QER changing the MBR only",
+		},
 		{
 			input: NewQERBuilder().
 				WithID(1).
@@ -115,3 +115,9 @@ func newMeasurementMethod(mParams *measurementMethodParams) *ie.IE {
 
 	return ie.NewMeasurementMethod(mParams.event, mParams.volum, mParams.durat)
 }
+
+// NewQueryURR returns a Query URR IE, asking the UPF for an immediate usage report of the URR with ID urrID.
+// Refer to section 7.5.4.10 in PFCP specs Release 16
+func NewQueryURR(urrID uint32) *ie.IE {
+	return ie.NewQueryURR(ie.NewURRID(urrID))
+}
@@ -0,0 +1,163 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/omec-project/pfcpsim/pkg/pfcpsim/session"
+	ieLib "github.com/wmnsk/go-pfcp/ie"
+)
+
+func TestPFCPSessionState(t *testing.T) {
+	pdrs := []*ieLib.IE{
+		session.NewPDRBuilder().
+			WithID(1).
+			WithMethod(session.Create).
+			WithTEID(100).
+			WithFARID(1).
+			AddQERID(1).
+			WithN3Address("198.18.0.1").
+			MarkAsUplink().
+			BuildPDR(),
+		session.NewPDRBuilder().
+			WithID(2).
+			WithMethod(session.Create).
+			WithUEAddress("17.0.0.1").
+			WithUEIPv6Prefix("2001:db8:0:1::/64").
+			WithFARID(2).
+			AddQERID(1).
+			AddURRID(1).
+			MarkAsDownlink().
+			BuildPDR(),
+	}
+
+	fars := []*ieLib.IE{
+		session.NewFARBuilder().
+			WithID(1).
+			WithMethod(session.Create).
+			WithAction(session.ActionForward).
+			WithDstInterface(ieLib.DstInterfaceCore).
+			BuildFAR(),
+		session.NewFARBuilder().
+			WithID(2).
+			WithMethod(session.Create).
+			WithAction(session.ActionDrop).
+			WithDstInterface(ieLib.DstInterfaceAccess).
+			WithZeroBasedOuterHeaderCreation().
+			BuildFAR(),
+	}
+
+	qers := []*ieLib.IE{
+		session.NewQERBuilder().WithID(1).WithMethod(session.Create).Build(),
+	}
+
+	est := &establishment{
+		peerSEID:    10,
+		peerAddress: "198.18.0.1",
+		createdPDRs: []CreatedPDR{{PDRID: 1, TEID: 100, IPv4Address: net.ParseIP("198.18.0.1").To4()}},
+	}
+
+	sess := newPFCPSession(1, "127.0.0.1", est, pdrs, fars, qers, nil)
+
+	if sess.PeerSEID() != 10 || sess.PeerAddress() != "198.18.0.1" || sess.LocalAddress() != "127.0.0.1" {
+		t.Errorf("F-SEIDs mismatch. local = %v, peer = %v %v", sess.LocalAddress(), sess.PeerSEID(), sess.PeerAddress())
+	}
+
+	if got := sess.UEAddresses(); !reflect.DeepEqual(got, []string{"17.0.0.1", "2001:db8:0:1::/64"}) {
+		t.Errorf("UE addresses mismatch. got = %v", got)
+	}
+
+	if got := sess.UplinkTEID(); got != 100 {
+		t.Errorf("uplink TEID mismatch. got = %v, want = 100", got)
+	}
+
+	if got := sess.GNBAddress(); got != "" {
+		t.Errorf("gNB address should not be set before modification. got = %v", got)
+	}
+
+	farIDs, urrIDs := sess.DownlinkRules()
+	if !reflect.DeepEqual(farIDs, []uint32{2}) || !reflect.DeepEqual(urrIDs, []uint32{1}) {
+		t.Errorf("downlink rules mismatch. FAR IDs = %v, URR IDs = %v", farIDs, urrIDs)
+	}
+
+	if got := sess.SharedQERIDs(); !reflect.DeepEqual(got, []uint32{1}) {
+		t.Errorf("shared QER IDs mismatch. got = %v, want = [1]", got)
+	}
+
+	if teid, address := sess.UplinkFTEID(); teid != 100 || !address.Equal(net.ParseIP("198.18.0.1")) {
+		t.Errorf("uplink F-TEID mismatch. got = %v %v, want = 100 198.18.0.1", teid, address)
+	}
+
+	if got := sess.UplinkFARIDs(); !reflect.DeepEqual(got, []uint32{1}) {
+		t.Errorf("uplink FAR IDs mismatch. got = %v, want = [1]", got)
+	}
+
+	// PDRs without SDF filter match the wildcard application filter
+	if got := sess.AppFilterPDRIDs(""); !reflect.DeepEqual(got, []uint16{1, 2}) {
+		t.Errorf("application filter PDR IDs mismatch. got = %v, want = [1 2]", got)
+	}
+
+	// the QER shared with the uplink PDR is kept
+	wantRemove := []*ieLib.IE{
+		ieLib.NewRemovePDR(ieLib.NewPDRID(2)),
+		ieLib.NewRemoveFAR(ieLib.NewFARID(2)),
+		ieLib.NewRemoveURR(ieLib.NewURRID(1)),
+	}
+	if got := sess.RemoveIEs(2); !reflect.DeepEqual(got, wantRemove) {
+		t.Errorf("remove IEs mismatch. got = %v, want = %v", got, wantRemove)
+	}
+
+	ids := sess.IDAllocator()
+
+	createdAt := sess.CreatedAt()
+
+	sess.applyModification(
+		[]CreatedPDR{{PDRID: 3, TEID: 300}},
+		[]*ieLib.IE{
+			session.NewFARBuilder().
+				WithID(2).
+				WithMethod(session.Update).
+				WithAction(session.ActionForward).
+				WithDstInterface(ieLib.DstInterfaceAccess).
+				WithTEID(200).
+				WithDownlinkIP("198.18.0.10").
+				BuildFAR(),
+		},
+		[]*ieLib.IE{
+			session.NewQERBuilder().WithID(1).WithMethod(session.Delete).Build(),
+			session.NewQERBuilder().WithID(2).WithMethod(session.Create).Build(),
+		},
+	)
+
+	if got := sess.GNBAddress(); got != "198.18.0.10" {
+		t.Errorf("gNB address mismatch. got = %v, want = 198.18.0.10", got)
+	}
+
+	if got := sess.QERIDs(); !reflect.DeepEqual(got, []uint32{2}) {
+		t.Errorf("QER IDs mismatch. got = %v, want = [2]", got)
+	}
+
+	if got := ids.New(session.RuleQER); got != 1 {
+		t.Errorf("the ID of the removed QER should be reused. got = %v, want = 1", got)
+	}
+
+	if got := ids.New(session.RuleFAR); got != 3 {
+		t.Errorf("FAR ID mismatch. got = %v, want = 3", got)
+	}
+
+	if got := sess.PDRIDs(); !reflect.DeepEqual(got, []uint16{1, 2}) {
+		t.Errorf("PDR IDs mismatch. got = %v, want = [1 2]", got)
+	}
+
+	if got := sess.CreatedPDRs(); len(got) != 2 || got[0].TEID != 100 || got[1].PDRID != 3 || got[1].TEID != 300 {
+		t.Errorf("created PDRs mismatch. got = %v", got)
+	}
+
+	if !sess.CreatedAt().Equal(createdAt) || sess.ModifiedAt().Before(createdAt) {
+		t.Errorf("timestamps mismatch. created = %v, modified = %v", sess.CreatedAt(), sess.ModifiedAt())
+	}
+}
@@ -0,0 +1,20 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+// Stats holds the counters of a PFCPClient.
+type Stats struct {
+	// Retransmissions is the number of requests sent again after T1 expired
+	Retransmissions uint64
+	// Timeouts is the number of requests that got no response after N1 retransmissions
+	Timeouts uint64
+}
+
+// Stats returns a snapshot of the client counters.
+func (c *PFCPClient) Stats() Stats {
+	return Stats{
+		Retransmissions: c.retransmissions.Load(),
+		Timeouts:        c.timeouts.Load(),
+	}
+}
@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"sync"
+	"time"
+
+	"github.com/wmnsk/go-pfcp/message"
+)
+
+// completedRetention is the time a sequence number is remembered after its transaction
+// completes. A response received within this window is reported as duplicate.
+const completedRetention = 30 * time.Second
+
+type matchResult int
+
+const (
+	txUnmatched matchResult = iota
+	txMatched
+	txDuplicate
+)
+
+// transaction is an outstanding PFCP request waiting for its response.
+type transaction struct {
+	// req is kept to be retransmitted as-is, with the same sequence number
+	req message.Message
+	seq uint32
+	// rspType is the type of the response expected for req
+	rspType uint8
+	// seid is the SEID expected in the response header. 0 for node related messages.
+	seid uint64
+	// rspChan is where the response is delivered once received.
+	rspChan chan message.Message
+}
+
+func newTransaction(req message.Message, seid uint64, rspChan chan message.Message) *transaction {
+	if rspChan == nil {
+		rspChan = make(chan message.Message, 1)
+	}
+
+	return &transaction{
+		req:     req,
+		seq:     req.Sequence(),
+		rspType: req.MessageType() + 1,
+		seid:    seid,
+		rspChan: rspChan,
+	}
+}
+
+// completion records when the transaction with sequence number seq completed.
+type completion struct {
+	seq uint32
+	at  time.Time
+}
+
+// transactionTable correlates PFCP responses to outstanding requests by sequence number, message type and SEID.
+type transactionTable struct {
+	lock      sync.Mutex
+	pending   map[uint32]*transaction
+	completed map[uint32]time.Time
+	// completions holds the completed transactions oldest first, to expire them without scanning completed
+	completions []completion
+}
+
+func newTransactionTable() *transactionTable {
+	return &transactionTable{
+		pending:   make(map[uint32]*transaction),
+		completed: make(map[uint32]time.Time),
+	}
+}
+
+func (t *transactionTable) add(tx *transaction) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	delete(t.completed, tx.seq)
+	t.pending[tx.seq] = tx
+}
+
+// remove abandons the transaction identified by seq, e.g. after its timeout expired.
+func (t *transactionTable) remove(seq uint32) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	delete(t.pending, seq)
+}
+
+// reset drops every pending and completed transaction. Used when sequence numbers restart.
+func (t *transactionTable) reset() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.pending = make(map[uint32]*transaction)
+	t.completed = make(map[uint32]time.Time)
+	t.completions = nil
+}
+
+// expireCompleted forgets the transactions completed more than completedRetention ago.
+// Must be called with t.lock held.
+func (t *transactionTable) expireCompleted(now time.Time) {
+	expired := 0
+
+	for _, c := range t.completions {
+		if now.Sub(c.at) <= completedRetention {
+			break
+		}
+
+		// the sequence number may have been reused and completed again since
+		if t.completed[c.seq].Equal(c.at) {
+			delete(t.completed, c.seq)
+		}
+
+		expired++
+	}
+
+	t.completions = t.completions[expired:]
+}
+
+// pendingNum returns the number of outstanding transactions.
+func (t *transactionTable) pendingNum() int {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return len(t.pending)
+}
+
+// resolve looks up the transaction owning rsp. On match the transaction is removed
+// from the pending set and returned. A response of another type than the one expected for the request
+// does not match.
+func (t *transactionTable) resolve(rsp message.Message) (*transaction, matchResult) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := time.Now()
+	t.expireCompleted(now)
+
+	seq := rsp.Sequence()
+
+	tx, ok := t.pending[seq]
+	if !ok {
+		if _, ok := t.completed[seq]; ok {
+			return nil, txDuplicate
+		}
+
+		return nil, txUnmatched
+	}
+
+	if rsp.MessageType() != tx.rspType || (tx.seid != 0 && rsp.SEID() != tx.seid) {
+		return nil, txUnmatched
+	}
+
+	delete(t.pending, seq)
+	t.completed[seq] = now
+	t.completions = append(t.completions, completion{seq: seq, at: now})
+
+	return tx, txMatched
+}
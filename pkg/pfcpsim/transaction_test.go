@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"testing"
+	"time"
+
+	ieLib "github.com/wmnsk/go-pfcp/ie"
+	"github.com/wmnsk/go-pfcp/message"
+)
+
+func TestTransactionTableResolve(t *testing.T) {
+	type testCase struct {
+		description string
+		pending     []*transaction
+		responses   []message.Message
+		expected    []matchResult
+		remaining   int
+	}
+
+	accepted := ieLib.NewCause(ieLib.CauseRequestAccepted)
+
+	for _, scenario := range []testCase{
+		{
+			description: "Response matches outstanding request",
+			pending: []*transaction{
+				newTransaction(message.NewHeartbeatRequest(1, nil, nil), 0, nil),
+			},
+			responses: []message.Message{
+				message.NewHeartbeatResponse(1, nil),
+			},
+			expected: []matchResult{txMatched},
+		},
+		{
+			description: "Responses out of order",
+			pending: []*transaction{
+				newTransaction(message.NewSessionDeletionRequest(0, 0, 10, 1, 0), 1, nil),
+				newTransaction(message.NewSessionDeletionRequest(0, 0, 20, 2, 0), 2, nil),
+			},
+			responses: []message.Message{
+				message.NewSessionDeletionResponse(0, 0, 2, 2, 0, accepted),
+				message.NewSessionDeletionResponse(0, 0, 1, 1, 0, accepted),
+			},
+			expected: []matchResult{txMatched, txMatched},
+		},
+		{
+			description: "Duplicate response",
+			pending: []*transaction{
+				newTransaction(message.NewHeartbeatRequest(5, nil, nil), 0, nil),
+			},
+			responses: []message.Message{
+				message.NewHeartbeatResponse(5, nil),
+				message.NewHeartbeatResponse(5, nil),
+			},
+			expected: []matchResult{txMatched, txDuplicate},
+		},
+		{
+			description: "Unknown sequence number",
+			pending: []*transaction{
+				newTransaction(message.NewHeartbeatRequest(5, nil, nil), 0, nil),
+			},
+			responses: []message.Message{
+				message.NewHeartbeatResponse(6, nil),
+			},
+			expected:  []matchResult{txUnmatched},
+			remaining: 1,
+		},
+		{
+			description: "SEID mismatch",
+			pending: []*transaction{
+				newTransaction(message.NewSessionDeletionRequest(0, 0, 10, 1, 0), 1, nil),
+			},
+			responses: []message.Message{
+				message.NewSessionDeletionResponse(0, 0, 3, 1, 0, accepted),
+				message.NewSessionDeletionResponse(0, 0, 1, 1, 0, accepted),
+			},
+			expected: []matchResult{txUnmatched, txMatched},
+		},
+		{
+			description: "Message type mismatch",
+			pending: []*transaction{
+				newTransaction(message.NewSessionDeletionRequest(0, 0, 10, 1, 0), 1, nil),
+			},
+			responses: []message.Message{
+				message.NewSessionModificationResponse(0, 0, 1, 1, 0, accepted),
+				message.NewSessionDeletionResponse(0, 0, 1, 1, 0, accepted),
+			},
+			expected: []matchResult{txUnmatched, txMatched},
+		},
+	} {
+		t.Run(scenario.description, func(t *testing.T) {
+			table := newTransactionTable()
+
+			for _, tx := range scenario.pending {
+				table.add(tx)
+			}
+
+			for i, rsp := range scenario.responses {
+				tx, result := table.resolve(rsp)
+				if result != scenario.expected[i] {
+					t.Errorf("response %v: result mismatch. got = %v, want = %v", i, result, scenario.expected[i])
+				}
+
+				if result == txMatched && tx.seq != rsp.Sequence() {
+					t.Errorf("response %v: matched wrong transaction %v", i, tx.seq)
+				}
+			}
+
+			if table.pendingNum() != scenario.remaining {
+				t.Errorf("pending transactions mismatch. got = %v, want = %v", table.pendingNum(), scenario.remaining)
+			}
+		})
+	}
+}
+
+func TestTransactionTableRemove(t *testing.T) {
+	table := newTransactionTable()
+	table.add(newTransaction(message.NewHeartbeatRequest(1, nil, nil), 0, nil))
+	table.remove(1)
+
+	if _, result := table.resolve(message.NewHeartbeatResponse(1, nil)); result != txUnmatched {
+		t.Errorf("late response for abandoned transaction should be unmatched, got %v", result)
+	}
+}
+
+func TestTransactionTableExpireCompleted(t *testing.T) {
+	table := newTransactionTable()
+	table.add(newTransaction(message.NewHeartbeatRequest(1, nil, nil), 0, nil))
+	table.add(newTransaction(message.NewHeartbeatRequest(2, nil, nil), 0, nil))
+
+	for _, seq := range []uint32{1, 2} {
+		if _, result := table.resolve(message.NewHeartbeatResponse(seq, nil)); result != txMatched {
+			t.Fatalf("response %v should match, got %v", seq, result)
+		}
+	}
+
+	// age the first completion beyond the retention
+	expiredAt := time.Now().Add(-2 * completedRetention)
+	table.completed[1] = expiredAt
+	table.completions[0].at = expiredAt
+
+	if _, result := table.resolve(message.NewHeartbeatResponse(1, nil)); result != txUnmatched {
+		t.Errorf("response for expired transaction should be unmatched, got %v", result)
+	}
+
+	if _, result := table.resolve(message.NewHeartbeatResponse(2, nil)); result != txDuplicate {
+		t.Errorf("response for recently completed transaction should be duplicate, got %v", result)
+	}
+
+	if len(table.completed) != 1 || len(table.completions) != 1 {
+		t.Errorf("expired transaction should be forgotten, got %v completed", len(table.completed))
+	}
+}
@@ -0,0 +1,151 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"time"
+
+	"github.com/omec-project/pfcpsim/logger"
+	ieLib "github.com/wmnsk/go-pfcp/ie"
+)
+
+// maxUsageReports is the number of usage reports kept for each session. Older reports are discarded.
+const maxUsageReports = 256
+
+// usageReportTriggers names the bits of the Usage Report Trigger IE, octet by octet from bit 1.
+// Refer to section 8.2.41 in PFCP specs Release 16
+var usageReportTriggers = [][]string{
+	{"PERIO", "VOLTH", "TIMTH", "QUHTI", "START", "STOPT", "DROTH", "IMMER"},
+	{"VOLQU", "TIMQU", "LIUSA", "TERMR", "MONIT", "ENVCL", "MACAR", "EVETH"},
+	{"EVEQU", "TEBUR", "IPMJL", "QUVTI", "EMRRE", "UPINT"},
+}
+
+// VolumeMeasurement is the content of a Volume Measurement IE. Values not reported by the peer are 0.
+type VolumeMeasurement struct {
+	TotalVolume             uint64
+	UplinkVolume            uint64
+	DownlinkVolume          uint64
+	TotalNumberOfPackets    uint64
+	UplinkNumberOfPackets   uint64
+	DownlinkNumberOfPackets uint64
+}
+
+// UsageReport is a Usage Report IE received in a Session Report Request,
+// Session Modification Response or Session Deletion Response.
+type UsageReport struct {
+	URRID  uint32
+	URSEQN uint32
+	// Triggers are the names of the bits set in the Usage Report Trigger IE (e.g. PERIO, VOLTH).
+	Triggers []string
+	// Volume is nil if the report does not carry a Volume Measurement IE.
+	Volume    *VolumeMeasurement
+	Duration  time.Duration
+	StartTime time.Time
+	EndTime   time.Time
+	// ReceivedAt is the time pfcpsim received the report.
+	ReceivedAt time.Time
+}
+
+// parseUsageReport decodes a Usage Report grouped IE. Optional IEs missing from the report are left zero.
+func parseUsageReport(report *ieLib.IE) (UsageReport, error) {
+	ies, err := report.UsageReport()
+	if err != nil {
+		return UsageReport{}, err
+	}
+
+	ur := UsageReport{ReceivedAt: time.Now()}
+
+	for _, i := range ies {
+		switch i.Type {
+		case ieLib.URRID:
+			ur.URRID, err = i.URRID()
+		case ieLib.URSEQN:
+			ur.URSEQN, err = i.URSEQN()
+		case ieLib.UsageReportTrigger:
+			var trigger []byte
+
+			trigger, err = i.UsageReportTrigger()
+			ur.Triggers = parseUsageReportTrigger(trigger)
+		case ieLib.VolumeMeasurement:
+			var volume *ieLib.VolumeMeasurementFields
+
+			volume, err = i.VolumeMeasurement()
+			if err == nil {
+				ur.Volume = &VolumeMeasurement{
+					TotalVolume:             volume.TotalVolume,
+					UplinkVolume:            volume.UplinkVolume,
+					DownlinkVolume:          volume.DownlinkVolume,
+					TotalNumberOfPackets:    volume.TotalNumberOfPackets,
+					UplinkNumberOfPackets:   volume.UplinkNumberOfPackets,
+					DownlinkNumberOfPackets: volume.DownlinkNumberOfPackets,
+				}
+			}
+		case ieLib.DurationMeasurement:
+			ur.Duration, err = i.DurationMeasurement()
+		case ieLib.StartTime:
+			ur.StartTime, err = i.StartTime()
+		case ieLib.EndTime:
+			ur.EndTime, err = i.EndTime()
+		}
+
+		if err != nil {
+			return UsageReport{}, err
+		}
+	}
+
+	return ur, nil
+}
+
+func parseUsageReportTrigger(trigger []byte) []string {
+	var names []string
+
+	for octet, bits := range usageReportTriggers {
+		if octet >= len(trigger) {
+			break
+		}
+
+		for bit, name := range bits {
+			if trigger[octet]&(1<<bit) != 0 {
+				names = append(names, name)
+			}
+		}
+	}
+
+	return names
+}
+
+// parseUsageReports decodes every Usage Report IE in reports. Reports that cannot be decoded are returned as errors
+// alongside the successfully decoded ones.
+func parseUsageReports(reports []*ieLib.IE) ([]UsageReport, []error) {
+	var (
+		parsed []UsageReport
+		errs   []error
+	)
+
+	for _, report := range reports {
+		ur, err := parseUsageReport(report)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		parsed = append(parsed, ur)
+	}
+
+	return parsed, errs
+}
+
+// storeUsageReports decodes reports and attaches them to sess. Reports that cannot be decoded are logged and dropped.
+func storeUsageReports(sess *PFCPSession, reports []*ieLib.IE) {
+	if len(reports) == 0 {
+		return
+	}
+
+	parsed, errs := parseUsageReports(reports)
+	for _, err := range errs {
+		logger.PfcpsimLog.Warnf("could not decode usage report for session with local SEID %v: %v", sess.LocalSEID(), err)
+	}
+
+	sess.addUsageReports(parsed)
+}
@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	ieLib "github.com/wmnsk/go-pfcp/ie"
+)
+
+func TestParseUsageReport(t *testing.T) {
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(10 * time.Second)
+
+	type testCase struct {
+		description string
+		input       *ieLib.IE
+		expected    UsageReport
+		wantErr     bool
+	}
+
+	for _, scenario := range []testCase{
+		{
+			description: "Periodic report with volume measurement",
+			input: ieLib.NewUsageReportWithinSessionReportRequest(
+				ieLib.NewURRID(10),
+				ieLib.NewURSEQN(3),
+				ieLib.NewUsageReportTrigger(0x01, 0x00, 0x00),
+				ieLib.NewStartTime(start),
+				ieLib.NewEndTime(end),
+				ieLib.NewVolumeMeasurement(0x3f, 300, 100, 200, 30, 10, 20),
+				ieLib.NewDurationMeasurement(10*time.Second),
+			),
+			expected: UsageReport{
+				URRID:    10,
+				URSEQN:   3,
+				Triggers: []string{"PERIO"},
+				Volume: &VolumeMeasurement{
+					TotalVolume:             300,
+					UplinkVolume:            100,
+					DownlinkVolume:          200,
+					TotalNumberOfPackets:    30,
+					UplinkNumberOfPackets:   10,
+					DownlinkNumberOfPackets: 20,
+				},
+				Duration:  10 * time.Second,
+				StartTime: start,
+				EndTime:   end,
+			},
+		},
+		{
+			description: "Final report in Session Deletion Response",
+			input: ieLib.NewUsageReportWithinSessionDeletionResponse(
+				ieLib.NewURRID(11),
+				ieLib.NewURSEQN(0),
+				ieLib.NewUsageReportTrigger(0x02, 0x09, 0x00),
+			),
+			expected: UsageReport{
+				URRID:    11,
+				Triggers: []string{"VOLTH", "VOLQU", "TERMR"},
+			},
+		},
+		{
+			description: "Not a Usage Report",
+			input:       ieLib.NewURRID(1),
+			wantErr:     true,
+		},
+	} {
+		t.Run(scenario.description, func(t *testing.T) {
+			result, err := parseUsageReport(scenario.input)
+			if (err != nil) != scenario.wantErr {
+				t.Fatalf("parseUsageReport() error = %v, wantErr %v", err, scenario.wantErr)
+			}
+
+			if scenario.wantErr {
+				return
+			}
+
+			result.ReceivedAt = time.Time{}
+			result.StartTime = result.StartTime.UTC()
+			result.EndTime = result.EndTime.UTC()
+
+			if !reflect.DeepEqual(result, scenario.expected) {
+				t.Errorf("usage report mismatch. got = %+v, want = %+v", result, scenario.expected)
+			}
+		})
+	}
+}
+
+func TestSessionUsageReportsRetention(t *testing.T) {
+	sess := &PFCPSession{}
+
+	for i := 0; i < maxUsageReports+10; i++ {
+		sess.addUsageReports([]UsageReport{{URSEQN: uint32(i)}})
+	}
+
+	reports := sess.UsageReports()
+	if len(reports) != maxUsageReports {
+		t.Fatalf("stored usage reports mismatch. got = %v, want = %v", len(reports), maxUsageReports)
+	}
+
+	if reports[0].URSEQN != 10 {
+		t.Errorf("oldest usage report should have been discarded. got UR-SEQN = %v, want = 10", reports[0].URSEQN)
+	}
+}
@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"sync"
+)
+
+// inFlightWindow bounds the number of session transactions waiting for a response.
+type inFlightWindow struct {
+	lock  sync.Mutex
+	cond  *sync.Cond
+	size  int
+	inUse int
+}
+
+func newInFlightWindow(size int) *inFlightWindow {
+	w := &inFlightWindow{}
+	w.cond = sync.NewCond(&w.lock)
+	w.setSize(size)
+
+	return w
+}
+
+// setSize changes the window size. Values lower than 1 are treated as 1.
+func (w *inFlightWindow) setSize(size int) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.size = max(size, 1)
+
+	w.cond.Broadcast()
+}
+
+// acquire blocks until a slot is available in the window.
+func (w *inFlightWindow) acquire() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	for w.inUse >= w.size {
+		w.cond.Wait()
+	}
+
+	w.inUse++
+}
+
+func (w *inFlightWindow) release() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.inUse--
+
+	w.cond.Signal()
+}
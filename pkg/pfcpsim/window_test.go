@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022-present Open Networking Foundation
+
+package pfcpsim
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestInFlightWindow(t *testing.T) {
+	const (
+		size    = 3
+		callers = 20
+	)
+
+	var (
+		wg      sync.WaitGroup
+		current int32
+		peak    int32
+	)
+
+	window := newInFlightWindow(size)
+
+	for range callers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			window.acquire()
+			defer window.release()
+
+			n := atomic.AddInt32(&current, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&current, -1)
+		}()
+	}
+
+	wg.Wait()
+
+	if peak > size {
+		t.Errorf("in-flight window exceeded. got = %v, want <= %v", peak, size)
+	}
+}